@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/adaptive-enforcement-lab/readability/pkg/analyzer"
 	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/mkdocs"
 	"github.com/adaptive-enforcement-lab/readability/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +26,9 @@ var (
 	maxARIFlag         float64
 	maxLinesFlag       int
 	minAdmonitionsFlag int
+	failOnFlag         string
+	mkdocsFlag         string
+	goDocFlag          bool
 )
 
 func main() {
@@ -53,7 +58,10 @@ Examples:
   readability docs/ --format json
   readability docs/ --format markdown
   readability docs/ --check
-  readability docs/ --config .readability.yml`,
+  readability docs/ --check --fail-on error
+  readability docs/ --config .readability.yml
+  readability docs/ --mkdocs mkdocs.yml
+  readability pkg/ --go-doc`,
 		Args: cobra.ExactArgs(1),
 		RunE: run,
 	}
@@ -61,12 +69,15 @@ Examples:
 	rootCmd.Flags().StringVarP(&formatFlag, "format", "f", "table", "Output format: table, json, markdown, summary, report, diagnostic")
 	rootCmd.Flags().BoolVarP(&verboseFlag, "verbose", "v", false, "Show all metrics")
 	rootCmd.Flags().BoolVar(&checkFlag, "check", false, "Check against thresholds (exit 1 on failure)")
+	rootCmd.Flags().StringVar(&failOnFlag, "fail-on", "warning", "Lowest severity that fails --check: error, warning, info")
 	rootCmd.Flags().BoolVar(&validateConfigFlag, "validate-config", false, "Validate configuration and exit (no analysis)")
 	rootCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Path to config file (default: auto-detect .readability.yml)")
 	rootCmd.Flags().Float64Var(&maxGradeFlag, "max-grade", 0, "Maximum Flesch-Kincaid grade level (overrides config)")
 	rootCmd.Flags().Float64Var(&maxARIFlag, "max-ari", 0, "Maximum ARI score (overrides config)")
 	rootCmd.Flags().IntVar(&maxLinesFlag, "max-lines", 0, "Maximum lines per file (overrides config, 0 to disable)")
 	rootCmd.Flags().IntVar(&minAdmonitionsFlag, "min-admonitions", -1, "Minimum MkDocs-style admonitions (overrides config, 0 to disable)")
+	rootCmd.Flags().BoolVar(&goDocFlag, "go-doc", false, "Also analyze doc comments in .go files (overrides config)")
+	rootCmd.Flags().StringVar(&mkdocsFlag, "mkdocs", "", "Path to mkdocs.yml: report pages missing from nav and group output by nav section (overrides config)")
 
 	return rootCmd
 }
@@ -84,6 +95,10 @@ func run(cmd *cobra.Command, args []string) error {
 
 	path := args[0]
 
+	if _, ok := analyzer.ParseSeverity(failOnFlag); !ok {
+		return fmt.Errorf("invalid --fail-on value %q: must be error, warning, or info", failOnFlag)
+	}
+
 	cfg, err := loadConfig(path)
 	if err != nil {
 		return err
@@ -101,12 +116,18 @@ func run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	navProblems, err := checkNav(cfg, results)
+	if err != nil {
+		return err
+	}
+
 	if err := outputResults(results); err != nil {
 		return err
 	}
+	output.FileDiagnostics(os.Stderr, cfg.MkDocs, navProblems)
 
 	if checkFlag {
-		return checkResults(results, cfg)
+		return errors.Join(checkResults(results, cfg), checkNavProblems(navProblems))
 	}
 
 	return nil
@@ -186,6 +207,12 @@ func applyFlagOverrides(cmd *cobra.Command, cfg *config.Config) {
 	if cmd.Flags().Changed("min-admonitions") {
 		cfg.Thresholds.MinAdmonitions = minAdmonitionsFlag
 	}
+	if mkdocsFlag != "" {
+		cfg.MkDocs = mkdocsFlag
+	}
+	if goDocFlag {
+		cfg.GoDoc.Enabled = true
+	}
 }
 
 // analyzeTarget analyzes a file or directory and returns results.
@@ -212,22 +239,36 @@ func analyzeTarget(cfg *config.Config, path string) ([]*analyzer.Result, error)
 	return []*analyzer.Result{result}, nil
 }
 
+// checkNav compares results with the MkDocs nav when one is configured.
+// It returns problems found in mkdocs.yml itself, such as broken entries.
+func checkNav(cfg *config.Config, results []*analyzer.Result) ([]analyzer.Diagnostic, error) {
+	if cfg.MkDocs == "" {
+		return nil, nil
+	}
+	site, err := mkdocs.Load(cfg.MkDocs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load MkDocs config %s: %w", cfg.MkDocs, err)
+	}
+	return analyzer.NewWithConfig(cfg).CheckNav(results, site), nil
+}
+
 // outputResults writes results in the specified format.
 func outputResults(results []*analyzer.Result) error {
+	failOn := failOnLevel()
 	switch formatFlag {
 	case "json":
 		return output.JSON(os.Stdout, results)
 	case "markdown":
-		output.Markdown(os.Stdout, results)
+		output.Markdown(os.Stdout, results, failOn)
 	case "summary":
-		output.Summary(os.Stdout, results)
+		output.Summary(os.Stdout, results, failOn)
 	case "report":
-		output.Report(os.Stdout, results)
+		output.Report(os.Stdout, results, failOn)
 	case "diagnostic":
 		output.Diagnostic(os.Stdout, results)
 		output.DiagnosticSummary(os.Stdout, results)
 	default:
-		output.Table(os.Stdout, results, verboseFlag)
+		output.Table(os.Stdout, results, verboseFlag, failOn)
 	}
 	return nil
 }
@@ -244,6 +285,44 @@ func checkResults(results []*analyzer.Result, cfg *config.Config) error {
 	return fmt.Errorf("%d file(s) failed readability checks", stats.failed)
 }
 
+// checkNavProblems returns an error if any mkdocs.yml problem fails the check.
+func checkNavProblems(problems []analyzer.Diagnostic) error {
+	failOn := failOnLevel()
+
+	failed := 0
+	for _, d := range problems {
+		if severityRank(d.Severity) >= severityRank(failOn) {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d problem(s) in MkDocs nav", failed)
+}
+
+// failOnLevel returns the severity set by --fail-on, warning if it is not
+// valid.
+func failOnLevel() analyzer.Severity {
+	failOn, ok := analyzer.ParseSeverity(failOnFlag)
+	if !ok {
+		return analyzer.SeverityWarning
+	}
+	return failOn
+}
+
+// severityRank orders severities from info (lowest) to error (highest).
+func severityRank(s analyzer.Severity) int {
+	switch s {
+	case analyzer.SeverityError:
+		return 2
+	case analyzer.SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // failureStats holds counts of different failure types.
 type failureStats struct {
 	failed             int
@@ -257,8 +336,10 @@ func countFailures(results []*analyzer.Result, cfg *config.Config) failureStats
 	stats := failureStats{}
 	minAdm := cfg.Thresholds.MinAdmonitions
 
+	failOn := failOnLevel()
+
 	for _, r := range results {
-		if r.Status != "fail" {
+		if !r.FailsOn(failOn) {
 			continue
 		}
 		stats.failed++
@@ -300,7 +381,7 @@ func printReadabilityGuidance() {
 	fmt.Fprintln(os.Stderr, "READABILITY: High grade level indicates complex sentence structure or dense vocabulary.")
 	fmt.Fprintln(os.Stderr, "- Break long sentences into shorter ones (aim for 15-20 words per sentence)")
 	fmt.Fprintln(os.Stderr, "- Replace jargon with plain language where possible")
-	fmt.Fprintln(os.Stderr, "- Add brief introductory sentences before bullet lists, code blocks, or admonitions")
+	fmt.Fprintln(os.Stderr, "- Add brief introductory sentences before bullet lists, code blocks, or admonitions (see structure/lead-in)")
 	fmt.Fprintln(os.Stderr, "- Use transitional phrases to connect dense technical sections")
 	fmt.Fprintln(os.Stderr, "Do NOT remove technical content. Rewrite for clarity while preserving accuracy.")
 	fmt.Fprintln(os.Stderr, "")
@@ -319,6 +400,10 @@ func printAdmonitionGuidance() {
 	fmt.Fprintln(os.Stderr, "  !!! note \"Optional Title\"")
 	fmt.Fprintln(os.Stderr, "      Content indented by 4 spaces.")
 	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "On pages rendered by GitHub, use an alert instead:")
+	fmt.Fprintln(os.Stderr, "  > [!NOTE]")
+	fmt.Fprintln(os.Stderr, "  > Content on quoted lines.")
+	fmt.Fprintln(os.Stderr, "")
 	fmt.Fprintln(os.Stderr, "Do NOT add empty or meaningless admonitions. Add value with relevant context.")
 	fmt.Fprintln(os.Stderr, "")
 }
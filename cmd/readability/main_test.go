@@ -620,6 +620,9 @@ func resetFlags() {
 	maxARIFlag = 0
 	maxLinesFlag = 0
 	minAdmonitionsFlag = -1
+	failOnFlag = "warning"
+	mkdocsFlag = ""
+	goDocFlag = false
 }
 
 func TestNewRootCmd(t *testing.T) {
@@ -632,7 +635,7 @@ func TestNewRootCmd(t *testing.T) {
 	}
 
 	// Verify all flags are registered
-	flags := []string{"format", "verbose", "check", "validate-config", "config", "max-grade", "max-ari", "max-lines", "min-admonitions"}
+	flags := []string{"format", "verbose", "check", "fail-on", "validate-config", "config", "max-grade", "max-ari", "max-lines", "min-admonitions"}
 	for _, flag := range flags {
 		if cmd.Flags().Lookup(flag) == nil {
 			t.Errorf("Expected flag %q to be registered", flag)
@@ -800,6 +803,57 @@ func TestCheckResults_Fail(t *testing.T) {
 	}
 }
 
+func TestCountFailures_FailOn(t *testing.T) {
+	results := []*analyzer.Result{
+		{Status: "fail", Diagnostics: []analyzer.Diagnostic{{Severity: analyzer.SeverityError}}},
+		{Status: "warn", Diagnostics: []analyzer.Diagnostic{{Severity: analyzer.SeverityWarning}}},
+		{Status: "pass", Diagnostics: []analyzer.Diagnostic{{Severity: analyzer.SeverityInfo}}},
+		{Status: "pass"},
+	}
+
+	tests := []struct {
+		failOn string
+		want   int
+	}{
+		{"error", 1},
+		{"warning", 2},
+		{"info", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.failOn, func(t *testing.T) {
+			resetFlags()
+			failOnFlag = tt.failOn
+			defer resetFlags()
+
+			stats := countFailures(results, config.DefaultConfig())
+			if stats.failed != tt.want {
+				t.Errorf("failed = %d, want %d", stats.failed, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_InvalidFailOn(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(testFile, []byte("# Test\n\nContent."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	defer resetFlags()
+
+	// Binding flags resets them to defaults, so set the value afterwards
+	cmd := newRootCmd()
+	failOnFlag = "fatal"
+
+	err := run(cmd, []string{testFile})
+	if err == nil || !strings.Contains(err.Error(), "--fail-on") {
+		t.Errorf("Expected --fail-on validation error, got %v", err)
+	}
+}
+
 func TestCheckResults_Pass(t *testing.T) {
 	cfg := config.DefaultConfig()
 
@@ -1020,6 +1074,18 @@ func TestApplyFlagOverrides_AllFlags(t *testing.T) {
 	}
 }
 
+func TestApplyFlagOverrides_GoDoc(t *testing.T) {
+	resetFlags()
+	defer resetFlags()
+
+	cfg := config.DefaultConfig()
+	goDocFlag = true
+	applyFlagOverrides(&cobra.Command{}, cfg)
+	if !cfg.GoDoc.Enabled {
+		t.Error("GoDoc.Enabled = false, want true with --go-doc")
+	}
+}
+
 func TestLoadConfig_AutoDetectFromFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1339,3 +1405,70 @@ func TestAnalyzeTarget_DirectoryAnalyzeError(t *testing.T) {
 		t.Errorf("Expected 'error analyzing directory' error, got %v", err)
 	}
 }
+
+func TestCheckNavProblems_FailOn(t *testing.T) {
+	problems := []analyzer.Diagnostic{
+		{Severity: analyzer.SeverityError, Rule: "nav/broken"},
+		{Severity: analyzer.SeverityWarning, Rule: "nav/broken"},
+	}
+
+	tests := []struct {
+		failOn  string
+		wantErr string
+	}{
+		{"error", "1 problem(s) in MkDocs nav"},
+		{"warning", "2 problem(s) in MkDocs nav"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.failOn, func(t *testing.T) {
+			resetFlags()
+			failOnFlag = tt.failOn
+			defer resetFlags()
+
+			err := checkNavProblems(problems)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("checkNavProblems() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := checkNavProblems(nil); err != nil {
+		t.Errorf("checkNavProblems(nil) = %v, want nil", err)
+	}
+}
+
+func TestRun_MkDocsNav(t *testing.T) {
+	tmpDir := t.TempDir()
+	docsDir := filepath.Join(tmpDir, "docs")
+	if err := os.MkdirAll(docsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.md", "orphan.md"} {
+		if err := os.WriteFile(filepath.Join(docsDir, name), []byte("# Test\n\nContent."), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdocsFile := filepath.Join(tmpDir, "mkdocs.yml")
+	if err := os.WriteFile(mkdocsFile, []byte("nav:\n  - Home: index.md\n  - Gone: gone.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	defer resetFlags()
+
+	cmd := newRootCmd()
+	mkdocsFlag = mkdocsFile
+	checkFlag = true
+	formatFlag = "summary"
+
+	err := run(cmd, []string{docsDir})
+	if err == nil || !strings.Contains(err.Error(), "1 problem(s) in MkDocs nav") {
+		t.Errorf("Expected broken nav entry to fail the check, got %v", err)
+	}
+
+	mkdocsFlag = filepath.Join(tmpDir, "missing.yml")
+	if err := run(cmd, []string{docsDir}); err == nil || !strings.Contains(err.Error(), "cannot load MkDocs config") {
+		t.Errorf("Expected load error for missing mkdocs.yml, got %v", err)
+	}
+}
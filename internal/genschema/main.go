@@ -1,5 +1,8 @@
-//go:build ignore
+//go:build genschema
 
+// Command genschema writes the JSON schema for the configuration file. It
+// runs through go generate in pkg/config. The genschema build tag keeps it
+// out of normal builds while go mod tidy still sees its imports.
 package main
 
 import (
@@ -15,15 +18,21 @@ import (
 // addExamples adds example values to schema fields
 func addExamples(schema *jsonschema.Schema) {
 	examples := map[string][]interface{}{
-		"max_grade":        {12, 14, 16},
-		"max_ari":          {12, 14, 16},
-		"max_fog":          {14, 16, 18},
-		"min_ease":         {30, 40, 50, -100},
-		"max_lines":        {250, 375, 500},
-		"min_words":        {50, 100, 150},
-		"min_admonitions":  {0, 1, 2, -1},
-		"max_dash_density": {0, 2, 5, -1},
-		"path":             {"docs/developer-guide/", "docs/user-guide/", "api/", "README.md"},
+		"max_grade":                    {12, 14, 16},
+		"max_ari":                      {12, 14, 16},
+		"max_fog":                      {14, 16, 18},
+		"min_ease":                     {30, 40, 50, -100},
+		"max_lines":                    {250, 375, 500},
+		"min_words":                    {50, 100, 150},
+		"min_admonitions":              {0, 1, 2, -1},
+		"max_dash_density":             {0, 2, 5, -1},
+		"max_rare_word_ratio":          {0, 0.15, 0.25, -1},
+		"max_sentence_start_run":       {0, 2, 3, -1},
+		"min_sentence_length_variance": {0, 4, 9, -1},
+		"max_paragraph_words":          {0, 120, 150, -1},
+		"max_paragraph_sentences":      {0, 6, 8, -1},
+		"max_lines_scope":              {"all", "prose"},
+		"path":                         {"docs/developer-guide/", "docs/user-guide/", "api/", "README.md"},
 	}
 
 	// Apply examples to thresholds
@@ -35,6 +44,123 @@ func addExamples(schema *jsonschema.Schema) {
 		}
 	}
 
+	// Apply examples to reading time model
+	readingTimeExamples := map[string][]interface{}{
+		"words_per_minute":      {150, 200, 250},
+		"seconds_per_code_line": {0, 2, 5},
+		"seconds_per_image":     {0, 10, 12},
+		"seconds_per_table":     {0, 15, 30},
+	}
+	if readingTime, ok := schema.Properties.Get("reading_time"); ok {
+		for field, exampleValues := range readingTimeExamples {
+			if prop, ok := readingTime.Properties.Get(field); ok {
+				prop.Examples = exampleValues
+			}
+		}
+	}
+
+	// Apply examples to glossary
+	if glossary, ok := schema.Properties.Get("glossary"); ok {
+		glossary.Examples = []interface{}{
+			[]string{"Kubernetes", "idempotent", "pull request"},
+		}
+	}
+
+	// Apply example to the MkDocs config path
+	if mkdocs, ok := schema.Properties.Get("mkdocs"); ok {
+		mkdocs.Examples = []interface{}{"mkdocs.yml"}
+	}
+
+	// Apply examples to acronym allow-list
+	if acronyms, ok := schema.Properties.Get("acronyms"); ok {
+		if allow, ok := acronyms.Properties.Get("allow"); ok {
+			allow.Examples = []interface{}{
+				[]string{"SLO", "SRE", "RBAC"},
+			}
+		}
+	}
+
+	// Apply examples to terminology groups
+	if terminology, ok := schema.Properties.Get("terminology"); ok && terminology.Items != nil {
+		if prop, ok := terminology.Items.Properties.Get("preferred"); ok {
+			prop.Examples = []interface{}{"sign in", "GitHub", "email"}
+		}
+		if prop, ok := terminology.Items.Properties.Get("variants"); ok {
+			prop.Examples = []interface{}{[]string{"log in", "login"}}
+		}
+	}
+
+	// Apply examples to inclusive language entries
+	if inclusive, ok := schema.Properties.Get("inclusive_language"); ok {
+		if terms, ok := inclusive.Properties.Get("terms"); ok && terms.Items != nil {
+			if prop, ok := terms.Items.Properties.Get("id"); ok {
+				prop.Examples = []interface{}{"kill-process"}
+			}
+			if prop, ok := terms.Items.Properties.Get("terms"); ok {
+				prop.Examples = []interface{}{[]string{"kill the process"}}
+			}
+			if prop, ok := terms.Items.Properties.Get("suggestions"); ok {
+				prop.Examples = []interface{}{[]string{"stop the process"}}
+			}
+		}
+		if prop, ok := inclusive.Properties.Get("exclude"); ok {
+			prop.Examples = []interface{}{[]string{"sanity-check", "dummy-value"}}
+		}
+	}
+
+	// Apply examples to preserved heading terms
+	if headingCase, ok := schema.Properties.Get("heading_case"); ok {
+		if prop, ok := headingCase.Properties.Get("preserve"); ok {
+			prop.Examples = []interface{}{[]string{"Kubernetes", "Visual Studio Code"}}
+		}
+	}
+
+	// Apply examples to frontmatter rules
+	if frontmatter, ok := schema.Properties.Get("frontmatter"); ok {
+		if prop, ok := frontmatter.Properties.Get("required"); ok {
+			prop.Examples = []interface{}{[]string{"title", "description", "tags"}}
+		}
+		if prop, ok := frontmatter.Properties.Get("fields"); ok {
+			prop.Examples = []interface{}{map[string]interface{}{
+				"description": map[string]interface{}{"type": "string", "min_length": 50, "max_length": 160},
+				"status":      map[string]interface{}{"allowed": []string{"draft", "published"}},
+			}}
+		}
+	}
+
+	// Apply examples to HTML input
+	if htmlInput, ok := schema.Properties.Get("html"); ok {
+		if prop, ok := htmlInput.Properties.Get("admonition_classes"); ok {
+			prop.Examples = []interface{}{[]string{"admonition", "callout", "alert"}}
+		}
+	}
+
+	// Apply examples to template stripping
+	if templates, ok := schema.Properties.Get("templates"); ok {
+		if prop, ok := templates.Properties.Get("profiles"); ok {
+			prop.Examples = []interface{}{[]string{"hugo"}, []string{"jinja"}}
+		}
+		if prop, ok := templates.Properties.Get("delimiters"); ok {
+			prop.Examples = []interface{}{[]map[string]string{{"open": "<%", "close": "%>"}}}
+		}
+	}
+
+	if snippets, ok := schema.Properties.Get("snippets"); ok {
+		if prop, ok := snippets.Properties.Get("base_path"); ok {
+			prop.Examples = []interface{}{[]string{"docs/includes"}, []string{".", "snippets"}}
+		}
+	}
+
+	// Apply examples to spelling word lists
+	if spelling, ok := schema.Properties.Get("spelling"); ok {
+		if prop, ok := spelling.Properties.Get("words"); ok {
+			prop.Examples = []interface{}{[]string{"frontmatter", "readability"}}
+		}
+		if prop, ok := spelling.Properties.Get("word_lists"); ok {
+			prop.Examples = []interface{}{[]string{".github/wordlist.txt"}}
+		}
+	}
+
 	// Apply examples to override path and thresholds
 	if overrides, ok := schema.Properties.Get("overrides"); ok {
 		if overrides.Items != nil {
@@ -54,6 +180,40 @@ func addExamples(schema *jsonschema.Schema) {
 	}
 }
 
+// ruleSeverities lists the values accepted for each entry in a rules map.
+var ruleSeverities = []interface{}{
+	config.SeverityError,
+	config.SeverityWarning,
+	config.SeverityInfo,
+	config.SeverityOff,
+}
+
+// restrictRuleSeverities limits rules map values to the known severity levels.
+// invopop/jsonschema cannot express enums for map values through struct tags.
+func restrictRuleSeverities(schema *jsonschema.Schema) {
+	restrict := func(rules *jsonschema.Schema) {
+		if rules == nil || rules.AdditionalProperties == nil {
+			return
+		}
+		rules.AdditionalProperties.Enum = ruleSeverities
+		rules.PropertyNames = &jsonschema.Schema{
+			Pattern: "^[a-z0-9-]+/[a-z0-9-]+$",
+		}
+		rules.Examples = []interface{}{
+			map[string]string{"content/admonitions": config.SeverityInfo},
+		}
+	}
+
+	if rules, ok := schema.Properties.Get("rules"); ok {
+		restrict(rules)
+	}
+	if overrides, ok := schema.Properties.Get("overrides"); ok && overrides.Items != nil {
+		if rules, ok := overrides.Items.Properties.Get("rules"); ok {
+			restrict(rules)
+		}
+	}
+}
+
 // removeRequired recursively removes "required" from all schema nodes
 func removeRequired(schema *jsonschema.Schema, isRoot bool) {
 	if schema == nil {
@@ -91,7 +251,8 @@ func main() {
 
 	schema := reflector.Reflect(&config.Config{})
 
-	// Post-process schema to remove "required" from all fields except PathOverride.path
+	// Post-process schema to remove "required" from all fields except
+	// PathOverride.path and TermGroup.preferred
 	removeRequired(schema, true)
 
 	// Set path as required in overrides
@@ -101,9 +262,26 @@ func main() {
 		}
 	}
 
+	// Set preferred as required in terminology groups
+	if terminology, ok := schema.Properties.Get("terminology"); ok {
+		if terminology.Items != nil {
+			terminology.Items.Required = []string{"preferred"}
+		}
+	}
+
+	// Set id and terms as required in inclusive language entries
+	if inclusive, ok := schema.Properties.Get("inclusive_language"); ok {
+		if terms, ok := inclusive.Properties.Get("terms"); ok && terms.Items != nil {
+			terms.Items.Required = []string{"id", "terms"}
+		}
+	}
+
 	// Add examples manually (invopop/jsonschema doesn't support examples in tags)
 	addExamples(schema)
 
+	// Constrain rule severity maps
+	restrictRuleSeverities(schema)
+
 	// Set metadata
 	schema.ID = jsonschema.ID("https://readability.adaptive-enforcement-lab.com/latest/schemas/config.json")
 	schema.Title = "Readability Configuration"
@@ -0,0 +1,114 @@
+// Package linekind tracks what each source line holds for the front ends
+// that parse formats other than Markdown (AsciiDoc, reStructuredText, and
+// HTML), so they count lines and find the block after each heading the
+// same way.
+package linekind
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// Kind is the kind of content on a source line. Kinds are ordered so that a
+// line holding several can keep the highest one.
+type Kind int
+
+const (
+	Empty Kind = iota
+	HTML       // Comments, directives, tags, and other markup that holds no text
+	Prose
+	List
+	Table
+	Code
+	Admonition
+	Frontmatter
+)
+
+// SegmentKind returns the segment kind for paragraphs of a line kind.
+func SegmentKind(kind Kind) markdown.SegmentKind {
+	if kind == List {
+		return markdown.SegmentList
+	}
+	return markdown.SegmentProse
+}
+
+// Fill sets the kind of the given lines. Blank lines stay empty unless they
+// are inside code.
+func Fill(kinds []Kind, text []string, lines []int, kind Kind) {
+	for _, i := range lines {
+		if strings.TrimSpace(text[i]) == "" && kind != Code {
+			kinds[i] = Empty
+			continue
+		}
+		kinds[i] = kind
+	}
+}
+
+// Count stores the line counts by kind in the result.
+func Count(r *markdown.ParseResult, kinds []Kind) {
+	r.TotalLines = len(kinds)
+	for _, kind := range kinds {
+		switch kind {
+		case Empty:
+			r.EmptyLines++
+		case List:
+			r.ListLines++
+		case Table:
+			r.TableLines++
+		case HTML:
+			r.HTMLLines++
+		case Code:
+			r.CodeLines++
+		case Admonition:
+			r.AdmonitionLines++
+		case Frontmatter:
+			r.FrontmatterLines++
+		}
+	}
+}
+
+// Span returns the line numbers [start, end).
+func Span(start, end int) []int {
+	lines := make([]int, 0, max(end-start, 0))
+	for i := start; i < end; i++ {
+		lines = append(lines, i)
+	}
+	return lines
+}
+
+// Cursor is a byte position on a line and the runes before it.
+type Cursor struct {
+	line, pos, runes int
+}
+
+// Column returns the 1-based column of byte pos on line i of text.
+// Positions are usually requested left to right, so counting resumes from
+// the last one.
+func (c *Cursor) Column(text []string, i, pos int) int {
+	if c.line != i || c.pos > pos {
+		*c = Cursor{line: i}
+	}
+	c.runes += utf8.RuneCountInString(text[i][c.pos:pos])
+	c.pos = pos
+	return c.runes + 1
+}
+
+// Block is a top-level block, used to find the block after each heading.
+type Block struct {
+	Kind    markdown.BlockKind
+	Heading int // Index in the result's Headings, or -1
+}
+
+// MarkFollowing records the kind of block that follows each heading.
+func MarkFollowing(blocks []Block, headings []markdown.Heading) {
+	for i, b := range blocks {
+		if b.Heading < 0 {
+			continue
+		}
+		if i+1 < len(blocks) {
+			headings[b.Heading].Next = blocks[i+1].Kind
+		}
+	}
+}
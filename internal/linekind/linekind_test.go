@@ -0,0 +1,78 @@
+package linekind
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestFill(t *testing.T) {
+	text := []string{"Text.", "  ", "More."}
+	tests := []struct {
+		kind Kind
+		want []Kind
+	}{
+		{Prose, []Kind{Prose, Empty, Prose}},
+		{List, []Kind{List, Empty, List}},
+		{Code, []Kind{Code, Code, Code}},
+	}
+
+	for _, tt := range tests {
+		kinds := make([]Kind, len(text))
+		Fill(kinds, text, Span(0, len(text)), tt.kind)
+		if !reflect.DeepEqual(kinds, tt.want) {
+			t.Errorf("Fill(%d) = %v, want %v", tt.kind, kinds, tt.want)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	r := &markdown.ParseResult{}
+	Count(r, []Kind{Prose, Empty, Empty, List, Table, HTML, Code, Code, Admonition, Frontmatter})
+	want := markdown.ParseResult{
+		TotalLines:       10,
+		EmptyLines:       2,
+		ListLines:        1,
+		TableLines:       1,
+		HTMLLines:        1,
+		CodeLines:        2,
+		AdmonitionLines:  1,
+		FrontmatterLines: 1,
+	}
+	if !reflect.DeepEqual(*r, want) {
+		t.Errorf("Count() = %+v, want %+v", *r, want)
+	}
+}
+
+func TestCursor_Column(t *testing.T) {
+	text := []string{"héllo wörld", "ünïcode"}
+	var c Cursor
+	tests := []struct {
+		line, pos, want int
+	}{
+		{0, 0, 1},
+		{0, 7, 7},
+		{0, 13, 12},
+		{0, 3, 3}, // Backwards restarts the count
+		{1, 5, 4},
+	}
+	for _, tt := range tests {
+		if got := c.Column(text, tt.line, tt.pos); got != tt.want {
+			t.Errorf("Column(%d, %d) = %d, want %d", tt.line, tt.pos, got, tt.want)
+		}
+	}
+}
+
+func TestMarkFollowing(t *testing.T) {
+	headings := []markdown.Heading{{Text: "A"}, {Text: "B"}}
+	blocks := []Block{
+		{Kind: markdown.BlockHeading, Heading: 0},
+		{Kind: markdown.BlockCode, Heading: -1},
+		{Kind: markdown.BlockHeading, Heading: 1},
+	}
+	MarkFollowing(blocks, headings)
+	if headings[0].Next != markdown.BlockCode || headings[1].Next != "" {
+		t.Errorf("Next = %q, %q, want %q, \"\"", headings[0].Next, headings[1].Next, markdown.BlockCode)
+	}
+}
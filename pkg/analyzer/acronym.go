@@ -0,0 +1,227 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// defaultAcronyms lists acronyms that technical readers know without expansion.
+// Entries from the acronyms.allow config setting and the glossary are added to these.
+var defaultAcronyms = []string{
+	"API", "ASCII", "CLI", "CPU", "CSS", "CSV", "DNS", "FAQ", "GPU", "GUI",
+	"HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "OK", "OS", "PDF", "PNG",
+	"RAM", "README", "SQL", "SSH", "SSL", "TCP", "TLS", "TODO", "UDP", "UI", "URL",
+	"USB", "UTC", "UTF", "UUID", "XML", "YAML",
+}
+
+var (
+	// acronymPattern matches all-caps tokens of two or more letters,
+	// optionally with digits and a plural "s" (APIs, EC2).
+	acronymPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*[A-Z][A-Z0-9]*s?\b`)
+
+	// acronymAfterExpansion matches "Service Level Objective (SLO)".
+	acronymAfterExpansion = regexp.MustCompile(`\(([A-Z][A-Za-z0-9]*[A-Z][A-Z0-9]*s?)\)`)
+
+	// acronymBeforeExpansion matches "SLO (Service Level Objective)".
+	acronymBeforeExpansion = regexp.MustCompile(`\b([A-Z][A-Z0-9]*[A-Z][A-Z0-9]*s?)\s*\(([^()]+)\)`)
+)
+
+// acronymFillers are short words an expansion may contain without
+// contributing a letter (Bill of Materials -> BOM).
+var acronymFillers = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "by": true,
+	"for": true, "in": true, "of": true, "on": true, "or": true, "the": true,
+	"to": true, "with": true,
+}
+
+// undefinedAcronyms reports the first use of each acronym that is not expanded
+// at that point. Heading text is ignored since headings often name a topic
+// that the following paragraph introduces.
+func undefinedAcronyms(segments []markdown.Segment, allowed map[string]bool) []Diagnostic {
+	text, lines := joinSegments(segments)
+	defined := definedAcronyms(text)
+
+	var diagnostics []Diagnostic
+	seen := make(map[string]bool)
+	for _, loc := range acronymPattern.FindAllStringIndex(text, -1) {
+		acronym := singularAcronym(text[loc[0]:loc[1]])
+		if seen[acronym] {
+			continue
+		}
+		seen[acronym] = true
+
+		if allowed[acronym] || isCommonWord(strings.ToLower(acronym)) {
+			continue
+		}
+		if offset, ok := defined[acronym]; ok && offset <= loc[0] {
+			continue
+		}
+
+		line, column := lines.positionAt(loc[0])
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     line,
+			Column:   column,
+			Severity: SeverityInfo,
+			Rule:     "content/undefined-acronym",
+			Message:  fmt.Sprintf("Acronym %s is used before it is defined. Spell it out on first use, for example \"Full Name (%s)\"", acronym, acronym),
+		})
+	}
+	return diagnostics
+}
+
+// definedAcronyms returns the offset at which each acronym is first expanded.
+// An acronym counts as expanded when the words next to it in parentheses
+// start with its letters.
+func definedAcronyms(text string) map[string]int {
+	defined := make(map[string]int)
+	record := func(acronym string, offset int) {
+		if prev, ok := defined[acronym]; !ok || offset < prev {
+			defined[acronym] = offset
+		}
+	}
+
+	for _, m := range acronymAfterExpansion.FindAllStringSubmatchIndex(text, -1) {
+		acronym := singularAcronym(text[m[2]:m[3]])
+		words := expansionWords(text[:m[0]])
+		for start := len(words) - 1; start >= 0 && len(words)-start <= len(acronym)+3; start-- {
+			if matchesAcronym(acronym, words[start:]) {
+				record(acronym, m[2])
+				break
+			}
+		}
+	}
+
+	for _, m := range acronymBeforeExpansion.FindAllStringSubmatchIndex(text, -1) {
+		acronym := singularAcronym(text[m[2]:m[3]])
+		if matchesAcronym(acronym, expansionWords(text[m[4]:m[5]])) {
+			record(acronym, m[2])
+		}
+	}
+
+	return defined
+}
+
+// expansionWords splits text into words, treating hyphens as separators.
+func expansionWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchesAcronym reports whether the acronym's letters can be taken, in order,
+// from the words: each word that is not a filler starts with the next letter
+// and may supply further letters from later in the same word (JavaScript
+// Object Notation -> JSON). Digits in the acronym are ignored (K8s, EC2).
+func matchesAcronym(acronym string, words []string) bool {
+	var letters []rune
+	for _, r := range strings.ToLower(acronym) {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+	lowered := make([][]rune, len(words))
+	for i, w := range words {
+		lowered[i] = []rune(strings.ToLower(w))
+	}
+	return matchLetters(letters, lowered)
+}
+
+// matchLetters matches letters against the first letter of each word.
+func matchLetters(letters []rune, words [][]rune) bool {
+	if len(words) == 0 {
+		return len(letters) == 0
+	}
+	word := words[0]
+	if acronymFillers[string(word)] && matchLetters(letters, words[1:]) {
+		return true
+	}
+	if len(letters) == 0 || word[0] != letters[0] {
+		return false
+	}
+	return matchWithin(letters[1:], word[1:], words[1:])
+}
+
+// matchWithin matches letters against the rest of the current word before
+// moving on to the following words.
+func matchWithin(letters, rest []rune, words [][]rune) bool {
+	if matchLetters(letters, words) {
+		return true
+	}
+	if len(letters) == 0 {
+		return false
+	}
+	for i, r := range rest {
+		if r == letters[0] && matchWithin(letters[1:], rest[i+1:], words) {
+			return true
+		}
+	}
+	return false
+}
+
+// singularAcronym strips a plural "s" (APIs -> API).
+func singularAcronym(token string) string {
+	if len(token) > 2 && strings.HasSuffix(token, "s") {
+		return token[:len(token)-1]
+	}
+	return token
+}
+
+// allowedAcronyms returns the built-in acronyms plus configured ones.
+func (a *Analyzer) allowedAcronyms() map[string]bool {
+	allowed := make(map[string]bool, len(defaultAcronyms))
+	for _, acronym := range defaultAcronyms {
+		allowed[acronym] = true
+	}
+	if a.Config == nil {
+		return allowed
+	}
+	for _, acronym := range a.Config.Acronyms.Allow {
+		allowed[strings.ToUpper(acronym)] = true
+	}
+	for _, term := range a.Config.Glossary {
+		for _, acronym := range acronymPattern.FindAllString(term, -1) {
+			allowed[singularAcronym(acronym)] = true
+		}
+	}
+	return allowed
+}
+
+// segmentLines maps offsets in joined segment text back to source positions.
+type segmentLines struct {
+	offsets  []int              // Start offset of each segment in the joined text
+	segments []markdown.Segment // Segment at each offset
+}
+
+// joinSegments concatenates non-heading segments with spaces so patterns
+// can span inline markup, and records where each segment starts.
+func joinSegments(segments []markdown.Segment) (string, segmentLines) {
+	var b strings.Builder
+	var lines segmentLines
+	for _, s := range segments {
+		if s.Kind == markdown.SegmentHeading {
+			continue
+		}
+		lines.offsets = append(lines.offsets, b.Len())
+		lines.segments = append(lines.segments, s)
+		b.WriteString(s.Text)
+		b.WriteString(" ")
+	}
+	return b.String(), lines
+}
+
+// positionAt returns the source line and column for an offset in the
+// joined text.
+func (l segmentLines) positionAt(offset int) (line, column int) {
+	line, column = 1, 1
+	for i, start := range l.offsets {
+		if start > offset {
+			break
+		}
+		line, column = l.segments[i].Position(offset - start)
+	}
+	return line, column
+}
@@ -0,0 +1,150 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestMatchesAcronym(t *testing.T) {
+	tests := []struct {
+		acronym string
+		words   []string
+		want    bool
+	}{
+		{"SLO", []string{"Service", "Level", "Objective"}, true},
+		{"BOM", []string{"Bill", "of", "Materials"}, true},
+		{"JSON", []string{"JavaScript", "Object", "Notation"}, true},
+		{"RBAC", []string{"Role", "Based", "Access", "Control"}, true},
+		{"K8s", []string{"Kubernetes"}, true},
+		{"SLO", []string{"the", "Service", "Level", "Objective"}, true},
+		{"SLO", []string{"Service", "Level"}, false},
+		{"SLO", []string{"Some", "Other", "Thing"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acronym, func(t *testing.T) {
+			if got := matchesAcronym(tt.acronym, tt.words); got != tt.want {
+				t.Errorf("matchesAcronym(%q, %v) = %v, want %v", tt.acronym, tt.words, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndefinedAcronyms(t *testing.T) {
+	allowed := map[string]bool{"API": true}
+
+	tests := []struct {
+		name     string
+		content  string
+		wantLine map[string]int // acronym -> line of the reported first use
+	}{
+		{
+			name:     "expanded before the acronym",
+			content:  "# Targets\n\nEach Service Level Objective (SLO) has an owner.\n\nReview SLOs monthly.",
+			wantLine: map[string]int{},
+		},
+		{
+			name:     "expanded after the acronym",
+			content:  "Track the SLO (Service Level Objective) weekly.",
+			wantLine: map[string]int{},
+		},
+		{
+			name:     "used before expansion",
+			content:  "Review the SLO.\n\nA Service Level Objective (SLO) is a target.",
+			wantLine: map[string]int{"SLO": 1},
+		},
+		{
+			name:     "never expanded reports first use",
+			content:  "Intro text.\n\nAsk the SRE team.\n\nThe SRE rotation changes weekly.",
+			wantLine: map[string]int{"SRE": 3},
+		},
+		{
+			name:     "parenthesized but not an expansion",
+			content:  "Our latency goals (SLO) are strict.",
+			wantLine: map[string]int{"SLO": 1},
+		},
+		{
+			name:     "allow-list and emphasis words skipped",
+			content:  "Call the API. Do NOT retry.",
+			wantLine: map[string]int{},
+		},
+		{
+			name:     "headings and code ignored",
+			content:  "## SRE Handbook\n\nRun `kubectl get SLO` first.\n\n```\nSRE=1\n```",
+			wantLine: map[string]int{},
+		},
+		{
+			name:     "line after frontmatter and admonition",
+			content:  "---\ntitle: Test\n---\n\n!!! note\n    See the docs.\n\nThe TTL is short.",
+			wantLine: map[string]int{"TTL": 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := markdown.Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			diagnostics := undefinedAcronyms(parsed.Segments, allowed)
+			if len(diagnostics) != len(tt.wantLine) {
+				t.Fatalf("got %d diagnostics, want %d: %+v", len(diagnostics), len(tt.wantLine), diagnostics)
+			}
+			for _, d := range diagnostics {
+				found := false
+				for acronym, line := range tt.wantLine {
+					if d.Line == line && containsWord(d.Message, acronym) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("unexpected diagnostic %+v, want %v", d, tt.wantLine)
+				}
+				if d.Rule != "content/undefined-acronym" {
+					t.Errorf("Rule = %q, want content/undefined-acronym", d.Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyze_UndefinedAcronymConfig(t *testing.T) {
+	content := []byte("Page the SRE on call. Check the SLO dashboard.")
+
+	cfg := config.DefaultConfig()
+	cfg.Acronyms.Allow = []string{"sre"}
+	cfg.Glossary = []string{"SLO"}
+	cfg.Rules = config.Rules{"content/undefined-acronym": config.SeverityError}
+
+	result, err := NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/undefined-acronym" {
+			t.Errorf("allowed acronym reported: %+v", d)
+		}
+	}
+
+	cfg.Acronyms.Allow = nil
+	result, err = NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Status != StatusFail {
+		t.Errorf("Status = %q, want fail when the rule is raised to error", result.Status)
+	}
+}
+
+// containsWord reports whether s contains word delimited by non-letters.
+func containsWord(s, word string) bool {
+	for _, field := range expansionWords(s) {
+		if field == word {
+			return true
+		}
+	}
+	return false
+}
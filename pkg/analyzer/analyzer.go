@@ -2,12 +2,20 @@ package analyzer
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/adaptive-enforcement-lab/readability/pkg/asciidoc"
 	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/godoc"
+	"github.com/adaptive-enforcement-lab/readability/pkg/html"
 	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+	"github.com/adaptive-enforcement-lab/readability/pkg/notebook"
+	"github.com/adaptive-enforcement-lab/readability/pkg/rst"
+	"github.com/adaptive-enforcement-lab/readability/pkg/snippets"
 	"github.com/darkliquid/textstats"
 )
 
@@ -15,6 +23,11 @@ import (
 type Analyzer struct {
 	Thresholds Thresholds
 	Config     *config.Config
+
+	projectWordsOnce  sync.Once
+	projectWordsCache map[string]bool
+	projectWordsErr   error
+	suggestions       suggestionCache
 }
 
 // New creates a new Analyzer with default thresholds.
@@ -61,7 +74,7 @@ func (a *Analyzer) AnalyzeFile(path string) (*Result, error) {
 // Analyze processes markdown content and returns metrics.
 func (a *Analyzer) Analyze(path string, content []byte) (*Result, error) {
 	// Parse markdown to extract prose and structure
-	parsed, err := markdown.Parse(content)
+	parsed, err := a.parse(path, content)
 	if err != nil {
 		return nil, err
 	}
@@ -70,45 +83,146 @@ func (a *Analyzer) Analyze(path string, content []byte) (*Result, error) {
 	prose := stripFrontmatter(parsed.Prose)
 
 	sentences := countSentences(prose)
+	words := countWords(prose)
+	readingSeconds := calculateReadingTime(a.readingTimeModel(), words, parsed.CodeLines, parsed.Images, parsed.Tables)
+	proseSentences := splitSentences(parsed.Segments)
 
 	// Calculate readability metrics using textstats
 	// Use the function-based API which takes strings directly
 	result := &Result{
 		File: path,
 		Structural: Structural{
-			Lines:              parsed.TotalLines,
-			Words:              countWords(prose),
-			Sentences:          sentences,
-			Characters:         len(prose),
-			ReadingTimeMinutes: calculateReadingTime(countWords(prose)),
-			DashDensity:        calculateDashDensity(prose, sentences),
-		},
-		Headings: countHeadings(parsed.Headings),
-		Readability: Readability{
-			FleschKincaidGrade: textstats.FleschKincaidGradeLevel(prose),
-			FleschReadingEase:  textstats.FleschKincaidReadingEase(prose),
-			ARI:                textstats.AutomatedReadabilityIndex(prose),
-			ColemanLiau:        textstats.ColemanLiauIndex(prose),
-			GunningFog:         textstats.GunningFogScore(prose),
-			SMOG:               textstats.SMOGIndex(prose),
+			Lines:                  parsed.TotalLines,
+			Words:                  words,
+			Sentences:              sentences,
+			Paragraphs:             len(parsed.Paragraphs),
+			Characters:             len(prose),
+			ReadingTimeMinutes:     ReadingTimeMinutes(readingSeconds),
+			ReadingTimeSeconds:     readingSeconds,
+			DashDensity:            calculateDashDensity(prose, sentences),
+			SentenceLengthVariance: sentenceLengthVariance(proseSentences),
 		},
+		Headings:    countHeadings(parsed.Headings),
+		Readability: measureReadability(prose),
 		Composition: Composition{
-			TotalLines:     parsed.TotalLines,
-			ProseLines:     parsed.TotalLines - parsed.CodeLines - parsed.EmptyLines,
-			CodeLines:      parsed.CodeLines,
-			EmptyLines:     parsed.EmptyLines,
-			CodeBlockRatio: calculateRatio(parsed.CodeLines, parsed.TotalLines),
+			TotalLines:       parsed.TotalLines,
+			ProseLines:       proseLines(parsed.ParseResult),
+			CodeLines:        parsed.CodeLines,
+			TableLines:       parsed.TableLines,
+			ListLines:        parsed.ListLines,
+			AdmonitionLines:  parsed.AdmonitionLines,
+			HTMLLines:        parsed.HTMLLines,
+			FrontmatterLines: parsed.FrontmatterLines,
+			EmptyLines:       parsed.EmptyLines,
+			CodeBlockRatio:   calculateRatio(parsed.CodeLines, parsed.TotalLines),
+			MarkdownCells:    parsed.cells.Count(notebook.CellMarkdown),
+			CodeCells:        parsed.cells.Count(notebook.CellCode),
 		},
 		Admonitions: countAdmonitions(parsed.Admonitions),
+		Vocabulary:  analyzeVocabulary(prose, a.glossary()),
+		terms:       extractTerms(parsed.Segments, a.declaredTermKeys()),
+		cells:       parsed.cells,
+		sources:     parsed.sources,
+		docComments: parsed.units != nil,
 	}
 
-	result.Diagnostics = a.collectDiagnostics(result)
+	located, err := a.contentDiagnostics(path, parsed, proseSentences)
+	if err != nil {
+		return nil, err
+	}
+	located = append(locateSnippets(located, path, parsed.sources), snippetDiagnostics(path, parsed.problems)...)
+	diagnostics := append(a.collectDiagnostics(result), located...)
+	diagnostics = append(diagnostics, a.docCommentDiagnostics(path, parsed.units)...)
+	result.Diagnostics = locateCells(a.applyRuleSeverities(path, diagnostics), parsed.cells)
 	result.Status = a.determineStatus(result.Diagnostics)
 
 	return result, nil
 }
 
-// AnalyzeDirectory processes all markdown files in a directory.
+// isSupported reports whether a file has an extension the analyzer reads:
+// .md for Markdown, .mdx for MDX, .adoc or .asciidoc for AsciiDoc, .rst
+// for reStructuredText, .html or .htm for HTML, and .ipynb for Jupyter
+// notebooks.
+func isSupported(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".mdx", ".adoc", ".asciidoc", ".rst", ".html", ".htm", ".ipynb":
+		return true
+	}
+	return false
+}
+
+// parsedFile is a ParseResult with what some formats add to it.
+type parsedFile struct {
+	*markdown.ParseResult
+	cells    notebook.Cells     // Jupyter notebooks only
+	units    []godoc.Unit       // Go source files only
+	sources  snippets.Sources   // Markdown pages with snippet includes only
+	problems []snippets.Problem // Snippet includes that could not be expanded
+}
+
+// parse parses content with the parser for the file's format.
+func (a *Analyzer) parse(path string, content []byte) (*parsedFile, error) {
+	var parsed *markdown.ParseResult
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ipynb":
+		parsed, cells, err := notebook.Parse(content)
+		return &parsedFile{ParseResult: parsed, cells: cells}, err
+	case ".go":
+		parsed, units, err := godoc.Parse(content)
+		return &parsedFile{ParseResult: parsed, units: units}, err
+	case ".mdx":
+		parsed, err = markdown.ParseMDX(content)
+	case ".adoc", ".asciidoc":
+		parsed, err = asciidoc.Parse(content)
+	case ".rst":
+		parsed, err = rst.Parse(content)
+	case ".html", ".htm":
+		var opts html.Options
+		if a.Config != nil {
+			opts.AdmonitionClasses = a.Config.HTML.AdmonitionClasses
+		}
+		parsed, err = html.Parse(content, opts)
+	default:
+		if a.Config != nil && a.Config.Snippets.Enabled {
+			expanded, sources, problems := snippets.Expand(path, content, snippets.Options{BasePaths: a.Config.Snippets.BasePath})
+			parsed, err = markdown.ParseWithOptions(expanded, a.markdownOptions())
+			return &parsedFile{ParseResult: parsed, sources: sources, problems: problems}, err
+		}
+		parsed, err = markdown.ParseWithOptions(content, a.markdownOptions())
+	}
+	return &parsedFile{ParseResult: parsed}, err
+}
+
+// markdownOptions returns the template tags to strip from Markdown files.
+func (a *Analyzer) markdownOptions() markdown.Options {
+	var opts markdown.Options
+	if a.Config == nil {
+		return opts
+	}
+	t := a.Config.Templates
+	opts.Templates.Profiles = t.Profiles
+	opts.Templates.KeepPaired = t.KeepPairedText
+	for _, d := range t.Delimiters {
+		opts.Templates.Delimiters = append(opts.Templates.Delimiters, markdown.Delimiters{Open: d.Open, Close: d.Close})
+	}
+	return opts
+}
+
+// locateCells moves diagnostic lines of a notebook from the combined
+// document into the cell they fall in. Diagnostics of other files are
+// returned unchanged.
+func locateCells(diagnostics []Diagnostic, cells notebook.Cells) []Diagnostic {
+	for i := range diagnostics {
+		if d := &diagnostics[i]; d.Line > 0 && len(cells) > 0 {
+			d.Cell, d.Line = cells.Locate(d.Line)
+		}
+	}
+	return diagnostics
+}
+
+// AnalyzeDirectory processes all supported files in a directory.
+// Terminology is compared across all files once every file is analyzed.
 func (a *Analyzer) AnalyzeDirectory(dir string) ([]*Result, error) {
 	var results []*Result
 
@@ -118,10 +232,13 @@ func (a *Analyzer) AnalyzeDirectory(dir string) ([]*Result, error) {
 		}
 
 		if info.IsDir() {
+			if info.Name() == ".ipynb_checkpoints" {
+				return filepath.SkipDir // Jupyter's autosaved copies
+			}
 			return nil
 		}
 
-		if !strings.HasSuffix(strings.ToLower(path), ".md") {
+		if !isSupported(path) && !(a.readsGoDoc() && isGoSource(path)) {
 			return nil
 		}
 
@@ -135,89 +252,46 @@ func (a *Analyzer) AnalyzeDirectory(dir string) ([]*Result, error) {
 		if err != nil {
 			return err
 		}
+		if result.docComments && result.Composition.TotalLines == 0 {
+			return nil // Go file without doc comments
+		}
 
 		results = append(results, result)
 		return nil
 	})
+	if err != nil {
+		return results, err
+	}
 
-	return results, err
+	a.CheckTerminology(results)
+	return results, nil
 }
 
 // collectDiagnostics gathers all issues found during analysis.
 func (a *Analyzer) collectDiagnostics(r *Result) []Diagnostic {
 	var diagnostics []Diagnostic
 
-	// Get path-specific thresholds if config is available
-	var maxGrade, maxARI, maxFog, minEase, maxDashDensity float64
-	var maxLines, minWords, minAdmonitions int
+	t := a.thresholds(r.File)
+	maxLines, minAdmonitions, maxDashDensity := t.MaxLines, t.MinAdmonitions, t.MaxDashDensity
+	lines, lineKind := r.Structural.Lines, "lines"
+	if t.MaxLinesScope == config.MaxLinesScopeProse {
+		lines, lineKind = r.Composition.ProseLines, "prose lines"
+	}
 
-	if a.Config != nil {
-		t := a.Config.ThresholdsForPath(r.File)
-		maxGrade = t.MaxGrade
-		maxARI = t.MaxARI
-		maxFog = t.MaxFog
-		minEase = t.MinEase
-		maxLines = t.MaxLines
-		minWords = t.MinWords
-		minAdmonitions = t.MinAdmonitions
-		maxDashDensity = t.MaxDashDensity
+	// Go doc comments are scored one symbol at a time and have no admonitions
+	if r.docComments {
+		minAdmonitions = 0
 	} else {
-		maxGrade = a.Thresholds.MaxFleschKincaidGrade
-		maxARI = a.Thresholds.MaxARI
-		maxFog = a.Thresholds.MaxGunningFog
-		minEase = a.Thresholds.MinFleschReadingEase
-		maxLines = a.Thresholds.MaxLines
-		minWords = 100 // Default minimum
-		minAdmonitions = 1
-		maxDashDensity = 0 // No dashes allowed by default
-	}
-
-	// Skip readability checks for very short/code-heavy documents
-	// Readability formulas produce unreliable results with sparse prose
-	skipReadability := minWords > 0 && r.Structural.Words < minWords
-
-	if !skipReadability {
-		if r.Readability.FleschKincaidGrade > maxGrade {
-			diagnostics = append(diagnostics, Diagnostic{
-				Line:     1,
-				Severity: SeverityError,
-				Rule:     "readability/grade-level",
-				Message:  fmt.Sprintf("Flesch-Kincaid grade %.1f exceeds threshold %.1f", r.Readability.FleschKincaidGrade, maxGrade),
-			})
-		}
-		if r.Readability.ARI > maxARI {
-			diagnostics = append(diagnostics, Diagnostic{
-				Line:     1,
-				Severity: SeverityError,
-				Rule:     "readability/ari",
-				Message:  fmt.Sprintf("ARI %.1f exceeds threshold %.1f", r.Readability.ARI, maxARI),
-			})
-		}
-		if r.Readability.GunningFog > maxFog {
-			diagnostics = append(diagnostics, Diagnostic{
-				Line:     1,
-				Severity: SeverityError,
-				Rule:     "readability/gunning-fog",
-				Message:  fmt.Sprintf("Gunning Fog %.1f exceeds threshold %.1f", r.Readability.GunningFog, maxFog),
-			})
-		}
-		if r.Readability.FleschReadingEase < minEase {
-			diagnostics = append(diagnostics, Diagnostic{
-				Line:     1,
-				Severity: SeverityError,
-				Rule:     "readability/flesch-ease",
-				Message:  fmt.Sprintf("Flesch Reading Ease %.1f below threshold %.1f", r.Readability.FleschReadingEase, minEase),
-			})
-		}
+		diagnostics = append(diagnostics, readabilityDiagnostics(r.Readability, r.Vocabulary, r.Structural.Words, t)...)
 	}
 
 	// Line limit always applies
-	if maxLines > 0 && r.Structural.Lines > maxLines {
+	if maxLines > 0 && lines > maxLines {
 		diagnostics = append(diagnostics, Diagnostic{
 			Line:     1,
 			Severity: SeverityError,
 			Rule:     "structure/max-lines",
-			Message:  fmt.Sprintf("%d lines exceeds threshold %d", r.Structural.Lines, maxLines),
+			Message:  fmt.Sprintf("%d %s exceeds threshold %d", lines, lineKind, maxLines),
 		})
 	}
 
@@ -249,20 +323,194 @@ func (a *Analyzer) collectDiagnostics(r *Result) []Diagnostic {
 	return diagnostics
 }
 
-// determineStatus returns pass/fail based on diagnostics.
-func (a *Analyzer) determineStatus(diagnostics []Diagnostic) string {
+// thresholds returns the thresholds for a path: the configured ones, or
+// the deprecated Analyzer.Thresholds when there is no config.
+func (a *Analyzer) thresholds(path string) config.Thresholds {
+	if a.Config != nil {
+		return a.Config.ThresholdsForPath(path)
+	}
+	return config.Thresholds{
+		MaxGrade:       a.Thresholds.MaxFleschKincaidGrade,
+		MaxARI:         a.Thresholds.MaxARI,
+		MaxFog:         a.Thresholds.MaxGunningFog,
+		MinEase:        a.Thresholds.MinFleschReadingEase,
+		MaxLines:       a.Thresholds.MaxLines,
+		MinWords:       100, // Default minimum
+		MinAdmonitions: 1,
+		MaxDashDensity: 0, // No dashes allowed by default
+	}
+}
+
+// readabilityDiagnostics checks readability scores against thresholds.
+// Readability formulas produce unreliable results with sparse prose, so
+// text with fewer than min_words words, or none at all, is not checked.
+func readabilityDiagnostics(m Readability, v Vocabulary, words int, t config.Thresholds) []Diagnostic {
+	if words == 0 || (t.MinWords > 0 && words < t.MinWords) {
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+	if m.FleschKincaidGrade > t.MaxGrade {
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     1,
+			Severity: SeverityError,
+			Rule:     "readability/grade-level",
+			Message:  fmt.Sprintf("Flesch-Kincaid grade %.1f exceeds threshold %.1f", m.FleschKincaidGrade, t.MaxGrade),
+		})
+	}
+	if m.ARI > t.MaxARI {
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     1,
+			Severity: SeverityError,
+			Rule:     "readability/ari",
+			Message:  fmt.Sprintf("ARI %.1f exceeds threshold %.1f", m.ARI, t.MaxARI),
+		})
+	}
+	if m.GunningFog > t.MaxFog {
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     1,
+			Severity: SeverityError,
+			Rule:     "readability/gunning-fog",
+			Message:  fmt.Sprintf("Gunning Fog %.1f exceeds threshold %.1f", m.GunningFog, t.MaxFog),
+		})
+	}
+	if m.FleschReadingEase < t.MinEase {
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     1,
+			Severity: SeverityError,
+			Rule:     "readability/flesch-ease",
+			Message:  fmt.Sprintf("Flesch Reading Ease %.1f below threshold %.1f", m.FleschReadingEase, t.MinEase),
+		})
+	}
+	if t.MaxRareWordRatio > 0 && v.RareWordRatio > t.MaxRareWordRatio {
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     1,
+			Severity: SeverityError,
+			Rule:     "readability/rare-words",
+			Message:  rareWordsMessage(v, t.MaxRareWordRatio),
+		})
+	}
+	return diagnostics
+}
+
+// measureReadability computes the readability formulas for prose. Prose
+// without words, such as a notebook of only code cells, scores zero
+// instead of the NaN the formulas give when dividing by no words.
+func measureReadability(prose string) Readability {
+	if textstats.WordCount(prose) == 0 {
+		return Readability{}
+	}
+	return Readability{
+		FleschKincaidGrade: textstats.FleschKincaidGradeLevel(prose),
+		FleschReadingEase:  textstats.FleschKincaidReadingEase(prose),
+		ARI:                textstats.AutomatedReadabilityIndex(prose),
+		ColemanLiau:        textstats.ColemanLiauIndex(prose),
+		GunningFog:         textstats.GunningFogScore(prose),
+		SMOG:               textstats.SMOGIndex(prose),
+	}
+}
+
+// contentDiagnostics runs rules that inspect the document text and report
+// the line where each issue occurs.
+func (a *Analyzer) contentDiagnostics(path string, parsed *parsedFile, sentences []sentence) ([]Diagnostic, error) {
+	diagnostics := undefinedAcronyms(parsed.Segments, a.allowedAcronyms())
+	diagnostics = append(diagnostics, repeatedWords(parsed.Segments)...)
+	diagnostics = append(diagnostics, missingLeadIns(parsed.Headings)...)
+
+	if a.Config != nil {
+		rules := a.Config.FrontmatterForPath(path)
+		if !hasFrontmatterBlock(path) {
+			rules.Required = nil // Metadata read from other formats is checked only when present
+		}
+		diagnostics = append(diagnostics, frontmatterDiagnostics(parsed.Frontmatter, rules)...)
+		t := a.Config.ThresholdsForPath(path)
+		if t.MaxSentenceStartRun > 0 {
+			diagnostics = append(diagnostics, sentenceStartRuns(sentences, t.MaxSentenceStartRun)...)
+		}
+		if t.MinSentenceLengthVariance > 0 {
+			diagnostics = append(diagnostics, monotonousPassages(sentences, t.MinSentenceLengthVariance)...)
+		}
+		diagnostics = append(diagnostics, longParagraphs(parsed.Paragraphs, t.MaxParagraphWords, t.MaxParagraphSentences)...)
+	}
+
+	if a.ruleEnabled(path, "content/spelling") {
+		words, err := a.projectWords()
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, misspellings(parsed.Segments, words, &a.suggestions)...)
+	}
+
+	if a.Config != nil && a.Config.HeadingCase.Style != "" {
+		diagnostics = append(diagnostics, headingCaseDiagnostics(parsed.Headings, a.Config.HeadingCase)...)
+	}
+
+	if a.ruleEnabled(path, "content/inclusive-language") {
+		diagnostics = append(diagnostics, nonInclusiveTerms(parsed.Segments, a.inclusiveMatchers())...)
+	}
+
+	return diagnostics, nil
+}
+
+// optInRules only report when enabled with a severity in the rules section.
+var optInRules = map[string]bool{
+	"content/spelling":           true,
+	"content/inclusive-language": true,
+}
+
+// ruleEnabled reports whether a rule reports diagnostics for the path.
+// Rules are enabled unless set to off, except opt-in rules which must be
+// given a severity.
+func (a *Analyzer) ruleEnabled(path, rule string) bool {
+	if a.Config == nil {
+		return !optInRules[rule]
+	}
+	severity, ok := a.Config.RulesForPath(path)[rule]
+	if !ok {
+		return !optInRules[rule]
+	}
+	return severity != config.SeverityOff
+}
+
+// applyRuleSeverities replaces built-in severities with those configured in
+// the rules section. Diagnostics from rules set to "off" are dropped.
+func (a *Analyzer) applyRuleSeverities(path string, diagnostics []Diagnostic) []Diagnostic {
+	if a.Config == nil {
+		return diagnostics
+	}
+	rules := a.Config.RulesForPath(path)
+	if len(rules) == 0 {
+		return diagnostics
+	}
+
+	kept := diagnostics[:0]
 	for _, d := range diagnostics {
-		if d.Severity == SeverityError {
-			return "fail"
+		if name, ok := rules[d.Rule]; ok {
+			if name == config.SeverityOff {
+				continue
+			}
+			if severity, ok := ParseSeverity(name); ok {
+				d.Severity = severity
+			}
 		}
+		kept = append(kept, d)
 	}
-	// Warnings also cause failure (to maintain backward compatibility)
+	return kept
+}
+
+// determineStatus returns fail on any error, warn on any warning, and pass otherwise.
+// Info diagnostics never change the status.
+func (a *Analyzer) determineStatus(diagnostics []Diagnostic) string {
+	status := StatusPass
 	for _, d := range diagnostics {
-		if d.Severity == SeverityWarning {
-			return "fail"
+		switch d.Severity {
+		case SeverityError:
+			return StatusFail
+		case SeverityWarning:
+			status = StatusWarn
 		}
 	}
-	return "pass"
+	return status
 }
 
 // stripFrontmatter removes YAML frontmatter from content.
@@ -301,14 +549,59 @@ func countSentences(text string) int {
 	return count
 }
 
-// calculateReadingTime estimates reading time at 200 WPM for technical content.
-// Uses ceiling division to round up (201 words = 2 minutes, not 1).
-func calculateReadingTime(words int) int {
-	if words <= 0 {
+// defaultWordsPerMinute is the reading pace used when no model is configured.
+const defaultWordsPerMinute = 200
+
+// readingTimeModel returns the configured reading time model.
+func (a *Analyzer) readingTimeModel() config.ReadingTime {
+	if a.Config == nil {
+		return config.ReadingTime{WordsPerMinute: defaultWordsPerMinute}
+	}
+	return a.Config.ReadingTime
+}
+
+// calculateReadingTime estimates reading time in seconds.
+// Prose is read at the model's words per minute; code lines, images,
+// and tables each add their configured number of seconds.
+// Partial seconds round up so any content takes at least one second.
+func calculateReadingTime(model config.ReadingTime, words, codeLines, images, tables int) int {
+	wpm := model.WordsPerMinute
+	if wpm <= 0 {
+		wpm = defaultWordsPerMinute
+	}
+
+	seconds := 0.0
+	if words > 0 {
+		seconds += float64(words) * 60 / float64(wpm)
+	}
+	if codeLines > 0 {
+		seconds += float64(codeLines) * model.SecondsPerCodeLine
+	}
+	if images > 0 {
+		seconds += float64(images) * model.SecondsPerImage
+	}
+	if tables > 0 {
+		seconds += float64(tables) * model.SecondsPerTable
+	}
+
+	return int(math.Ceil(seconds))
+}
+
+// proseLines counts the lines that are not code, tables, lists,
+// admonitions, HTML, frontmatter, or blank.
+func proseLines(parsed *markdown.ParseResult) int {
+	return parsed.TotalLines - parsed.CodeLines - parsed.TableLines - parsed.ListLines -
+		parsed.AdmonitionLines - parsed.HTMLLines - parsed.FrontmatterLines - parsed.EmptyLines
+}
+
+// ReadingTimeMinutes converts a reading time in seconds to whole minutes.
+// Uses ceiling division to round up (61 seconds = 2 minutes, not 1).
+// All output formats use this so per-file and total estimates agree.
+func ReadingTimeMinutes(seconds int) int {
+	if seconds <= 0 {
 		return 0
 	}
-	// Ceiling division: (words + 199) / 200
-	return (words + 199) / 200
+	return (seconds + 59) / 60
 }
 
 // calculateRatio safely calculates a ratio.
@@ -1,8 +1,10 @@
 package analyzer
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -189,8 +191,10 @@ Additionally, synthesizing heterogeneous data sources requires meticulous consid
 		t.Fatalf("Analyze() error = %v", err)
 	}
 
-	if result.Status != "fail" {
-		t.Errorf("Expected fail status for complex content, got %q", result.Status)
+	// The content is under the default 100-word minimum, so readability
+	// formulas are skipped and only the admonition warning applies.
+	if result.Status != "warn" {
+		t.Errorf("Expected warn status for complex content, got %q", result.Status)
 	}
 
 	if len(result.Diagnostics) == 0 {
@@ -270,9 +274,15 @@ func TestAnalyzeDirectory(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	files := map[string]string{
-		"doc1.md":          "# Doc 1\n\nContent one.",
-		"doc2.md":          "# Doc 2\n\nContent two.",
-		"subdir/doc3.md":   "# Doc 3\n\nContent three.",
+		"doc1.md":           "# Doc 1\n\nContent one.",
+		"doc2.md":           "# Doc 2\n\nContent two.",
+		"subdir/doc3.md":    "# Doc 3\n\nContent three.",
+		"subdir/doc4.mdx":   "# Doc 4\n\n<Note>Content four.</Note>",
+		"subdir/doc5.adoc":  "= Doc 5\n\nContent five.",
+		"subdir/doc6.rst":   "Doc 6\n=====\n\nContent six.",
+		"subdir/doc7.html":  "<h1>Doc 7</h1>\n<p>Content seven.</p>",
+		"subdir/doc8.ipynb": `{"cells": [{"cell_type": "markdown", "source": "# Doc 8\n\nContent eight."}]}`,
+		"subdir/.ipynb_checkpoints/doc8-checkpoint.ipynb": `{"cells": []}`, // Should be skipped
 		"README.md":        "# README\n\nThis is readme.",
 		"CHANGELOG.md":     "# Changelog\n\nChanges here.",         // Should be skipped
 		"CONTRIBUTING.md":  "# Contributing\n\nHow to contribute.", // Should be skipped
@@ -295,10 +305,13 @@ func TestAnalyzeDirectory(t *testing.T) {
 		t.Fatalf("AnalyzeDirectory() error = %v", err)
 	}
 
-	// Should have doc1.md, doc2.md, subdir/doc3.md, README.md
-	// Should NOT have CHANGELOG.md, CONTRIBUTING.md, not_markdown.txt
-	if len(results) != 4 {
-		t.Errorf("Expected 4 results, got %d", len(results))
+	// Should have doc1.md, doc2.md, subdir/doc3.md, subdir/doc4.mdx,
+	// subdir/doc5.adoc, subdir/doc6.rst, subdir/doc7.html, subdir/doc8.ipynb,
+	// README.md
+	// Should NOT have CHANGELOG.md, CONTRIBUTING.md, not_markdown.txt, or
+	// the notebook checkpoint
+	if len(results) != 9 {
+		t.Errorf("Expected 9 results, got %d", len(results))
 	}
 
 	// Verify CHANGELOG.md and CONTRIBUTING.md are excluded
@@ -310,6 +323,356 @@ func TestAnalyzeDirectory(t *testing.T) {
 	}
 }
 
+func TestAnalyze_MDX(t *testing.T) {
+	content := []byte("import Tabs from '@theme/Tabs';\n\n# Install\n\n<Tabs>\n  <TabItem value=\"npm\">\n\n    Run the the installer.\n\n  </TabItem>\n</Tabs>\n\n:::tip\nA short tip.\n:::\n")
+
+	result, err := New().Analyze("install.mdx", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Admonitions.Count != 1 {
+		t.Errorf("Admonitions.Count = %d, want 1", result.Admonitions.Count)
+	}
+	var found bool
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/repeated-word" {
+			found = true
+			if d.Line != 8 || d.Column != 9 {
+				t.Errorf("repeated word at %d:%d, want 8:9", d.Line, d.Column)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Diagnostics = %+v, want a repeated word in the tab text", result.Diagnostics)
+	}
+
+	// The same content as plain markdown keeps the JSX as text
+	result, err = New().Analyze("install.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Admonitions.Count != 0 {
+		t.Errorf("Admonitions.Count = %d for .md, want 0", result.Admonitions.Count)
+	}
+}
+
+func TestAnalyze_AsciiDoc(t *testing.T) {
+	content := []byte("= Install Guide\n:description: How to install the tool.\n\n== Steps\n\nNOTE: Read this first.\n\nRun the the installer.\n\n[source,bash]\n----\ngo install the the tool\n----\n")
+
+	result, err := New().Analyze("install.adoc", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Admonitions.Count != 1 {
+		t.Errorf("Admonitions.Count = %d, want 1", result.Admonitions.Count)
+	}
+	if result.Headings.H1 != 1 || result.Headings.H2 != 1 {
+		t.Errorf("Headings = %+v, want one H1 and one H2", result.Headings)
+	}
+	var repeated []int
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/repeated-word" {
+			repeated = append(repeated, d.Line, d.Column)
+		}
+	}
+	// Only the prose repeat counts; the one in the listing block is code
+	if !reflect.DeepEqual(repeated, []int{8, 5}) {
+		t.Errorf("repeated word positions = %v, want [8 5]", repeated)
+	}
+}
+
+func TestAnalyze_RST(t *testing.T) {
+	content := []byte("Install Guide\n=============\n\n:description: How to install the SDK.\n\nSteps\n-----\n\n.. note:: Read this first.\n\nRun the the installer::\n\n    pip install the the sdk\n")
+
+	result, err := New().Analyze("install.rst", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Admonitions.Count != 1 {
+		t.Errorf("Admonitions.Count = %d, want 1", result.Admonitions.Count)
+	}
+	if result.Headings.H1 != 1 || result.Headings.H2 != 1 {
+		t.Errorf("Headings = %+v, want one H1 and one H2", result.Headings)
+	}
+	var repeated []int
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/repeated-word" {
+			repeated = append(repeated, d.Line, d.Column)
+		}
+	}
+	// Only the prose repeat counts; the one in the literal block is code
+	if !reflect.DeepEqual(repeated, []int{11, 5}) {
+		t.Errorf("repeated word positions = %v, want [11 5]", repeated)
+	}
+}
+
+func TestAnalyze_HTML(t *testing.T) {
+	content := []byte("<html>\n<head><title>Install</title></head>\n<body>\n<nav><p>the the menu</p></nav>\n" +
+		"<h1>Install Guide</h1>\n<div class=\"panel\">Read this first.</div>\n<h2>Steps</h2>\n" +
+		"<p>Run the the installer.</p>\n<pre>pip install the the sdk</pre>\n</body>\n</html>\n")
+
+	a := NewWithConfig(config.DefaultConfig())
+	a.Config.HTML.AdmonitionClasses = []string{"panel"}
+	result, err := a.Analyze("install.html", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Admonitions.Count != 1 {
+		t.Errorf("Admonitions.Count = %d, want 1", result.Admonitions.Count)
+	}
+	if result.Headings.H1 != 1 || result.Headings.H2 != 1 {
+		t.Errorf("Headings = %+v, want one H1 and one H2", result.Headings)
+	}
+	var repeated []int
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/repeated-word" {
+			repeated = append(repeated, d.Line, d.Column)
+		}
+	}
+	// Only the prose repeat counts; navigation and code are skipped
+	if !reflect.DeepEqual(repeated, []int{8, 8}) {
+		t.Errorf("repeated word positions = %v, want [8 8]", repeated)
+	}
+}
+
+func TestAnalyze_Templates(t *testing.T) {
+	content := []byte("# Install\n\n{{< notice warning >}}\nBack up first.\n{{< /notice >}}\n\n" +
+		"Download {{< param \"version\" >}} from the site.\n")
+
+	tests := []struct {
+		name      string
+		templates config.TemplatesInput
+		wantWords int
+	}{
+		{"not configured", config.TemplatesInput{}, 19},
+		{"hugo", config.TemplatesInput{Profiles: []string{"hugo"}}, 5},
+		{"hugo keeping paired text", config.TemplatesInput{Profiles: []string{"hugo"}, KeepPairedText: true}, 8},
+		{"custom delimiters", config.TemplatesInput{Delimiters: []config.TemplateDelimiters{{Open: "{{<", Close: ">}}"}}}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Templates = tt.templates
+			result, err := NewWithConfig(cfg).Analyze("install.md", content)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if result.Structural.Words != tt.wantWords {
+				t.Errorf("Words = %d, want %d", result.Structural.Words, tt.wantWords)
+			}
+		})
+	}
+}
+
+func TestAnalyze_Snippets(t *testing.T) {
+	dir := t.TempDir()
+	snippet := filepath.Join(dir, "note.md")
+	if err := os.WriteFile(snippet, []byte("Back up\nthe the data first.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "intro.md"), []byte("Read this guide once.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	page := filepath.Join(dir, "guide.md")
+	content := []byte("# Guide\n\n--8<-- \"intro.md\"\n\n1. Before you start:\n\n    --8<-- \"note.md\"\n\n--8<-- \"missing.md\"\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Snippets = config.SnippetsInput{Enabled: true, BasePath: []string{dir}}
+	result, err := NewWithConfig(cfg).Analyze(page, content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Structural.Words != 5 {
+		t.Errorf("Words = %d, want 5", result.Structural.Words)
+	}
+
+	type position struct {
+		rule, file   string
+		line, column int
+	}
+	var found []position
+	for _, d := range result.Diagnostics {
+		switch d.Rule {
+		case "content/repeated-word", "snippets/missing":
+			found = append(found, position{d.Rule, d.File, d.Line, d.Column})
+		}
+	}
+	// The repeat is reported in the snippet, without the list item indent;
+	// the missing include at its directive in the page
+	want := []position{{"content/repeated-word", snippet, 2, 1}, {"snippets/missing", "", 9, 0}}
+	if !reflect.DeepEqual(found, want) {
+		t.Errorf("diagnostics = %v, want %v", found, want)
+	}
+}
+
+func TestAnalyze_Notebook(t *testing.T) {
+	content := []byte(`{
+ "cells": [
+  {"cell_type": "markdown", "source": ["# Tutorial\n", "\n", "Load the data first.\n"]},
+  {"cell_type": "code", "source": ["print(\"the the\")\n", "x = 1"]},
+  {"cell_type": "markdown", "source": "## Results\n\nThe chart shows\nthe the totals."}
+ ],
+ "metadata": {"language_info": {"name": "python"}}
+}`)
+
+	result, err := New().Analyze("tutorial.ipynb", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Composition.MarkdownCells != 2 || result.Composition.CodeCells != 1 {
+		t.Errorf("cells = %d markdown, %d code, want 2, 1",
+			result.Composition.MarkdownCells, result.Composition.CodeCells)
+	}
+	if result.Composition.CodeLines != 2 || result.Composition.TotalLines != 9 {
+		t.Errorf("CodeLines, TotalLines = %d, %d, want 2, 9",
+			result.Composition.CodeLines, result.Composition.TotalLines)
+	}
+	var repeated []int
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/repeated-word" {
+			repeated = append(repeated, d.Cell, d.Line, d.Column)
+		}
+	}
+	// Only the markdown repeat counts, reported by cell and line in the cell
+	if !reflect.DeepEqual(repeated, []int{3, 4, 1}) {
+		t.Errorf("repeated word cell, line, column = %v, want [3 4 1]", repeated)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Line > 0 && d.Cell == 0 {
+			t.Errorf("diagnostic %+v has no cell", d)
+		}
+	}
+}
+
+func TestAnalyze_NotebookWithoutMarkdown(t *testing.T) {
+	content := []byte(`{"cells": [{"cell_type": "code", "source": ["print(1)\n"]}]}`)
+
+	result, err := New().Analyze("code.ipynb", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Readability != (Readability{}) {
+		t.Errorf("Readability = %+v, want zero scores", result.Readability)
+	}
+	for _, d := range result.Diagnostics {
+		if strings.HasPrefix(d.Rule, "readability/") {
+			t.Errorf("unexpected diagnostic %+v", d)
+		}
+	}
+	if _, err := json.Marshal(result); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
+
+func TestAnalyze_GoDoc(t *testing.T) {
+	content := []byte(`// Package jobs runs work.
+package jobs
+
+// Scheduler is the thing.
+type Scheduler struct{}
+
+// Reconcile performs comprehensive synchronization of heterogeneous
+// infrastructure configurations, systematically evaluating organizational
+// compliance requirements against authoritative declarative specifications
+// while simultaneously orchestrating asynchronous remediation procedures.
+func (s *Scheduler) Reconcile() {}
+`)
+
+	cfg := config.DefaultConfig()
+	result, err := NewWithConfig(cfg).Analyze("jobs.go", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Composition.TotalLines != 6 {
+		t.Errorf("TotalLines = %d, want 6 doc comment lines", result.Composition.TotalLines)
+	}
+	var lines []int
+	for _, d := range result.Diagnostics {
+		switch {
+		case d.Rule == "content/admonitions" || strings.HasPrefix(d.Rule, "frontmatter/"):
+			t.Errorf("unexpected %s diagnostic for a Go file", d.Rule)
+		case d.Rule == "readability/grade-level":
+			lines = append(lines, d.Line)
+			if !strings.HasSuffix(d.Message, " in the doc comment of method Scheduler.Reconcile") {
+				t.Errorf("Message = %q, want it to name the method", d.Message)
+			}
+		}
+	}
+	// One-liners are under go_doc.min_words and are not scored
+	if !reflect.DeepEqual(lines, []int{7}) {
+		t.Errorf("grade-level diagnostic lines = %v, want [7]", lines)
+	}
+
+	cfg.GoDoc.MinWords = 100
+	result, err = NewWithConfig(cfg).Analyze("jobs.go", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	for _, d := range result.Diagnostics {
+		if strings.HasPrefix(d.Rule, "readability/") {
+			t.Errorf("unexpected %s diagnostic under min_words", d.Rule)
+		}
+	}
+}
+
+func TestAnalyzeDirectory_GoDoc(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"doc.md":                 "# Doc\n\nContent.",
+		"jobs.go":                "// Package jobs runs work.\npackage jobs\n",
+		"plain.go":               "package jobs\n\nfunc f() {}\n",
+		"jobs_test.go":           "package jobs\n",
+		"vendor/dep/dep.go":      "package dep\n",
+		"internal/testdata/x.go": "package x\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		enabled bool
+		want    []string
+	}{
+		{false, []string{"doc.md"}},
+		{true, []string{"doc.md", "jobs.go"}},
+	}
+	for _, tt := range tests {
+		cfg := config.DefaultConfig()
+		cfg.GoDoc.Enabled = tt.enabled
+		results, err := NewWithConfig(cfg).AnalyzeDirectory(tmpDir)
+		if err != nil {
+			t.Fatalf("AnalyzeDirectory() error = %v", err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, filepath.Base(r.File))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("enabled=%v: files = %v, want %v", tt.enabled, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyze_GoWithoutDocComments(t *testing.T) {
+	result, err := New().Analyze("plain.go", []byte("package x\n\nfunc f() {}\n"))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %+v, want none", result.Diagnostics)
+	}
+	if _, err := json.Marshal(result); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
+
 func TestAnalyzeDirectory_NotFound(t *testing.T) {
 	a := New()
 	_, err := a.AnalyzeDirectory("/nonexistent/directory")
@@ -443,6 +806,22 @@ func TestDetermineStatus(t *testing.T) {
 			diagnostics: []Diagnostic{
 				{Severity: SeverityWarning, Rule: "test", Message: "warning"},
 			},
+			want: "warn",
+		},
+		{
+			name: "warning and info",
+			diagnostics: []Diagnostic{
+				{Severity: SeverityInfo, Rule: "test1", Message: "info"},
+				{Severity: SeverityWarning, Rule: "test2", Message: "warning"},
+			},
+			want: "warn",
+		},
+		{
+			name: "warning before error",
+			diagnostics: []Diagnostic{
+				{Severity: SeverityWarning, Rule: "test1", Message: "warning"},
+				{Severity: SeverityError, Rule: "test2", Message: "error"},
+			},
 			want: "fail",
 		},
 		{
@@ -586,16 +965,72 @@ func TestCalculateReadingTime(t *testing.T) {
 		{"1001 words rounds up", 1001, 6},
 	}
 
+	model := config.DefaultConfig().ReadingTime
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := calculateReadingTime(tt.words)
+			got := ReadingTimeMinutes(calculateReadingTime(model, tt.words, 0, 0, 0))
 			if got != tt.want {
-				t.Errorf("calculateReadingTime(%d) = %d, want %d", tt.words, got, tt.want)
+				t.Errorf("reading time for %d words = %d, want %d", tt.words, got, tt.want)
 			}
 		})
 	}
 }
 
+func TestCalculateReadingTime_Model(t *testing.T) {
+	tests := []struct {
+		name                        string
+		model                       config.ReadingTime
+		words, code, images, tables int
+		want                        int
+	}{
+		{"words at custom pace", config.ReadingTime{WordsPerMinute: 100}, 150, 0, 0, 0, 90},
+		{"zero pace falls back to default", config.ReadingTime{}, 200, 0, 0, 0, 60},
+		{"code lines add time", config.ReadingTime{WordsPerMinute: 200, SecondsPerCodeLine: 2}, 200, 30, 0, 0, 120},
+		{"images add time", config.ReadingTime{WordsPerMinute: 200, SecondsPerImage: 12}, 0, 0, 2, 0, 24},
+		{"tables add time", config.ReadingTime{WordsPerMinute: 200, SecondsPerTable: 15}, 0, 0, 0, 3, 45},
+		{"fractional seconds round up", config.ReadingTime{WordsPerMinute: 200}, 1, 0, 0, 0, 1},
+		{"default model ignores code", config.DefaultConfig().ReadingTime, 0, 100, 4, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateReadingTime(tt.model, tt.words, tt.code, tt.images, tt.tables)
+			if got != tt.want {
+				t.Errorf("calculateReadingTime() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze_ReadingTimeModel(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ReadingTime = config.ReadingTime{
+		WordsPerMinute:     200,
+		SecondsPerCodeLine: 3,
+		SecondsPerImage:    10,
+		SecondsPerTable:    20,
+	}
+	a := NewWithConfig(cfg)
+
+	content := "# Tutorial\n\nRun this.\n\n" +
+		"```sh\nmake build\nmake test\n```\n\n" +
+		"![diagram](diagram.png)\n\n" +
+		"| A | B |\n|---|---|\n| 1 | 2 |\n"
+
+	result, err := a.Analyze("tutorial.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	// 4 words incl. alt text (1.2s) + 4 code lines (12s) + 1 image (10s) + 1 table (20s)
+	if result.Structural.ReadingTimeSeconds != 44 {
+		t.Errorf("ReadingTimeSeconds = %d, want 44", result.Structural.ReadingTimeSeconds)
+	}
+	if result.Structural.ReadingTimeMinutes != 1 {
+		t.Errorf("ReadingTimeMinutes = %d, want 1", result.Structural.ReadingTimeMinutes)
+	}
+}
+
 func TestCalculateRatio(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -1103,3 +1538,101 @@ This sentence - which has a dash - violates the rule.`,
 		})
 	}
 }
+
+func TestApplyRuleSeverities(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Rules = config.Rules{
+		"content/admonitions": config.SeverityInfo,
+		"structure/max-lines": config.SeverityOff,
+	}
+	cfg.Overrides = []config.PathOverride{
+		{
+			Path:  "docs/api/",
+			Rules: config.Rules{"content/admonitions": config.SeverityOff},
+		},
+	}
+	a := NewWithConfig(cfg)
+
+	diagnostics := func() []Diagnostic {
+		return []Diagnostic{
+			{Severity: SeverityWarning, Rule: "content/admonitions"},
+			{Severity: SeverityError, Rule: "structure/max-lines"},
+			{Severity: SeverityError, Rule: "readability/grade-level"},
+		}
+	}
+
+	got := a.applyRuleSeverities("docs/guide.md", diagnostics())
+	if len(got) != 2 {
+		t.Fatalf("got %d diagnostics, want 2: %+v", len(got), got)
+	}
+	if got[0].Rule != "content/admonitions" || got[0].Severity != SeverityInfo {
+		t.Errorf("admonitions diagnostic = %+v, want info severity", got[0])
+	}
+	if got[1].Rule != "readability/grade-level" || got[1].Severity != SeverityError {
+		t.Errorf("unlisted rule = %+v, want built-in error severity", got[1])
+	}
+
+	got = a.applyRuleSeverities("docs/api/ref.md", diagnostics())
+	if len(got) != 1 || got[0].Rule != "readability/grade-level" {
+		t.Errorf("override should turn off admonitions, got %+v", got)
+	}
+}
+
+func TestAnalyze_AdvisoryAdmonitions(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Rules = config.Rules{"content/admonitions": config.SeverityInfo}
+	a := NewWithConfig(cfg)
+
+	result, err := a.Analyze("test.md", []byte("# Title\n\nShort page without callouts."))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if result.Status != StatusPass {
+		t.Errorf("Status = %q, want pass when admonitions are advisory", result.Status)
+	}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0].Severity != SeverityInfo {
+		t.Errorf("Expected one info diagnostic, got %+v", result.Diagnostics)
+	}
+	if !result.FailsOn(SeverityInfo) {
+		t.Error("FailsOn(info) should fail a result with info diagnostics")
+	}
+	if result.FailsOn(SeverityWarning) {
+		t.Error("FailsOn(warning) should not fail a result with only info diagnostics")
+	}
+}
+
+func TestAnalyze_MaxLinesScope(t *testing.T) {
+	content := "# Title\n\nShort intro.\n\n```\n" + strings.Repeat("code line\n", 30) + "```\n\n" +
+		strings.Repeat("- item\n", 20) + "\nClosing words.\n"
+
+	tests := []struct {
+		scope    string
+		wantFail bool
+	}{
+		{config.MaxLinesScopeAll, true},
+		{config.MaxLinesScopeProse, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Thresholds.MaxLines = 10
+			cfg.Thresholds.MaxLinesScope = tt.scope
+			result, err := NewWithConfig(cfg).Analyze("test.md", []byte(content))
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if result.Composition.ProseLines != 3 || result.Composition.ListLines != 20 || result.Composition.CodeLines != 32 {
+				t.Errorf("Composition = %+v, want 3 prose, 20 list, 32 code lines", result.Composition)
+			}
+			found := false
+			for _, d := range result.Diagnostics {
+				found = found || d.Rule == "structure/max-lines"
+			}
+			if found != tt.wantFail {
+				t.Errorf("structure/max-lines reported = %v, want %v", found, tt.wantFail)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// hasFrontmatterBlock reports whether a file is Markdown or MDX, the formats
+// that carry a YAML or TOML frontmatter block. Other formats may map their
+// own metadata to frontmatter, such as AsciiDoc header attributes, but are
+// not expected to declare every required key.
+func hasFrontmatterBlock(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".mdx":
+		return true
+	}
+	return false
+}
+
+// frontmatterDiagnostics checks page frontmatter against the configured keys
+// and value constraints. Problems are reported at the line of the key, or at
+// line 1 when the key or the whole block is missing.
+func frontmatterDiagnostics(fm *markdown.Frontmatter, rules config.FrontmatterRules) []Diagnostic {
+	if len(rules.Required) == 0 && len(rules.Fields) == 0 {
+		return nil
+	}
+
+	problem := func(line int, rule, format string, args ...any) Diagnostic {
+		return Diagnostic{
+			Line:     line,
+			Severity: SeverityError,
+			Rule:     rule,
+			Message:  fmt.Sprintf(format, args...),
+		}
+	}
+
+	if fm == nil {
+		if len(rules.Required) == 0 {
+			return nil
+		}
+		return []Diagnostic{problem(1, "frontmatter/missing", "Page has no frontmatter. Required keys: %s", strings.Join(rules.Required, ", "))}
+	}
+	if fm.Err != nil {
+		return []Diagnostic{problem(1, "frontmatter/invalid", "%v", fm.Err)}
+	}
+
+	var diagnostics []Diagnostic
+	for _, key := range rules.Required {
+		if _, ok := fm.Fields[key]; !ok {
+			diagnostics = append(diagnostics, problem(1, "frontmatter/missing", "Frontmatter is missing required key %q", key))
+		}
+	}
+
+	// Check fields in a stable order
+	keys := make([]string, 0, len(rules.Fields))
+	for key := range rules.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := fm.Fields[key]
+		if !ok {
+			continue
+		}
+		field := rules.Fields[key]
+		line := fm.Lines[key]
+		if line == 0 {
+			line = 1
+		}
+
+		if field.Type != "" && !hasFieldType(value, field.Type) {
+			diagnostics = append(diagnostics, problem(line, "frontmatter/type", "Frontmatter key %q should be %s, got %s", key, withArticle(field.Type), withArticle(fieldType(value))))
+			continue
+		}
+		if len(field.Allowed) > 0 {
+			for _, v := range fieldValues(value) {
+				if !isAllowedValue(v, field.Allowed) {
+					diagnostics = append(diagnostics, problem(line, "frontmatter/value", "Frontmatter key %q has value %q, expected one of: %s", key, fmt.Sprint(v), strings.Join(field.Allowed, ", ")))
+				}
+			}
+		}
+		if n, unit, ok := fieldLength(value); ok {
+			switch {
+			case field.MinLength > 0 && n < field.MinLength:
+				diagnostics = append(diagnostics, problem(line, "frontmatter/length", "Frontmatter key %q has %d %s, minimum is %d", key, n, unit, field.MinLength))
+			case field.MaxLength > 0 && n > field.MaxLength:
+				diagnostics = append(diagnostics, problem(line, "frontmatter/length", "Frontmatter key %q has %d %s, maximum is %d", key, n, unit, field.MaxLength))
+			}
+		}
+	}
+	return diagnostics
+}
+
+// fieldType names the type of a decoded YAML or TOML value.
+func fieldType(value any) string {
+	switch value.(type) {
+	case string:
+		return config.FieldString
+	case bool:
+		return config.FieldBoolean
+	case int, int64, uint64:
+		return config.FieldInteger
+	case float64:
+		return config.FieldNumber
+	case []any, []map[string]any:
+		return config.FieldArray
+	case map[string]any:
+		return config.FieldObject
+	case time.Time:
+		return config.FieldDate
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// hasFieldType reports whether a value matches the expected type. Integers
+// are numbers, and date strings in YYYY-MM-DD form are dates.
+func hasFieldType(value any, want string) bool {
+	got := fieldType(value)
+	switch {
+	case got == want:
+		return true
+	case want == config.FieldNumber && got == config.FieldInteger:
+		return true
+	case want == config.FieldDate && got == config.FieldString:
+		_, err := time.Parse(time.DateOnly, value.(string))
+		return err == nil
+	}
+	return false
+}
+
+// fieldValues returns the items of an array, or the value itself.
+func fieldValues(value any) []any {
+	if items, ok := value.([]any); ok {
+		return items
+	}
+	return []any{value}
+}
+
+// isAllowedValue reports whether a value's text matches an allowed value.
+func isAllowedValue(value any, allowed []string) bool {
+	text := fmt.Sprint(value)
+	for _, a := range allowed {
+		if text == a {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldLength returns the characters in a string or the items in an array.
+func fieldLength(value any) (int, string, bool) {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v), "characters", true
+	case []any:
+		return len(v), "items", true
+	case []map[string]any:
+		return len(v), "items", true
+	}
+	return 0, "", false
+}
+
+// withArticle prefixes a type name with "a" or "an".
+func withArticle(name string) string {
+	if strings.ContainsRune("aeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
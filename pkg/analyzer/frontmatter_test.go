@@ -0,0 +1,158 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestFrontmatterDiagnostics(t *testing.T) {
+	rules := config.FrontmatterRules{
+		Required: []string{"title", "description", "tags"},
+		Fields: map[string]config.FrontmatterField{
+			"description": {Type: config.FieldString, MinLength: 20, MaxLength: 160},
+			"tags":        {Type: config.FieldArray, Allowed: []string{"cli", "config"}, MinLength: 1},
+			"status":      {Allowed: []string{"draft", "published"}},
+			"weight":      {Type: config.FieldNumber},
+			"date":        {Type: config.FieldDate},
+		},
+	}
+
+	type want struct {
+		rule string
+		line int
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    []want
+	}{
+		{
+			name:    "valid",
+			content: "---\ntitle: Guide\ndescription: How to configure the tool for a project.\ntags: [cli]\nweight: 2\ndate: 2024-05-01\n---\n",
+		},
+		{
+			name:    "date string",
+			content: "+++\ntitle = \"Guide\"\ndescription = \"How to configure the tool for a project.\"\ntags = [\"cli\"]\ndate = \"2024-05-01\"\n+++\n",
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Guide\n",
+			want:    []want{{"frontmatter/missing", 1}},
+		},
+		{
+			name:    "missing keys",
+			content: "---\ntitle: Guide\n---\n",
+			want:    []want{{"frontmatter/missing", 1}, {"frontmatter/missing", 1}},
+		},
+		{
+			name:    "wrong values",
+			content: "---\ntitle: Guide\ndescription: Too short.\ntags: [cli, misc]\nstatus: done\nweight: heavy\ndate: soon\n---\n",
+			want: []want{
+				{"frontmatter/type", 7},
+				{"frontmatter/length", 3},
+				{"frontmatter/value", 5},
+				{"frontmatter/value", 4},
+				{"frontmatter/type", 6},
+			},
+		},
+		{
+			name:    "invalid",
+			content: "---\ntitle: [oops\n---\n",
+			want:    []want{{"frontmatter/invalid", 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := markdown.Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got := frontmatterDiagnostics(parsed.Frontmatter, rules)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d diagnostics, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, d := range got {
+				if d.Rule != tt.want[i].rule || d.Line != tt.want[i].line {
+					t.Errorf("diagnostic %d = %+v, want %s at line %d", i, d, tt.want[i].rule, tt.want[i].line)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyze_FrontmatterOverride(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Frontmatter.Required = []string{"title", "tags"}
+	cfg.Overrides = []config.PathOverride{
+		{Path: "docs/blog/", Frontmatter: config.FrontmatterRules{Required: []string{"title", "date"}}},
+	}
+	content := []byte("---\ntitle: Post\ntags: [news]\n---\n# Post\n")
+
+	count := func(path string) int {
+		result, err := NewWithConfig(cfg).Analyze(path, content)
+		if err != nil {
+			t.Fatalf("Analyze() error = %v", err)
+		}
+		n := 0
+		for _, d := range result.Diagnostics {
+			if d.Rule == "frontmatter/missing" {
+				n++
+			}
+		}
+		return n
+	}
+
+	if got := count("docs/guide.md"); got != 0 {
+		t.Errorf("docs/guide.md: %d missing-key diagnostics, want 0", got)
+	}
+	if got := count("docs/blog/post.md"); got != 1 {
+		t.Errorf("docs/blog/post.md: %d missing-key diagnostics, want 1 for date", got)
+	}
+}
+
+func TestAnalyze_FrontmatterRequiredOnlyInMarkdown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Frontmatter = config.FrontmatterRules{
+		Required: []string{"title"},
+		Fields:   map[string]config.FrontmatterField{"description": {Type: "string", MinLength: 20}},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []string
+	}{
+		{"markdown", "page.md", "# Page\n\nText.\n", []string{"frontmatter/missing"}},
+		{"mdx", "page.mdx", "# Page\n\nText.\n", []string{"frontmatter/missing"}},
+		{"asciidoc", "page.adoc", "= Page\n\nText.\n", nil},
+		{"asciidoc fields still checked", "page.adoc", "= Page\n:description: Short.\n\nText.\n", []string{"frontmatter/length"}},
+		{"rst", "page.rst", "Page\n====\n\nText.\n", nil},
+		{"html", "page.html", "<h1>Page</h1><p>Text.</p>", nil},
+		{"notebook", "page.ipynb", `{"cells": [{"cell_type": "markdown", "source": "# Page"}]}`, nil},
+		{"go", "page.go", "// Package page does things.\npackage page\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewWithConfig(cfg).Analyze(tt.path, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			var got []string
+			for _, d := range result.Diagnostics {
+				if strings.HasPrefix(d.Rule, "frontmatter/") {
+					got = append(got, d.Rule)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("frontmatter diagnostics = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
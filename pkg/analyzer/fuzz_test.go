@@ -3,6 +3,8 @@ package analyzer
 import (
 	"strings"
 	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
 )
 
 // FuzzStripFrontmatter tests the stripFrontmatter function with arbitrary input.
@@ -195,8 +197,10 @@ func FuzzCalculateReadingTime(f *testing.F) {
 	f.Add(1<<30 - 1)  // Large positive
 	f.Add(-(1 << 30)) // Large negative
 
+	model := config.DefaultConfig().ReadingTime
+
 	f.Fuzz(func(t *testing.T, words int) {
-		result := calculateReadingTime(words)
+		result := ReadingTimeMinutes(calculateReadingTime(model, words, 0, 0, 0))
 
 		// calculateReadingTime should never panic
 
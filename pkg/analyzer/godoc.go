@@ -0,0 +1,61 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/godoc"
+)
+
+// defaultDocCommentMinWords is the minimum number of words in a doc
+// comment for readability checks when there is no config.
+const defaultDocCommentMinWords = 15
+
+// docCommentDiagnostics scores each doc comment of a Go source file on its
+// own. Diagnostics point at the first line of the comment and name the
+// symbol it documents. Comments shorter than go_doc.min_words are skipped.
+func (a *Analyzer) docCommentDiagnostics(path string, units []godoc.Unit) []Diagnostic {
+	if len(units) == 0 {
+		return nil
+	}
+
+	t := a.thresholds(path)
+	t.MinWords = defaultDocCommentMinWords
+	if a.Config != nil {
+		t.MinWords = a.Config.GoDoc.MinWords
+	}
+
+	var diagnostics []Diagnostic
+	for _, u := range units {
+		words := countWords(u.Prose)
+		if words == 0 {
+			continue
+		}
+		found := readabilityDiagnostics(measureReadability(u.Prose), analyzeVocabulary(u.Prose, a.glossary()), words, t)
+		for _, d := range found {
+			d.Line = u.Line
+			d.Message += " in the doc comment of " + u.Symbol
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// readsGoDoc reports whether directory analysis includes Go source files.
+func (a *Analyzer) readsGoDoc() bool {
+	return a.Config != nil && a.Config.GoDoc.Enabled
+}
+
+// isGoSource reports whether a file is Go source whose doc comments are
+// published: test files and files under vendor or testdata are not.
+func isGoSource(path string) bool {
+	if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+		return false
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if dir == "vendor" || dir == "testdata" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// titleSmallWords stay lowercase in title case unless they start or end
+// the heading or follow a colon: articles, coordinating conjunctions, and
+// common prepositions.
+var titleSmallWords = map[string]bool{
+	"a": true, "an": true, "the": true,
+	"and": true, "but": true, "for": true, "nor": true, "or": true, "so": true, "yet": true,
+	"as": true, "at": true, "by": true, "from": true, "in": true, "into": true, "of": true,
+	"off": true, "on": true, "onto": true, "per": true, "to": true, "up": true, "via": true,
+	"vs": true, "with": true,
+}
+
+// headingWord is one space-separated token of a heading.
+type headingWord struct {
+	prefix, core, suffix string // Punctuation around the word is kept as is
+	preserved            bool   // Core must not change (product name, code, acronym)
+}
+
+// headingCaseDiagnostics reports headings that do not follow the configured
+// capitalization style and suggests the corrected heading.
+func headingCaseDiagnostics(headings []markdown.Heading, hc config.HeadingCase) []Diagnostic {
+	convert := sentenceCase
+	if hc.Style == config.HeadingCaseTitle {
+		convert = titleCase
+	}
+
+	var diagnostics []Diagnostic
+	for _, h := range headings {
+		text := strings.TrimSpace(h.Text)
+		if text == "" {
+			continue
+		}
+		want := convert(text, hc.Preserve)
+		if want == text {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:        h.Line,
+			Severity:    SeverityWarning,
+			Rule:        "style/heading-case",
+			Message:     fmt.Sprintf("Heading should use %s case: %q", hc.Style, want),
+			Suggestions: []string{want},
+		})
+	}
+	return diagnostics
+}
+
+// sentenceCase capitalizes the first word and any word after a colon, and
+// lowercases the rest. Preserved terms, code, acronyms, and words with
+// inner capitals (GitHub, macOS) are left unchanged.
+func sentenceCase(text string, preserve []string) string {
+	words := splitHeading(text, preserve)
+	for i := range words {
+		w := &words[i]
+		if w.preserved {
+			continue
+		}
+		if i == 0 || followsColon(words, i) {
+			w.core = capitalizeFirst(strings.ToLower(w.core))
+		} else {
+			w.core = strings.ToLower(w.core)
+		}
+	}
+	return joinHeading(words)
+}
+
+// titleCase capitalizes every word except small words in the middle of the
+// heading. Each part of a hyphenated word is treated as a word, so
+// "step-by-step" becomes "Step-by-Step".
+func titleCase(text string, preserve []string) string {
+	words := splitHeading(text, preserve)
+	for i := range words {
+		w := &words[i]
+		if w.preserved {
+			continue
+		}
+		edge := i == 0 || i == len(words)-1 || followsColon(words, i)
+		parts := strings.Split(w.core, "-")
+		for j, part := range parts {
+			lower := strings.ToLower(part)
+			if titleSmallWords[lower] && !(edge && (j == 0 || j == len(parts)-1)) {
+				parts[j] = lower
+				continue
+			}
+			parts[j] = capitalizeFirst(lower)
+		}
+		w.core = strings.Join(parts, "-")
+	}
+	return joinHeading(words)
+}
+
+// splitHeading splits heading text into words and marks those whose case
+// must not change. Preserved terms may span several words and replace the
+// words they match with the configured spelling.
+func splitHeading(text string, preserve []string) []headingWord {
+	var words []headingWord
+	inCode := false
+	for _, token := range strings.Split(text, " ") {
+		start := strings.IndexFunc(token, isWordRune)
+		wasCode := inCode
+		if strings.Count(token, "`")%2 == 1 {
+			inCode = !inCode
+		}
+		if start < 0 || wasCode || strings.Contains(token, "`") {
+			words = append(words, headingWord{core: token, preserved: true})
+			continue
+		}
+		end := strings.LastIndexFunc(token, isWordRune)
+		_, size := utf8.DecodeRuneInString(token[end:])
+		end += size
+		w := headingWord{prefix: token[:start], core: token[start:end], suffix: token[end:]}
+		w.preserved = hasFixedCase(w.core) || isIdentifier(w)
+		words = append(words, w)
+	}
+
+	for _, term := range preserve {
+		termWords := strings.Fields(term)
+		if len(termWords) == 0 {
+			continue
+		}
+		for i := 0; i+len(termWords) <= len(words); i++ {
+			if matchesTerm(words[i:i+len(termWords)], termWords) {
+				for j, tw := range termWords {
+					words[i+j].core = tw
+					words[i+j].preserved = true
+				}
+			}
+		}
+	}
+	return words
+}
+
+// matchesTerm reports whether the words spell the term, ignoring case.
+// Only the last word may carry trailing punctuation.
+func matchesTerm(words []headingWord, term []string) bool {
+	for i, w := range words {
+		if !strings.EqualFold(w.core, term[i]) {
+			return false
+		}
+		if i < len(words)-1 && w.suffix != "" || i > 0 && w.prefix != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// hasFixedCase reports whether a word's capitalization carries meaning:
+// acronyms (API), inner capitals (GitHub, macOS), digits (EC2), or single
+// capital letters (the pronoun "I", "Plan B").
+func hasFixedCase(word string) bool {
+	if r, size := utf8.DecodeRuneInString(word); size == len(word) && unicode.IsUpper(r) {
+		return true
+	}
+	for _, part := range strings.Split(word, "-") {
+		for i, r := range part {
+			if unicode.IsDigit(r) || (i > 0 && unicode.IsUpper(r)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isIdentifier reports whether a word looks like a command-line flag, file
+// name, or setting (--check, max_lines, coc.nvim), which keep their case.
+func isIdentifier(w headingWord) bool {
+	return strings.HasSuffix(w.prefix, "-") || strings.ContainsAny(w.core, "_./\\")
+}
+
+// followsColon reports whether the previous word ends a clause with a colon.
+func followsColon(words []headingWord, i int) bool {
+	return i > 0 && strings.HasSuffix(words[i-1].suffix, ":")
+}
+
+// isWordRune reports whether r can be part of a heading word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// capitalizeFirst uppercases the first letter of s.
+func capitalizeFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// joinHeading reassembles heading words separated by single spaces.
+func joinHeading(words []headingWord) string {
+	tokens := make([]string, len(words))
+	for i, w := range words {
+		tokens[i] = w.prefix + w.core + w.suffix
+	}
+	return strings.Join(tokens, " ")
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestSentenceCase(t *testing.T) {
+	preserve := []string{"Kubernetes", "Visual Studio Code"}
+
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"Getting Started", "Getting started"},
+		{"getting started", "Getting started"},
+		{"Deploy To Kubernetes", "Deploy to Kubernetes"},
+		{"Set Up visual studio code", "Set up Visual Studio Code"},
+		{"Using The GitHub API", "Using the GitHub API"},
+		{"Step 2: Run The Tests", "Step 2: Run the tests"},
+		{"The --Check Flag", "The --Check flag"},
+		{"Configure `Max_Lines` Option", "Configure `Max_Lines` option"},
+		{"Using `go Test` Locally", "Using `go Test` locally"},
+		{"What Plan B Covers", "What plan B covers"},
+		{"Top-Level Settings", "Top-level settings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			if got := sentenceCase(tt.heading, preserve); got != tt.want {
+				t.Errorf("sentenceCase(%q) = %q, want %q", tt.heading, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	preserve := []string{"macOS", "npm"}
+
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"getting started with the cli", "Getting Started with the Cli"},
+		{"a guide to writing", "A Guide to Writing"},
+		{"what to look for", "What to Look For"},
+		{"installing on macos", "Installing on macOS"},
+		{"publish with npm", "Publish with npm"},
+		{"step-by-step setup", "Step-by-Step Setup"},
+		{"part 1: the basics", "Part 1: The Basics"},
+		{"Why (and when) to split", "Why (and When) to Split"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			if got := titleCase(tt.heading, preserve); got != tt.want {
+				t.Errorf("titleCase(%q) = %q, want %q", tt.heading, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeadingCaseDiagnostics(t *testing.T) {
+	headings := []markdown.Heading{
+		{Line: 1, Level: 1, Text: "Getting Started"},
+		{Line: 5, Level: 2, Text: "Install the tool"},
+	}
+
+	got := headingCaseDiagnostics(headings, config.HeadingCase{Style: config.HeadingCaseSentence})
+	if len(got) != 1 || got[0].Line != 1 || got[0].Suggestions[0] != "Getting started" {
+		t.Errorf("sentence case diagnostics = %+v, want one at line 1", got)
+	}
+
+	got = headingCaseDiagnostics(headings, config.HeadingCase{Style: config.HeadingCaseTitle})
+	if len(got) != 1 || got[0].Line != 5 || got[0].Suggestions[0] != "Install the Tool" {
+		t.Errorf("title case diagnostics = %+v, want one at line 5", got)
+	}
+}
+
+func TestAnalyze_HeadingCaseConfig(t *testing.T) {
+	content := []byte("# Getting Started\n\nSome text.")
+
+	result, err := NewWithConfig(config.DefaultConfig()).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "style/heading-case" {
+			t.Errorf("reported without a style: %+v", d)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.HeadingCase.Style = config.HeadingCaseSentence
+	result, err = NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	found := false
+	for _, d := range result.Diagnostics {
+		found = found || d.Rule == "style/heading-case"
+	}
+	if !found {
+		t.Errorf("Diagnostics = %+v, want style/heading-case", result.Diagnostics)
+	}
+}
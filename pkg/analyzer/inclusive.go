@@ -0,0 +1,159 @@
+package analyzer
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/inclusive-language.yml
+var inclusivePackData []byte
+
+// inclusivePack is the built-in inclusive language rule pack.
+type inclusivePack struct {
+	Version int                    `yaml:"version"`
+	Entries []config.InclusiveTerm `yaml:"entries"`
+}
+
+var (
+	builtinInclusivePack inclusivePack
+	inclusivePackOnce    sync.Once
+)
+
+// loadInclusivePack parses the embedded rule pack on first use.
+func loadInclusivePack() inclusivePack {
+	inclusivePackOnce.Do(func() {
+		if err := yaml.Unmarshal(inclusivePackData, &builtinInclusivePack); err != nil {
+			panic(fmt.Sprintf("embedded inclusive language pack is invalid: %v", err))
+		}
+	})
+	return builtinInclusivePack
+}
+
+// inclusiveMatcher is a compiled rule pack entry.
+type inclusiveMatcher struct {
+	id string // Entry ID, with the pack version for built-in entries
+
+	pattern     *regexp.Regexp
+	suggestions []string
+}
+
+// inclusiveMatchers combines the built-in pack with configured entries.
+// Configured entries replace built-in entries with the same ID, and
+// excluded IDs are dropped.
+func (a *Analyzer) inclusiveMatchers() []inclusiveMatcher {
+	pack := loadInclusivePack()
+	entries := pack.Entries
+	builtin := make(map[string]bool)
+	for _, entry := range pack.Entries {
+		builtin[entry.ID] = true
+	}
+
+	exclude := make(map[string]bool)
+	if a.Config != nil {
+		for _, id := range a.Config.InclusiveLanguage.Exclude {
+			exclude[id] = true
+		}
+		for _, entry := range a.Config.InclusiveLanguage.Terms {
+			builtin[entry.ID] = false
+		}
+		entries = mergeInclusiveTerms(entries, a.Config.InclusiveLanguage.Terms)
+	}
+
+	var matchers []inclusiveMatcher
+	for _, entry := range entries {
+		if exclude[entry.ID] || len(entry.Terms) == 0 {
+			continue
+		}
+		id := entry.ID
+		if builtin[id] {
+			id = fmt.Sprintf("%s, pack v%d", id, pack.Version)
+		}
+		matchers = append(matchers, inclusiveMatcher{
+			id:          id,
+			pattern:     inclusivePattern(entry.Terms),
+			suggestions: entry.Suggestions,
+		})
+	}
+	return matchers
+}
+
+// mergeInclusiveTerms replaces built-in entries with configured entries of
+// the same ID and appends the rest.
+func mergeInclusiveTerms(builtin, configured []config.InclusiveTerm) []config.InclusiveTerm {
+	byID := make(map[string]int, len(builtin))
+	merged := append([]config.InclusiveTerm{}, builtin...)
+	for i, entry := range merged {
+		byID[entry.ID] = i
+	}
+	for _, entry := range configured {
+		if i, ok := byID[entry.ID]; ok {
+			merged[i] = entry
+			continue
+		}
+		byID[entry.ID] = len(merged)
+		merged = append(merged, entry)
+	}
+	return merged
+}
+
+// inclusivePattern matches any of the terms as whole words, case-insensitively,
+// with an optional plural or -ed/-ing ending. Spaces and hyphens in a term
+// match either separator ("man hours" also matches "man-hours").
+func inclusivePattern(terms []string) *regexp.Regexp {
+	alternatives := make([]string, 0, len(terms))
+	for _, term := range terms {
+		words := strings.FieldsFunc(term, func(r rune) bool { return r == ' ' || r == '-' })
+		for i, w := range words {
+			words[i] = regexp.QuoteMeta(w)
+		}
+		alternatives = append(alternatives, strings.Join(words, `[\s-]+`))
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(alternatives, "|") + `)(?:s|es|ed|ing)?\b`)
+}
+
+// nonInclusiveTerms reports each use of a rule pack term in prose, lists,
+// tables, and headings. Code is never checked since segments exclude it.
+func nonInclusiveTerms(segments []markdown.Segment, matchers []inclusiveMatcher) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, seg := range segments {
+		for _, m := range matchers {
+			for _, loc := range m.pattern.FindAllStringIndex(seg.Text, -1) {
+				found := seg.Text[loc[0]:loc[1]]
+				line, column := seg.Position(loc[0])
+				suggestions := make([]string, len(m.suggestions))
+				for i, s := range m.suggestions {
+					suggestions[i] = capitalizeLike(s, found)
+				}
+				msg := fmt.Sprintf("Avoid %q (%s)", found, m.id)
+				if len(suggestions) > 0 {
+					msg = fmt.Sprintf("Consider %s instead of %q (%s)", quoteList(suggestions), found, m.id)
+				}
+				diagnostics = append(diagnostics, Diagnostic{
+					Line:        line,
+					Column:      column,
+					Severity:    SeverityWarning,
+					Rule:        "content/inclusive-language",
+					Message:     msg,
+					Suggestions: suggestions,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// quoteList formats words as a quoted list joined with "or".
+func quoteList(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = fmt.Sprintf("%q", w)
+	}
+	return strings.Join(quoted, " or ")
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+)
+
+func TestLoadInclusivePack(t *testing.T) {
+	pack := loadInclusivePack()
+	if pack.Version < 1 {
+		t.Errorf("Version = %d, want at least 1", pack.Version)
+	}
+	seen := make(map[string]bool)
+	for _, entry := range pack.Entries {
+		if entry.ID == "" || len(entry.Terms) == 0 {
+			t.Errorf("entry %+v needs an id and terms", entry)
+		}
+		if seen[entry.ID] {
+			t.Errorf("duplicate entry id %q", entry.ID)
+		}
+		seen[entry.ID] = true
+	}
+}
+
+func TestInclusivePattern(t *testing.T) {
+	pattern := inclusivePattern([]string{"blacklist", "man hours"})
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Add it to the blacklist.", true},
+		{"The host was Blacklisted.", true},
+		{"Track man-hours per sprint.", true},
+		{"Track man  hours per sprint.", true},
+		{"Blacklisting hosts", true},
+		{"The blacklists", true},
+		{"A blacklistener", false},
+		{"Human hours", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := pattern.MatchString(tt.text); got != tt.want {
+				t.Errorf("MatchString(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze_InclusiveLanguage(t *testing.T) {
+	content := []byte("# Whitelist Setup\n\nRun a sanity check on the master branch.\n\nSet `whitelist: true` in code.\n\n```\nblacklist = []\n```\n\nAsk the on-call rotation.")
+
+	inclusive := func(cfg *config.Config) []Diagnostic {
+		t.Helper()
+		result, err := NewWithConfig(cfg).Analyze("test.md", content)
+		if err != nil {
+			t.Fatalf("Analyze() error = %v", err)
+		}
+		var found []Diagnostic
+		for _, d := range result.Diagnostics {
+			if d.Rule == "content/inclusive-language" {
+				found = append(found, d)
+			}
+		}
+		return found
+	}
+
+	cfg := config.DefaultConfig()
+	if got := inclusive(cfg); len(got) != 0 {
+		t.Errorf("reported without opting in: %+v", got)
+	}
+
+	cfg.Rules = config.Rules{"content/inclusive-language": config.SeverityWarning}
+	got := inclusive(cfg)
+	if len(got) != 3 {
+		t.Fatalf("got %d diagnostics, want 3: %+v", len(got), got)
+	}
+	if got[0].Line != 1 || got[0].Suggestions[0] != "Allowlist" {
+		t.Errorf("heading diagnostic = %+v, want line 1 suggesting Allowlist", got[0])
+	}
+	if !strings.Contains(got[1].Message, "pack v") {
+		t.Errorf("Message = %q, want the pack version", got[1].Message)
+	}
+
+	cfg.InclusiveLanguage = config.InclusiveLanguage{
+		Exclude: []string{"sanity-check", "whitelist"},
+		Terms: []config.InclusiveTerm{
+			{ID: "master-branch", Terms: []string{"master branch"}, Suggestions: []string{"trunk"}},
+			{ID: "on-call", Terms: []string{"on-call"}},
+		},
+	}
+	got = inclusive(cfg)
+	if len(got) != 2 {
+		t.Fatalf("got %d diagnostics, want 2: %+v", len(got), got)
+	}
+	if got[0].Suggestions[0] != "trunk" || strings.Contains(got[0].Message, "pack v") {
+		t.Errorf("replaced entry diagnostic = %+v, want configured suggestion", got[0])
+	}
+	if got[1].Line != 11 || len(got[1].Suggestions) != 0 {
+		t.Errorf("added entry diagnostic = %+v, want line 11 without suggestions", got[1])
+	}
+}
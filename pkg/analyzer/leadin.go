@@ -0,0 +1,36 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// leadInBlocks are blocks that need an introductory sentence between them
+// and the heading above, and how each is named in diagnostics.
+var leadInBlocks = map[markdown.BlockKind]string{
+	markdown.BlockList:       "a list",
+	markdown.BlockCode:       "a code block",
+	markdown.BlockTable:      "a table",
+	markdown.BlockAdmonition: "an admonition",
+}
+
+// missingLeadIns reports headings followed directly by a list, code block,
+// table, or admonition. A sentence of introduction tells readers what the
+// block shows before they read it.
+func missingLeadIns(headings []markdown.Heading) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, h := range headings {
+		block, ok := leadInBlocks[h.Next]
+		if !ok {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     h.Line,
+			Severity: SeverityInfo,
+			Rule:     "structure/lead-in",
+			Message:  fmt.Sprintf("Heading %q is followed by %s with no introduction. Add a sentence that says what it shows", h.Text, block),
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,73 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestMissingLeadIns(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine []int
+	}{
+		{"introduced list", "## Steps\n\nFollow these steps:\n\n- one\n- two", nil},
+		{"bare list", "## Steps\n\n- one\n- two", []int{1}},
+		{"bare code", "# Title\n\n## Usage\n```bash\nrun\n```", []int{3}},
+		{"bare table", "## Options\n\n| A |\n|---|\n| b |", []int{1}},
+		{"bare admonition", "## Notes\n\n!!! note\n    Body.\n\nMore text.", []int{1}},
+		{"heading then heading", "## Parent\n\n### Child\n\nText.", nil},
+		{"empty section at end", "## Last", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := markdown.Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got := missingLeadIns(parsed.Headings)
+			if len(got) != len(tt.wantLine) {
+				t.Fatalf("got %d diagnostics, want %d: %+v", len(got), len(tt.wantLine), got)
+			}
+			for i, d := range got {
+				if d.Line != tt.wantLine[i] || d.Rule != "structure/lead-in" {
+					t.Errorf("diagnostic %d = %+v, want line %d", i, d, tt.wantLine[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyze_LeadInSeverity(t *testing.T) {
+	content := []byte("# Guide\n\n- one\n- two\n")
+
+	result, err := NewWithConfig(config.DefaultConfig()).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	found := false
+	for _, d := range result.Diagnostics {
+		if d.Rule == "structure/lead-in" {
+			found = true
+			if d.Severity != SeverityInfo {
+				t.Errorf("Severity = %q, want info by default", d.Severity)
+			}
+		}
+	}
+	if !found {
+		t.Error("missing structure/lead-in diagnostic")
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Rules = config.Rules{"structure/lead-in": config.SeverityError}
+	result, err = NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Status != StatusFail {
+		t.Errorf("Status = %q, want fail when raised to error", result.Status)
+	}
+}
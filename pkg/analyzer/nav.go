@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/mkdocs"
+)
+
+// NotInNav is the section of pages under docs_dir that the nav leaves out.
+const NotInNav = "Not in nav"
+
+// CheckNav compares results with an MkDocs nav. Each result under docs_dir
+// gets the nav section it belongs to, and results are sorted into nav order
+// with orphan pages last. Markdown pages missing from the nav get a
+// nav/orphan warning unless not_in_nav excludes them; other files in
+// docs_dir are static files MkDocs copies without a nav entry. Nav entries whose file does not
+// exist are returned as nav/broken errors located in mkdocs.yml.
+//
+// Top-level pages form their own section, named by their nav title. A site
+// without a nav is left unchanged, since MkDocs then lists every page.
+func (a *Analyzer) CheckNav(results []*Result, site *mkdocs.Site) []Diagnostic {
+	if !site.HasNav {
+		return nil
+	}
+	docsDir, err := filepath.Abs(site.DocsDir)
+	if err != nil {
+		docsDir = site.DocsDir
+	}
+
+	var broken []Diagnostic
+	order := make(map[string]int, len(site.Pages))
+	sections := make(map[string]string, len(site.Pages))
+	for i, page := range site.Pages {
+		if _, err := os.Stat(filepath.Join(docsDir, filepath.FromSlash(page.Path))); err != nil {
+			broken = append(broken, Diagnostic{
+				Line:     page.Line,
+				Severity: SeverityError,
+				Rule:     "nav/broken",
+				Message:  fmt.Sprintf("Nav entry %q points to a file that does not exist in %s", page.Path, site.DocsDir),
+			})
+			continue
+		}
+		if _, seen := order[page.Path]; seen {
+			continue
+		}
+		order[page.Path] = i
+		sections[page.Path] = navSectionName(page)
+	}
+
+	rank := make(map[*Result]int, len(results))
+	for _, r := range results {
+		rel, ok := docsPath(docsDir, r.File)
+		if !ok {
+			rank[r] = len(site.Pages) + 1 // Outside docs_dir, after orphans
+			continue
+		}
+		if i, ok := order[rel]; ok {
+			r.Section = sections[rel]
+			rank[r] = i
+			continue
+		}
+		rank[r] = len(site.Pages)
+		if !mkdocs.IsPage(rel) || site.ExcludedFromNav(rel) {
+			continue
+		}
+		r.Section = NotInNav
+		orphan := Diagnostic{
+			Line:     1,
+			Severity: SeverityWarning,
+			Rule:     "nav/orphan",
+			Message:  fmt.Sprintf("Page is not in the nav of %s. Add it to nav or to not_in_nav", site.ConfigFile),
+		}
+		r.Diagnostics = append(r.Diagnostics, a.applyRuleSeverities(r.File, []Diagnostic{orphan})...)
+		r.Status = a.determineStatus(r.Diagnostics)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return rank[results[i]] < rank[results[j]]
+	})
+	return a.applyRuleSeverities(site.ConfigFile, broken)
+}
+
+// navSectionName returns the section a page is grouped under.
+func navSectionName(page mkdocs.NavPage) string {
+	if name := page.SectionName(); name != "" {
+		return name
+	}
+	if page.Title != "" {
+		return page.Title
+	}
+	return page.Path
+}
+
+// docsPath returns a file's path relative to docs_dir with forward slashes,
+// or false if the file is outside it.
+func docsPath(docsDir, file string) (string, bool) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(docsDir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
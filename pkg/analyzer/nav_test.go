@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/mkdocs"
+)
+
+func TestCheckNav(t *testing.T) {
+	root := t.TempDir()
+	docsDir := filepath.Join(root, "docs")
+	for _, page := range []string{"index.md", "guide/install.md", "guide/setup.md", "orphan.md", "drafts/idea.md", "extra.html", "nb.ipynb"} {
+		file := filepath.Join(docsDir, filepath.FromSlash(page))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		content := "# Page\n\nSome words here.\n"
+		if filepath.Ext(page) == ".ipynb" {
+			content = `{"cells": [{"cell_type": "markdown", "source": "Some words here."}]}`
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	site := &mkdocs.Site{
+		ConfigFile: filepath.Join(root, "mkdocs.yml"),
+		DocsDir:    docsDir,
+		HasNav:     true,
+		NotInNav:   []string{"/drafts/"},
+		Pages: []mkdocs.NavPage{
+			{Path: "index.md", Title: "Home", Line: 2},
+			{Path: "guide/setup.md", Section: []string{"Guide"}, Line: 4},
+			{Path: "guide/install.md", Title: "Install", Section: []string{"Guide"}, Line: 5},
+			{Path: "guide/missing.md", Section: []string{"Guide"}, Line: 6},
+		},
+	}
+
+	a := NewWithConfig(config.DefaultConfig())
+	results, err := a.AnalyzeDirectory(docsDir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory() error = %v", err)
+	}
+	broken := a.CheckNav(results, site)
+
+	if len(broken) != 1 || broken[0].Rule != "nav/broken" || broken[0].Line != 6 {
+		t.Errorf("broken = %+v, want one nav/broken at line 6", broken)
+	}
+
+	want := []struct {
+		page, section string
+		orphan        bool
+	}{
+		{"index.md", "Home", false},
+		{"guide/setup.md", "Guide", false},
+		{"guide/install.md", "Guide", false},
+		{"drafts/idea.md", "", false}, // Pages off the nav keep walk order
+		{"extra.html", "", false},     // Static files are not pages
+		{"nb.ipynb", "", false},
+		{"orphan.md", NotInNav, true},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, r := range results {
+		rel, _ := filepath.Rel(docsDir, r.File)
+		orphan := false
+		for _, d := range r.Diagnostics {
+			orphan = orphan || d.Rule == "nav/orphan"
+		}
+		w := want[i]
+		if filepath.ToSlash(rel) != w.page || r.Section != w.section || orphan != w.orphan {
+			t.Errorf("result %d = %s (section %q, orphan %v), want %s (section %q, orphan %v)",
+				i, rel, r.Section, orphan, w.page, w.section, w.orphan)
+		}
+	}
+}
+
+func TestCheckNav_NoNav(t *testing.T) {
+	results := []*Result{{File: "docs/a.md"}, {File: "docs/b.md"}}
+	site := &mkdocs.Site{DocsDir: "docs"}
+
+	if broken := New().CheckNav(results, site); broken != nil {
+		t.Errorf("CheckNav() = %v, want nil", broken)
+	}
+	for _, r := range results {
+		if r.Section != "" || len(r.Diagnostics) != 0 {
+			t.Errorf("%s changed without a nav: %+v", r.File, r)
+		}
+	}
+}
+
+func TestCheckNav_RuleSeverity(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "docs", "orphan.md")
+	cfg := config.DefaultConfig()
+	cfg.Rules = config.Rules{"nav/orphan": "off", "nav/broken": "warning"}
+	site := &mkdocs.Site{
+		ConfigFile: filepath.Join(root, "mkdocs.yml"),
+		DocsDir:    filepath.Join(root, "docs"),
+		HasNav:     true,
+		Pages:      []mkdocs.NavPage{{Path: "missing.md", Line: 2}},
+	}
+	results := []*Result{{File: file, Status: StatusPass}}
+
+	broken := NewWithConfig(cfg).CheckNav(results, site)
+	if len(broken) != 1 || broken[0].Severity != SeverityWarning {
+		t.Errorf("broken = %+v, want one warning", broken)
+	}
+	if len(results[0].Diagnostics) != 0 || results[0].Status != StatusPass {
+		t.Errorf("orphan diagnostics = %+v, want none when the rule is off", results[0].Diagnostics)
+	}
+}
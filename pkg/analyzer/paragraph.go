@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// paragraphSentences counts the sentences in paragraph text. Text after
+// the last sentence end counts as one more sentence.
+func paragraphSentences(text string) int {
+	count := 0
+	trailing := false
+	for _, field := range strings.Fields(text) {
+		trailing = true
+		if endsSentence(field) {
+			count++
+			trailing = false
+		}
+	}
+	if trailing {
+		count++
+	}
+	return count
+}
+
+// longParagraphs reports paragraphs with more words or sentences than
+// allowed, at the paragraph's first line. A limit of 0 or less is not checked.
+func longParagraphs(paragraphs []markdown.Paragraph, maxWords, maxSentences int) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, p := range paragraphs {
+		var problems []string
+		if words := countWords(p.Text); maxWords > 0 && words > maxWords {
+			problems = append(problems, fmt.Sprintf("%d words (maximum %d)", words, maxWords))
+		}
+		if sentences := paragraphSentences(p.Text); maxSentences > 0 && sentences > maxSentences {
+			problems = append(problems, fmt.Sprintf("%d sentences (maximum %d)", sentences, maxSentences))
+		}
+		if len(problems) == 0 {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     p.Line,
+			Severity: SeverityError,
+			Rule:     "structure/paragraph-length",
+			Message:  fmt.Sprintf("Paragraph has %s. Split it into smaller paragraphs", strings.Join(problems, " and ")),
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestParagraphSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"One sentence.", 1},
+		{"One. Two! Three?", 3},
+		{"Use a tool, e.g. this one. Then stop", 2},
+		{"Open config.yaml now.", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := paragraphSentences(tt.text); got != tt.want {
+				t.Errorf("paragraphSentences(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongParagraphs(t *testing.T) {
+	paragraphs := []markdown.Paragraph{
+		{Line: 1, Text: "Short one."},
+		{Line: 3, Text: strings.Repeat("word ", 30) + "end."},
+		{Line: 5, Text: "One. Two. Three. Four."},
+		{Line: 7, Text: strings.Repeat("Five words in a sentence. ", 5)},
+	}
+
+	tests := []struct {
+		name         string
+		maxWords     int
+		maxSentences int
+		wantLines    []int
+	}{
+		{"not checked", 0, 0, nil},
+		{"words", 20, 0, []int{3, 7}},
+		{"sentences", 0, 3, []int{5, 7}},
+		{"both", 20, 3, []int{3, 5, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := longParagraphs(paragraphs, tt.maxWords, tt.maxSentences)
+			if len(got) != len(tt.wantLines) {
+				t.Fatalf("got %d diagnostics, want %d: %+v", len(got), len(tt.wantLines), got)
+			}
+			for i, d := range got {
+				if d.Line != tt.wantLines[i] || d.Rule != "structure/paragraph-length" {
+					t.Errorf("diagnostic %d = %+v, want line %d", i, d, tt.wantLines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyze_ParagraphLimits(t *testing.T) {
+	content := []byte("# Guide\n\nA short intro.\n\n" + strings.Repeat("This sentence adds words. ", 10) + "\n")
+
+	cfg := config.DefaultConfig()
+	cfg.Thresholds.MaxParagraphWords = 25
+	result, err := NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Structural.Paragraphs != 2 {
+		t.Errorf("Paragraphs = %d, want 2", result.Structural.Paragraphs)
+	}
+
+	var found []Diagnostic
+	for _, d := range result.Diagnostics {
+		if d.Rule == "structure/paragraph-length" {
+			found = append(found, d)
+		}
+	}
+	if len(found) != 1 || found[0].Line != 5 {
+		t.Errorf("paragraph diagnostics = %+v, want one at line 5", found)
+	}
+	if result.Status != StatusFail {
+		t.Errorf("Status = %q, want fail", result.Status)
+	}
+}
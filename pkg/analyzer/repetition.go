@@ -0,0 +1,203 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// sentenceWindow is the number of consecutive sentences compared when
+// looking for passages of similar sentence lengths.
+const sentenceWindow = 5
+
+// allowedRepeats are words that are correctly doubled in English
+// ("he had had enough", "note that that step is optional").
+var allowedRepeats = map[string]bool{
+	"had": true, "that": true,
+}
+
+// sentenceAbbreviations end with a period but do not end a sentence.
+var sentenceAbbreviations = map[string]bool{
+	"e.g.": true, "i.e.": true, "etc.": true, "vs.": true, "cf.": true,
+}
+
+// sentence is one sentence of paragraph prose.
+type sentence struct {
+	line, column int // Position of the first word
+	words        []string
+}
+
+// repeatedWords reports adjacent duplicate words ("the the") at the first
+// word of the pair, suggesting the pair written once. Words are compared
+// within a segment and across a line break inside a paragraph, where
+// doubled words are easiest to miss. Table cells and list items on
+// consecutive lines are not compared. Reported as info, since doubled
+// words are sometimes intended.
+func repeatedWords(segments []markdown.Segment) []Diagnostic {
+	var diagnostics []Diagnostic
+	var prevWord string
+	var prevLine, prevColumn int
+	var prev *markdown.Segment
+	for i := range segments {
+		seg := &segments[i]
+		if prev == nil || prev.Kind != markdown.SegmentProse || seg.Kind != markdown.SegmentProse || seg.Line != prev.Line+1 {
+			prevWord = ""
+		}
+
+		locs := termWordPattern.FindAllStringIndex(seg.Text, -1)
+		for j, loc := range locs {
+			word := seg.Text[loc[0]:loc[1]]
+			// Words within a segment must be separated by whitespace only
+			if j > 0 && strings.TrimSpace(seg.Text[locs[j-1][1]:loc[0]]) != "" {
+				prevWord = ""
+			}
+			if j == 0 && strings.TrimSpace(seg.Text[:loc[0]]) != "" {
+				prevWord = ""
+			}
+			line, column := seg.Position(loc[0])
+			if prevWord != "" && strings.EqualFold(prevWord, word) && !allowedRepeats[strings.ToLower(word)] && !isNumber(word) {
+				diagnostics = append(diagnostics, Diagnostic{
+					Line:        prevLine,
+					Column:      prevColumn,
+					Severity:    SeverityInfo,
+					Rule:        "content/repeated-word",
+					Message:     fmt.Sprintf("Repeated word %q in %q", strings.ToLower(word), prevWord+" "+word),
+					Suggestions: []string{prevWord},
+				})
+			}
+			prevWord, prevLine, prevColumn = word, line, column
+		}
+		if len(locs) > 0 && strings.TrimSpace(seg.Text[locs[len(locs)-1][1]:]) != "" {
+			prevWord = ""
+		}
+		prev = seg
+	}
+	return diagnostics
+}
+
+// splitSentences splits paragraph prose into sentences. Headings, lists,
+// and tables are left out since they rarely contain full sentences.
+func splitSentences(segments []markdown.Segment) []sentence {
+	var prose []markdown.Segment
+	for _, s := range segments {
+		if s.Kind == markdown.SegmentProse {
+			prose = append(prose, s)
+		}
+	}
+	text, lines := joinSegments(prose)
+
+	var sentences []sentence
+	start := -1
+	fields := strings.Fields(text)
+	offset := 0
+	var words []string
+	for _, field := range fields {
+		offset += strings.Index(text[offset:], field)
+		if start < 0 {
+			start = offset
+		}
+		words = append(words, termWordPattern.FindAllString(field, -1)...)
+		offset += len(field)
+
+		if endsSentence(field) && len(words) > 0 {
+			line, column := lines.positionAt(start)
+			sentences = append(sentences, sentence{line: line, column: column, words: words})
+			start, words = -1, nil
+		}
+	}
+	if len(words) > 0 {
+		line, column := lines.positionAt(start)
+		sentences = append(sentences, sentence{line: line, column: column, words: words})
+	}
+	return sentences
+}
+
+// endsSentence reports whether a whitespace-separated token ends a sentence.
+func endsSentence(token string) bool {
+	if sentenceAbbreviations[strings.ToLower(token)] {
+		return false
+	}
+	token = strings.TrimRight(token, `"')]*_’”`)
+	return strings.HasSuffix(token, ".") || strings.HasSuffix(token, "!") || strings.HasSuffix(token, "?")
+}
+
+// sentenceLengthVariance returns the population variance of sentence
+// lengths in words. Low variance means every sentence has about the same
+// length, which reads as monotonous.
+func sentenceLengthVariance(sentences []sentence) float64 {
+	if len(sentences) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, s := range sentences {
+		sum += float64(len(s.words))
+	}
+	mean := sum / float64(len(sentences))
+	var squares float64
+	for _, s := range sentences {
+		d := float64(len(s.words)) - mean
+		squares += d * d
+	}
+	return squares / float64(len(sentences))
+}
+
+// sentenceStartRuns reports runs of more than maxRun consecutive sentences
+// that begin with the same word, at the line of the run's first sentence.
+func sentenceStartRuns(sentences []sentence, maxRun int) []Diagnostic {
+	var diagnostics []Diagnostic
+	for i := 0; i < len(sentences); {
+		first := strings.ToLower(sentences[i].words[0])
+		j := i + 1
+		for j < len(sentences) && strings.ToLower(sentences[j].words[0]) == first {
+			j++
+		}
+		if j-i > maxRun {
+			diagnostics = append(diagnostics, Diagnostic{
+				Line:     sentences[i].line,
+				Column:   sentences[i].column,
+				Severity: SeverityWarning,
+				Rule:     "style/sentence-start",
+				Message:  fmt.Sprintf("%d consecutive sentences start with %q (maximum %d). Vary how sentences begin", j-i, sentences[i].words[0], maxRun),
+			})
+		}
+		i = j
+	}
+	return diagnostics
+}
+
+// monotonousPassages reports passages where every window of sentenceWindow
+// consecutive sentences has a length variance below minVariance.
+// Overlapping windows are merged into one passage.
+func monotonousPassages(sentences []sentence, minVariance float64) []Diagnostic {
+	var diagnostics []Diagnostic
+	passageStart, passageEnd := -1, -1
+	flush := func() {
+		if passageStart < 0 {
+			return
+		}
+		passage := sentences[passageStart:passageEnd]
+		diagnostics = append(diagnostics, Diagnostic{
+			Line:     passage[0].line,
+			Column:   passage[0].column,
+			Severity: SeverityWarning,
+			Rule:     "style/sentence-length-variance",
+			Message: fmt.Sprintf("%d consecutive sentences have similar lengths (variance %.1f, minimum %.1f). Mix short and long sentences",
+				len(passage), sentenceLengthVariance(passage), minVariance),
+		})
+		passageStart, passageEnd = -1, -1
+	}
+
+	for i := 0; i+sentenceWindow <= len(sentences); i++ {
+		if sentenceLengthVariance(sentences[i:i+sentenceWindow]) >= minVariance {
+			continue
+		}
+		if passageStart < 0 || i > passageEnd {
+			flush()
+			passageStart = i
+		}
+		passageEnd = i + sentenceWindow
+	}
+	flush()
+	return diagnostics
+}
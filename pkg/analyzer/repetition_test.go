@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// parseSegments parses markdown content and returns its text segments.
+func parseSegments(t *testing.T, content string) []markdown.Segment {
+	t.Helper()
+	parsed, err := markdown.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return parsed.Segments
+}
+
+func TestRepeatedWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine []int
+	}{
+		{"within a line", "Open the the file.", []int{1}},
+		{"case insensitive", "The the file is open.", []int{1}},
+		{"across a line break", "Open the\nthe file.", []int{1}},
+		{"tripled", "Open the the the file.", []int{1, 1}},
+		{"separated by punctuation", "Wait, wait. Then go.", nil},
+		{"correct doubles", "He had had enough. Note that that step is optional.", nil},
+		{"numbers", "Set it to 1 1 times.", nil},
+		{"across paragraphs", "Setup\n\nSetup takes a minute.", nil},
+		{"table cells", "| a | b |\n|---|---|\n| `x` with | y |\n| `z` with | w |", nil},
+		{"code ignored", "Run `go go` now.", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := repeatedWords(parseSegments(t, tt.content))
+			if len(got) != len(tt.wantLine) {
+				t.Fatalf("got %d diagnostics, want %d: %+v", len(got), len(tt.wantLine), got)
+			}
+			for i, d := range got {
+				if d.Line != tt.wantLine[i] || d.Rule != "content/repeated-word" {
+					t.Errorf("diagnostic %d = %+v, want line %d", i, d, tt.wantLine[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRepeatedWords_Position(t *testing.T) {
+	content := "---\ntitle: Test\n---\n\nSome *text* then open the the café file.\n"
+
+	got := repeatedWords(parseSegments(t, content))
+	if len(got) != 1 {
+		t.Fatalf("got %d diagnostics, want 1: %+v", len(got), got)
+	}
+	// The pair starts at the first "the"
+	if got[0].Line != 5 || got[0].Column != 23 {
+		t.Errorf("position = %d:%d, want 5:23", got[0].Line, got[0].Column)
+	}
+}
+
+func TestRepeatedWords_Suggestion(t *testing.T) {
+	got := repeatedWords(parseSegments(t, "The the file is open."))
+	if len(got) != 1 {
+		t.Fatalf("got %d diagnostics, want 1: %+v", len(got), got)
+	}
+	d := got[0]
+	if d.Column != 1 || len(d.Suggestions) != 1 || d.Suggestions[0] != "The" {
+		t.Errorf("diagnostic = %+v, want column 1 suggesting \"The\" for the pair", d)
+	}
+	if d.Message != `Repeated word "the" in "The the"` {
+		t.Errorf("Message = %q", d.Message)
+	}
+	if d.Severity != SeverityInfo {
+		t.Errorf("Severity = %q, want info", d.Severity)
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	content := "# Title\n\nFirst one here. Second, e.g. this one!\nThird spans\ntwo lines?\n\n- A list item.\n\nLast without a period"
+	sentences := splitSentences(parseSegments(t, content))
+
+	want := []struct {
+		line  int
+		words int
+	}{
+		{3, 3},
+		{3, 5}, // e.g. counts as two words
+		{4, 4},
+		{9, 4},
+	}
+	if len(sentences) != len(want) {
+		t.Fatalf("got %d sentences, want %d: %+v", len(sentences), len(want), sentences)
+	}
+	for i, w := range want {
+		if sentences[i].line != w.line || len(sentences[i].words) != w.words {
+			t.Errorf("sentence %d = %+v, want line %d with %d words", i, sentences[i], w.line, w.words)
+		}
+	}
+}
+
+func TestSentenceLengthVariance(t *testing.T) {
+	tests := []struct {
+		name    string
+		lengths []int
+		want    float64
+	}{
+		{"empty", nil, 0},
+		{"single", []int{10}, 0},
+		{"equal", []int{8, 8, 8}, 0},
+		{"varied", []int{2, 4, 6}, 8.0 / 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sentences []sentence
+			for _, n := range tt.lengths {
+				sentences = append(sentences, sentence{words: make([]string, n)})
+			}
+			if got := sentenceLengthVariance(sentences); got != tt.want {
+				t.Errorf("sentenceLengthVariance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentenceStartRuns(t *testing.T) {
+	content := "This is one. This is two.\nThis is three. Then stop.\n\nIt runs. It ends."
+	sentences := splitSentences(parseSegments(t, content))
+
+	got := sentenceStartRuns(sentences, 2)
+	if len(got) != 1 || got[0].Line != 1 || got[0].Rule != "style/sentence-start" {
+		t.Fatalf("got %+v, want one diagnostic at line 1", got)
+	}
+	if got := sentenceStartRuns(sentences, 3); len(got) != 0 {
+		t.Errorf("run within limit reported: %+v", got)
+	}
+}
+
+func TestMonotonousPassages(t *testing.T) {
+	lengths := []int{3, 20, 30, 8, 8, 8, 8, 8, 8, 2, 15}
+	var sentences []sentence
+	for i, n := range lengths {
+		sentences = append(sentences, sentence{line: i + 1, words: make([]string, n)})
+	}
+
+	got := monotonousPassages(sentences, 4)
+	if len(got) != 1 {
+		t.Fatalf("got %d diagnostics, want 1: %+v", len(got), got)
+	}
+	if got[0].Line != 4 || got[0].Rule != "style/sentence-length-variance" {
+		t.Errorf("diagnostic = %+v, want passage starting at line 4", got[0])
+	}
+}
+
+func TestAnalyze_SentenceMonotonyThresholds(t *testing.T) {
+	content := []byte("The tool reads each file. The tool counts the words. The tool scores the text. The tool prints the result. The tool exits.")
+
+	result, err := NewWithConfig(config.DefaultConfig()).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if result.Structural.SentenceLengthVariance == 0 {
+		t.Error("SentenceLengthVariance = 0, want a value")
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "style/sentence-start" || d.Rule == "style/sentence-length-variance" {
+			t.Errorf("reported without a threshold: %+v", d)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Thresholds.MaxSentenceStartRun = 2
+	cfg.Thresholds.MinSentenceLengthVariance = 4
+	result, err = NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	rules := make(map[string]bool)
+	for _, d := range result.Diagnostics {
+		rules[d.Rule] = true
+	}
+	if !rules["style/sentence-start"] || !rules["style/sentence-length-variance"] {
+		t.Errorf("Diagnostics = %+v, want sentence start and length variance", result.Diagnostics)
+	}
+}
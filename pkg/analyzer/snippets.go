@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/snippets"
+)
+
+// snippetDiagnostics reports the snippet includes of a page that could not
+// be expanded. A cycle is reported as snippets/cycle and any other problem
+// as snippets/missing, both at the line of the directive.
+func snippetDiagnostics(path string, problems []snippets.Problem) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, p := range problems {
+		rule := "snippets/missing"
+		if errors.Is(p.Err, snippets.ErrCycle) {
+			rule = "snippets/cycle"
+		}
+		d := Diagnostic{
+			Line:     p.Line,
+			Severity: SeverityWarning,
+			Rule:     rule,
+			Message:  fmt.Sprintf("Cannot include %q: %v", p.Target, p.Err),
+		}
+		if p.File != path {
+			d.File = p.File
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// locateSnippets moves diagnostic lines of a page with snippet includes from
+// the expanded page into the file and line the text came from. Columns lose
+// the indentation the directive added. Diagnostics of pages with no
+// includes are returned unchanged.
+func locateSnippets(diagnostics []Diagnostic, path string, sources snippets.Sources) []Diagnostic {
+	for i := range diagnostics {
+		d := &diagnostics[i]
+		if d.Line <= 0 || len(sources) == 0 {
+			continue
+		}
+		s := sources.Locate(d.Line)
+		d.Line = s.Line
+		if s.File != path {
+			d.File = s.File
+		}
+		if d.Column > s.Indent {
+			d.Column -= s.Indent
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,337 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+//go:embed data/dictionary-en.txt.gz
+var dictionaryData []byte
+
+var (
+	dictionary         map[string]bool   // Words as listed, including capitalized proper nouns
+	dictionaryLower    map[string]string // Lowercase word -> listed spelling
+	dictionaryByLength map[int][]string  // Lowercase words grouped by rune count, for suggestions
+	dictionaryOnce     sync.Once
+)
+
+const (
+	maxSpellingSuggestions = 3 // Suggestions per misspelling
+	maxSuggestionDistance  = 2 // Largest edit distance suggested
+)
+
+// loadDictionary decompresses the embedded English dictionary on first use.
+func loadDictionary() {
+	dictionaryOnce.Do(func() {
+		dictionary = make(map[string]bool)
+		dictionaryLower = make(map[string]string)
+		dictionaryByLength = make(map[int][]string)
+
+		zr, err := gzip.NewReader(bytes.NewReader(dictionaryData))
+		if err != nil {
+			panic(fmt.Sprintf("embedded dictionary is corrupt: %v", err))
+		}
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			panic(fmt.Sprintf("embedded dictionary is corrupt: %v", err))
+		}
+
+		for _, word := range strings.Split(string(data), "\n") {
+			if word == "" {
+				continue
+			}
+			dictionary[word] = true
+			lower := strings.ToLower(word)
+			if _, ok := dictionaryLower[lower]; !ok {
+				dictionaryLower[lower] = word
+				n := utf8.RuneCountInString(lower)
+				dictionaryByLength[n] = append(dictionaryByLength[n], lower)
+			}
+		}
+	})
+}
+
+// loadWordList reads a project word list: one word per line, # starts a comment.
+func loadWordList(path string, words map[string]bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words[strings.ToLower(word)] = true
+	}
+	return scanner.Err()
+}
+
+// projectWords returns the words accepted in addition to the dictionary:
+// inline spelling words, word list files, and glossary terms.
+// Word lists are read once per analyzer.
+func (a *Analyzer) projectWords() (map[string]bool, error) {
+	a.projectWordsOnce.Do(func() {
+		words := make(map[string]bool)
+		if a.Config != nil {
+			for _, w := range a.Config.Spelling.Words {
+				words[strings.ToLower(w)] = true
+			}
+			for _, term := range a.Config.Glossary {
+				for _, w := range strings.Fields(term) {
+					words[strings.ToLower(w)] = true
+				}
+			}
+			for _, path := range a.Config.Spelling.WordLists {
+				if err := loadWordList(path, words); err != nil {
+					a.projectWordsErr = fmt.Errorf("cannot read word list: %w", err)
+					return
+				}
+			}
+		}
+		a.projectWordsCache = words
+	})
+	return a.projectWordsCache, a.projectWordsErr
+}
+
+// misspellings reports each word that is neither in the dictionary nor in
+// the project words. Code is never checked since segments exclude it.
+func misspellings(segments []markdown.Segment, project map[string]bool, suggestions *suggestionCache) []Diagnostic {
+	loadDictionary()
+
+	var diagnostics []Diagnostic
+	for _, seg := range segments {
+		from := 0
+		for _, word := range spellingWords(seg.Text) {
+			// Words come in order, so search forward from the last match.
+			// Curly apostrophes are normalized and may not be found.
+			at := from
+			if i := strings.Index(seg.Text[from:], word); i >= 0 {
+				at = from + i
+				from = at + len(word)
+			}
+			if isKnownSpelling(word, project) {
+				continue
+			}
+			suggested := suggestions.get(word)
+			msg := fmt.Sprintf("Unknown word %q", word)
+			if len(suggested) > 0 {
+				msg += fmt.Sprintf(" (did you mean %q?)", suggested[0])
+			}
+			line, column := seg.Position(at)
+			diagnostics = append(diagnostics, Diagnostic{
+				Line:        line,
+				Column:      column,
+				Severity:    SeverityWarning,
+				Rule:        "content/spelling",
+				Message:     msg,
+				Suggestions: suggested,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// spellingWords extracts the words to check from text. Tokens that look like
+// identifiers, paths, URLs, or versions are skipped, as are all-caps acronyms
+// and words with capitals inside them (GitHub, camelCase).
+func spellingWords(text string) []string {
+	var words []string
+	for _, field := range strings.Fields(strings.ReplaceAll(text, "’", "'")) {
+		field = strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if field == "" || strings.ContainsAny(field, "/\\_@=.:#<>{}[]|~`$%^&*+0123456789") {
+			continue
+		}
+		parts := strings.FieldsFunc(field, func(r rune) bool { return r == '-' || r == '–' || r == '—' })
+		for _, part := range parts {
+			if len(parts) > 1 && isPrefix(strings.ToLower(part)) {
+				continue // "pre" in "pre-commit"
+			}
+			part = strings.Trim(part, "'")
+			part = strings.TrimSuffix(part, "'s")
+			if utf8.RuneCountInString(part) < 2 || !isSpellable(part) {
+				continue
+			}
+			words = append(words, part)
+		}
+	}
+	return words
+}
+
+// isPrefix reports whether a hyphenated part is a word prefix.
+func isPrefix(part string) bool {
+	for _, prefix := range prefixes {
+		if part == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpellable reports whether a word is made of letters (and apostrophes)
+// with at most a leading capital, or is entirely lowercase.
+func isSpellable(word string) bool {
+	for i, r := range word {
+		switch {
+		case r == '\'':
+		case !unicode.IsLetter(r):
+			return false
+		case i > 0 && unicode.IsUpper(r):
+			return false
+		}
+	}
+	return true
+}
+
+// isKnownSpelling reports whether the word is in the dictionary or project
+// words. A capitalized word also matches its lowercase form, since it may
+// start a sentence or a title.
+func isKnownSpelling(word string, project map[string]bool) bool {
+	lower := strings.ToLower(word)
+	if project[lower] || dictionary[word] {
+		return true
+	}
+	return word != lower && dictionary[lower]
+}
+
+// suggestionCache holds the spelling suggestions found for each lowercase
+// word, so a word repeated across a run is searched for once.
+type suggestionCache struct {
+	mu    sync.Mutex
+	words map[string][]string
+}
+
+// get returns the spelling suggestions for a word, capitalized like it.
+func (c *suggestionCache) get(word string) []string {
+	lower := strings.ToLower(word)
+	c.mu.Lock()
+	found, ok := c.words[lower]
+	c.mu.Unlock()
+	if !ok {
+		found = spellingSuggestions(lower)
+		c.mu.Lock()
+		if c.words == nil {
+			c.words = make(map[string][]string)
+		}
+		c.words[lower] = found
+		c.mu.Unlock()
+	}
+
+	suggestions := make([]string, len(found))
+	for i, s := range found {
+		suggestions[i] = capitalizeLike(s, word)
+	}
+	return suggestions
+}
+
+// spellingSuggestions returns dictionary words within a small edit distance
+// of a lowercase word, closest first. At the same distance, words with the
+// same letters (a transposition such as "teh") rank first, then common
+// words.
+func spellingSuggestions(lower string) []string {
+	n := utf8.RuneCountInString(lower)
+
+	type candidate struct {
+		word     string
+		distance int
+		anagram  bool
+		common   bool
+	}
+	var candidates []candidate
+	for length := n - maxSuggestionDistance; length <= n+maxSuggestionDistance; length++ {
+		for _, w := range dictionaryByLength[length] {
+			if d := editDistance(lower, w); d <= maxSuggestionDistance {
+				candidates = append(candidates, candidate{w, d, sameLetters(lower, w), isCommonWord(w)})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		if candidates[i].anagram != candidates[j].anagram {
+			return candidates[i].anagram
+		}
+		if candidates[i].common != candidates[j].common {
+			return candidates[i].common
+		}
+		return candidates[i].word < candidates[j].word
+	})
+
+	suggestions := make([]string, 0, maxSpellingSuggestions)
+	for _, c := range candidates {
+		if len(suggestions) == maxSpellingSuggestions {
+			break
+		}
+		suggestions = append(suggestions, dictionaryLower[c.word])
+	}
+	return suggestions
+}
+
+// sameLetters reports whether a and b are made of the same letters.
+func sameLetters(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) != len(rb) {
+		return false
+	}
+	sort.Slice(ra, func(i, j int) bool { return ra[i] < ra[j] })
+	sort.Slice(rb, func(i, j int) bool { return rb[i] < rb[j] })
+	return string(ra) == string(rb)
+}
+
+// capitalizeLike capitalizes the suggestion when the misspelled word starts
+// with a capital. Proper nouns keep their dictionary capitalization.
+func capitalizeLike(suggestion, word string) string {
+	if r, _ := utf8.DecodeRuneInString(word); !unicode.IsUpper(r) {
+		return suggestion
+	}
+	s, size := utf8.DecodeRuneInString(suggestion)
+	return string(unicode.ToUpper(s)) + suggestion[size:]
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions, and adjacent
+// transpositions needed to turn one into the other.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"receive", "receive", 0},
+		{"recieve", "receive", 1},
+		{"teh", "the", 1},
+		{"documnet", "document", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpellingWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Run the tool, then stop.", []string{"Run", "the", "tool", "then", "stop"}},
+		{"Open config.yaml or ./docs/index.md", []string{"Open", "or"}},
+		{"Set max_lines to 375 on GitHub and CI", []string{"Set", "to", "on", "and"}},
+		{"Use camelCase and don't re-run the user's job", []string{"Use", "and", "don't", "run", "the", "user", "job"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := spellingWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("spellingWords(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMisspellings_SuggestionsCached(t *testing.T) {
+	content := strings.Repeat("Recieve the data. We recieve it daily. ", 300)
+	parsed, err := markdown.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	cache := &suggestionCache{}
+	diagnostics := misspellings(parsed.Segments, map[string]bool{}, cache)
+	if len(diagnostics) != 600 {
+		t.Fatalf("got %d diagnostics, want 600", len(diagnostics))
+	}
+	if len(cache.words) != 1 {
+		t.Errorf("cache holds %d words, want 1: %v", len(cache.words), cache.words)
+	}
+	if got := diagnostics[0].Suggestions[0]; got != "Receive" {
+		t.Errorf("first suggestion = %q, want Receive", got)
+	}
+	if got := diagnostics[1].Suggestions[0]; got != "receive" {
+		t.Errorf("second suggestion = %q, want receive", got)
+	}
+}
+
+func TestMisspellings(t *testing.T) {
+	content := "# Recieve Data\n\nThe server will recieve teh request.\n\nRun `recieve --now` in Paris.\n\n```\nteh code\n```"
+	parsed, err := markdown.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	diagnostics := misspellings(parsed.Segments, map[string]bool{}, &suggestionCache{})
+	want := []struct {
+		line       int
+		suggestion string
+	}{
+		{1, "Receive"},
+		{3, "receive"},
+		{3, "the"},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d: %+v", len(diagnostics), len(want), diagnostics)
+	}
+	for i, w := range want {
+		d := diagnostics[i]
+		if d.Line != w.line || len(d.Suggestions) == 0 || d.Suggestions[0] != w.suggestion {
+			t.Errorf("diagnostic %d = %+v, want line %d suggesting %q", i, d, w.line, w.suggestion)
+		}
+		if d.Rule != "content/spelling" {
+			t.Errorf("Rule = %q, want content/spelling", d.Rule)
+		}
+	}
+}
+
+func TestAnalyze_SpellingOptIn(t *testing.T) {
+	dir := t.TempDir()
+	wordList := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(wordList, []byte("# project words\nkustomize\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("Apply the kustomize overlay. Then run argocd sync.")
+
+	cfg := config.DefaultConfig()
+	result, err := NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/spelling" {
+			t.Errorf("spelling reported without opting in: %+v", d)
+		}
+	}
+
+	cfg.Rules = config.Rules{"content/spelling": config.SeverityWarning}
+	cfg.Spelling.Words = []string{"ArgoCD"}
+	cfg.Spelling.WordLists = []string{wordList}
+	result, err = NewWithConfig(cfg).Analyze("test.md", content)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "content/spelling" {
+			t.Errorf("project word reported: %+v", d)
+		}
+	}
+
+	cfg.Spelling.WordLists = []string{filepath.Join(dir, "missing.txt")}
+	_, err = NewWithConfig(cfg).Analyze("test.md", content)
+	if err == nil || !strings.Contains(err.Error(), "word list") {
+		t.Errorf("Analyze() error = %v, want word list error", err)
+	}
+}
@@ -0,0 +1,347 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// termUse is one occurrence of a word or short phrase in a document.
+type termUse struct {
+	key  string // Normalized form shared by all variants (lowercase, no spaces or hyphens)
+	form string // Spelling as written
+
+	line, column int
+}
+
+// termWordPattern matches words, keeping internal hyphens and apostrophes.
+var termWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’-][\p{L}\p{N}]+)*`)
+
+// phraseStarters are words that rarely begin a compound, so two-word
+// phrases starting with them are not compared with single words
+// ("in to" and "into", "may be" and "maybe" are both correct English).
+var phraseStarters = map[string]bool{
+	"a": true, "an": true, "and": true, "any": true, "as": true, "at": true,
+	"be": true, "by": true, "can": true, "every": true, "for": true, "how": true,
+	"if": true, "in": true, "is": true, "it": true, "may": true, "no": true,
+	"of": true, "on": true, "or": true, "some": true, "the": true, "there": true,
+	"to": true, "what": true, "where": true, "who": true, "with": true,
+}
+
+// phrasalVerbs are the keys of compounds whose spaced form is a verb of
+// its own ("set up" the tool, follow the "setup"), so neither spelling is
+// a variant of the other unless the configuration says so.
+var phrasalVerbs = map[string]bool{
+	"backup": true, "breakdown": true, "buildup": true, "checkout": true,
+	"cleanup": true, "handoff": true, "layout": true, "lookup": true,
+	"pickup": true, "printout": true, "rollout": true, "setup": true,
+	"shutdown": true, "signup": true, "startup": true, "takeover": true,
+	"walkthrough": true, "workaround": true, "writeup": true,
+}
+
+// maxDeclaredTermWords is the longest configured phrase matched in text.
+const maxDeclaredTermWords = 3
+
+// minSpacingVariantUses is how often the most common form must be used
+// before a spaced phrase and a compound ("log in" and "login") are
+// reported as variants. A phrase and a compound may be different parts of
+// speech, so a few uses are not enough to call one of them a misspelling.
+const minSpacingVariantUses = 3
+
+// extractTerms records words and two-word phrases that may be spelling
+// variants of each other. Longer phrases are recorded only when they match
+// a configured terminology entry, and phrasal verbs only when declared.
+// All-caps words are skipped since "US" and "us" are different words, not
+// variants. So are command-line flags such as --fail-on, which are names,
+// not terms.
+func extractTerms(segments []markdown.Segment, declared map[string]bool) []termUse {
+	var terms []termUse
+	for _, seg := range segments {
+		locs := termWordPattern.FindAllStringIndex(seg.Text, -1)
+		for i := range locs {
+			if isFlag(seg.Text, locs[i]) {
+				continue
+			}
+			for n := 1; n <= maxDeclaredTermWords && i+n <= len(locs); n++ {
+				if n > 1 && !adjacentWords(seg.Text, locs[i+n-2], locs[i+n-1]) {
+					break
+				}
+				form := seg.Text[locs[i][0]:locs[i+n-1][1]]
+				key := termKey(form)
+				switch {
+				case declared[key]:
+				case n == 1 && utf8.RuneCountInString(form) >= 2 && !isNumber(form) && strings.ToUpper(form) != form:
+				case n == 2 && !phraseStarters[strings.ToLower(seg.Text[locs[i][0]:locs[i][1]])] && !phrasalVerbs[key]:
+				default:
+					continue
+				}
+				line, column := seg.Position(locs[i][0])
+				terms = append(terms, termUse{key: key, form: form, line: line, column: column})
+			}
+		}
+	}
+	return terms
+}
+
+// isFlag reports whether the word match starts with a dash, as in
+// --mkdocs or -v.
+func isFlag(text string, loc []int) bool {
+	return loc[0] > 0 && text[loc[0]-1] == '-'
+}
+
+// adjacentWords reports whether two word matches are separated by a single space.
+func adjacentWords(text string, prev, next []int) bool {
+	return text[prev[1]:next[0]] == " "
+}
+
+// isNumber reports whether s contains only digits.
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// termKey normalizes a term so that case, hyphenation, and spacing variants
+// share a key ("E-mail", "email", and "e mail" all become "email").
+func termKey(term string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, term)
+}
+
+// termVariant identifies a spelling while ignoring capitalization that does
+// not distinguish terms: a capitalized first letter ("Email" at the start of
+// a sentence, "Top-Level" in a title) or all caps. Mixed case inside a word
+// is kept, so "GitHub" and "Github" are different variants.
+func termVariant(term string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i <= len(term); i++ {
+		if i < len(term) && term[i] != ' ' && term[i] != '-' {
+			continue
+		}
+		b.WriteString(foldCapitalization(term[start:i]))
+		if i < len(term) {
+			b.WriteByte(term[i])
+		}
+		start = i + 1
+	}
+	return b.String()
+}
+
+// foldCapitalization lowercases a word that is all caps or capitalized only
+// on its first letter, and returns other words unchanged.
+func foldCapitalization(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	rest := word[size:]
+	if strings.ToUpper(word) == word || (unicode.IsUpper(r) && strings.ToLower(rest) == rest) {
+		return strings.ToLower(word)
+	}
+	return word
+}
+
+// matchCase capitalizes the suggestion's first letter when the replaced text
+// starts with a capital, and lowercases it when the replaced text does not
+// and the suggestion is simply capitalized.
+func matchCase(suggestion, replaced string) string {
+	s, size := utf8.DecodeRuneInString(suggestion)
+	r, _ := utf8.DecodeRuneInString(replaced)
+	switch {
+	case unicode.IsUpper(r):
+		return string(unicode.ToUpper(s)) + suggestion[size:]
+	case isCapitalized(strings.Fields(suggestion)[0]):
+		return string(unicode.ToLower(s)) + suggestion[size:]
+	}
+	return suggestion
+}
+
+// variantStats counts how often a spelling variant is used across documents.
+type variantStats struct {
+	count    int
+	spelling map[string]int // Written forms and their counts, for display
+}
+
+// display returns the most common written form of the variant. Forms that are
+// capitalized only because they appear in a title or start a sentence are
+// used when no other form exists; for phrases the variant itself is used then.
+func (v *variantStats) display(variant string) string {
+	best, bestCount := "", 0
+	fallback, fallbackCount := "", 0
+	for form, count := range v.spelling {
+		if isCapitalized(form) {
+			if count > fallbackCount || (count == fallbackCount && form < fallback) {
+				fallback, fallbackCount = form, count
+			}
+			continue
+		}
+		if count > bestCount || (count == bestCount && form < best) {
+			best, bestCount = form, count
+		}
+	}
+	switch {
+	case best != "":
+		return best
+	case strings.ContainsAny(variant, " -"):
+		return variant
+	}
+	return fallback
+}
+
+// isCapitalized reports whether every word of the term starts with a
+// capital followed only by lowercase letters ("Set Up", "Top-Level").
+func isCapitalized(term string) bool {
+	for _, word := range strings.FieldsFunc(term, func(r rune) bool { return r == ' ' || r == '-' }) {
+		r, size := utf8.DecodeRuneInString(word)
+		if !unicode.IsUpper(r) || strings.ToLower(word[size:]) != word[size:] {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckTerminology compares spelling variants across all results and adds a
+// terminology/consistency diagnostic wherever a minority variant is used.
+// Variants differ only in case, hyphenation, or spacing ("e-mail" and
+// "email", "log in" and "login"), or belong to a group declared in the
+// configuration. Spacing variants need a compound written as one word,
+// and are reported only when the winning form is used at least
+// minSpacingVariantUses times.
+// AnalyzeDirectory calls this after analyzing every file.
+func (a *Analyzer) CheckTerminology(results []*Result) {
+	var groups []config.TermGroup
+	if a.Config != nil {
+		groups = a.Config.Terminology
+	}
+
+	// Keys of declared groups map to the group's preferred term.
+	preferred := make(map[string]string)
+	for _, g := range groups {
+		preferred[termKey(g.Preferred)] = g.Preferred
+		for _, v := range g.Variants {
+			preferred[termKey(v)] = g.Preferred
+		}
+	}
+
+	// Count variants by key across the corpus
+	variants := make(map[string]map[string]*variantStats)
+	for _, r := range results {
+		for _, t := range r.terms {
+			if _, ok := variants[t.key]; !ok {
+				variants[t.key] = make(map[string]*variantStats)
+			}
+			v := termVariant(t.form)
+			stats, ok := variants[t.key][v]
+			if !ok {
+				stats = &variantStats{spelling: make(map[string]int)}
+				variants[t.key][v] = stats
+			}
+			stats.count++
+			stats.spelling[t.form]++
+		}
+	}
+
+	for _, r := range results {
+		var diagnostics []Diagnostic
+		for _, t := range r.terms {
+			v := termVariant(t.form)
+			if want, ok := preferred[t.key]; ok {
+				if v != termVariant(want) {
+					diagnostics = append(diagnostics, terminologyDiagnostic(t, want, "the preferred term"))
+				}
+				continue
+			}
+
+			group := variants[t.key]
+			if len(group) < 2 || !hasSingleWordVariant(group) {
+				continue
+			}
+			dominant := dominantVariant(group)
+			if dominant == "" || dominant == v {
+				continue
+			}
+			if (strings.Contains(dominant, " ") || strings.Contains(v, " ")) && group[dominant].count < minSpacingVariantUses {
+				continue
+			}
+			reason := fmt.Sprintf("used %d times across the docs", group[dominant].count)
+			diagnostics = append(diagnostics, terminologyDiagnostic(t, group[dominant].display(dominant), reason))
+		}
+
+		if len(diagnostics) == 0 {
+			continue
+		}
+		r.Diagnostics = append(r.Diagnostics, locateCells(a.applyRuleSeverities(r.File, locateSnippets(diagnostics, r.File, r.sources)), r.cells)...)
+		r.Status = a.determineStatus(r.Diagnostics)
+	}
+}
+
+// hasSingleWordVariant reports whether a group with a spaced variant has
+// one written as a single word. Phrases that only differ inside their
+// words ("meta-schema validation" and "metaschema validation") are already
+// reported through those words, and a hyphenated adjective is not a
+// spelling of a spaced phrase ("a one-line summary", "on one line").
+func hasSingleWordVariant(group map[string]*variantStats) bool {
+	spaced := false
+	for v := range group {
+		if !strings.ContainsAny(v, " -") {
+			return true
+		}
+		spaced = spaced || strings.Contains(v, " ")
+	}
+	return !spaced
+}
+
+// dominantVariant returns the variant used most often, or "" on a tie.
+func dominantVariant(group map[string]*variantStats) string {
+	best, bestCount, tie := "", 0, false
+	for v, stats := range group {
+		switch {
+		case stats.count > bestCount:
+			best, bestCount, tie = v, stats.count, false
+		case stats.count == bestCount:
+			tie = true
+		}
+	}
+	if tie {
+		return ""
+	}
+	return best
+}
+
+// terminologyDiagnostic reports a minority variant and suggests a replacement.
+func terminologyDiagnostic(t termUse, suggestion, reason string) Diagnostic {
+	suggestion = matchCase(suggestion, t.form)
+	return Diagnostic{
+		Line:        t.line,
+		Column:      t.column,
+		Severity:    SeverityInfo,
+		Rule:        "terminology/consistency",
+		Message:     fmt.Sprintf("Use %q instead of %q (%s)", suggestion, t.form, reason),
+		Suggestions: []string{suggestion},
+	}
+}
+
+// declaredTermKeys returns the keys of all configured terminology entries.
+func (a *Analyzer) declaredTermKeys() map[string]bool {
+	keys := make(map[string]bool)
+	if a.Config == nil {
+		return keys
+	}
+	for _, g := range a.Config.Terminology {
+		keys[termKey(g.Preferred)] = true
+		for _, v := range g.Variants {
+			keys[termKey(v)] = true
+		}
+	}
+	return keys
+}
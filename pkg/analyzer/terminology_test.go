@@ -0,0 +1,256 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+)
+
+func TestTermKey(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"email", "email"},
+		{"E-mail", "email"},
+		{"e mail", "email"},
+		{"GitHub", "github"},
+		{"log in", "login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			if got := termKey(tt.term); got != tt.want {
+				t.Errorf("termKey(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTermVariant(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"Email", "email"},
+		{"EMAIL", "email"},
+		{"Top-Level", "top-level"},
+		{"GitHub", "GitHub"},
+		{"Github", "github"},
+		{"Log In", "log in"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			if got := termVariant(tt.term); got != tt.want {
+				t.Errorf("termVariant(%q) = %q, want %q", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchCase(t *testing.T) {
+	tests := []struct {
+		suggestion string
+		replaced   string
+		want       string
+	}{
+		{"setup", "Set up", "Setup"},
+		{"Setup", "set up", "setup"},
+		{"GitHub", "github", "GitHub"},
+		{"JSON Schema", "jsonschema", "JSON Schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.replaced, func(t *testing.T) {
+			if got := matchCase(tt.suggestion, tt.replaced); got != tt.want {
+				t.Errorf("matchCase(%q, %q) = %q, want %q", tt.suggestion, tt.replaced, got, tt.want)
+			}
+		})
+	}
+}
+
+// analyzeCorpus analyzes each document as a separate file and runs the
+// cross-document terminology check.
+func analyzeCorpus(t *testing.T, cfg *config.Config, docs map[string]string) map[string]*Result {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range docs {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := NewWithConfig(cfg).AnalyzeDirectory(dir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory() error = %v", err)
+	}
+	byName := make(map[string]*Result)
+	for _, r := range results {
+		byName[filepath.Base(r.File)] = r
+	}
+	return byName
+}
+
+// terminologyDiagnostics returns the terminology diagnostics of a result.
+func terminologyDiagnostics(r *Result) []Diagnostic {
+	var found []Diagnostic
+	for _, d := range r.Diagnostics {
+		if d.Rule == "terminology/consistency" {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+func TestCheckTerminology_Variants(t *testing.T) {
+	results := analyzeCorpus(t, config.DefaultConfig(), map[string]string{
+		"a.md": "Send an email to the team. Email is fastest.\n\nHost the code on GitHub.",
+		"b.md": "Check your email first.\n\nOpen GitHub in a browser.",
+		"c.md": "Intro.\n\nReply by e-mail.\n\nThe Github page lists releases.",
+	})
+
+	if got := terminologyDiagnostics(results["a.md"]); len(got) != 0 {
+		t.Errorf("majority spelling reported: %+v", got)
+	}
+
+	got := terminologyDiagnostics(results["c.md"])
+	if len(got) != 2 {
+		t.Fatalf("got %d diagnostics for c.md, want 2: %+v", len(got), got)
+	}
+	if got[0].Line != 3 || got[0].Suggestions[0] != "email" {
+		t.Errorf("e-mail diagnostic = %+v, want line 3 suggesting email", got[0])
+	}
+	if got[1].Line != 5 || got[1].Suggestions[0] != "GitHub" {
+		t.Errorf("Github diagnostic = %+v, want line 5 suggesting GitHub", got[1])
+	}
+}
+
+func TestCheckTerminology_NotVariants(t *testing.T) {
+	tests := []struct {
+		name string
+		docs map[string]string
+	}{
+		{
+			// A verb phrase and a noun are not spellings of one term
+			name: "phrasal verb",
+			docs: map[string]string{
+				"a.md": "Follow the setup guide. The setup takes a minute. Check the setup twice.",
+				"b.md": "Set up the tool before you start.",
+			},
+		},
+		{
+			name: "spacing variant used too little",
+			docs: map[string]string{
+				"a.md": "Open the login page. The login form asks for a token.",
+				"b.md": "Log in with your token.",
+			},
+		},
+		{
+			name: "hyphenated adjective",
+			docs: map[string]string{
+				"a.md": "Write a one-line summary. Keep one-line comments short.",
+				"b.md": "Put each sentence on one line.",
+			},
+		},
+		{
+			name: "flag headings",
+			docs: map[string]string{
+				"a.md": "# Flags\n\n## --fail-on\n\n## --go-doc\n\nSet the level to fail on.",
+				"b.md": "Tests fail on errors. Builds fail on warnings.",
+			},
+		},
+		{
+			name: "code in headings",
+			docs: map[string]string{
+				"a.md": "# The `mkdocs` Option\n\nSet `mkdocs` to the config path.",
+				"b.md": "Build the site with MkDocs. MkDocs reads the nav.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, r := range analyzeCorpus(t, config.DefaultConfig(), tt.docs) {
+				if got := terminologyDiagnostics(r); len(got) != 0 {
+					t.Errorf("%s: got %+v, want no diagnostics", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTerminology_Spacing(t *testing.T) {
+	results := analyzeCorpus(t, config.DefaultConfig(), map[string]string{
+		"a.md": "Open the login page. The login form asks for a token.",
+		"b.md": "Each login is logged.",
+		"c.md": "Log in with your token.",
+	})
+
+	for _, name := range []string{"a.md", "b.md"} {
+		if got := terminologyDiagnostics(results[name]); len(got) != 0 {
+			t.Errorf("%s: majority spelling reported: %+v", name, got)
+		}
+	}
+	got := terminologyDiagnostics(results["c.md"])
+	if len(got) != 1 || got[0].Column != 1 || got[0].Suggestions[0] != "Login" {
+		t.Errorf("got %+v, want one diagnostic at column 1 suggesting Login", got)
+	}
+}
+
+func TestCheckTerminology_DeclaredSpacing(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Terminology = []config.TermGroup{{Preferred: "setup", Variants: []string{"set up"}}}
+
+	results := analyzeCorpus(t, cfg, map[string]string{
+		"a.md": "Follow the setup guide.",
+		"b.md": "Set up the tool before you start.",
+	})
+
+	got := terminologyDiagnostics(results["b.md"])
+	if len(got) != 1 || got[0].Suggestions[0] != "Setup" {
+		t.Errorf("got %+v, want one diagnostic suggesting Setup", got)
+	}
+}
+
+func TestCheckTerminology_TieNotReported(t *testing.T) {
+	results := analyzeCorpus(t, config.DefaultConfig(), map[string]string{
+		"a.md": "Send an email.",
+		"b.md": "Send an e-mail.",
+	})
+
+	for name, r := range results {
+		if got := terminologyDiagnostics(r); len(got) != 0 {
+			t.Errorf("%s: tie should not be reported, got %+v", name, got)
+		}
+	}
+}
+
+func TestCheckTerminology_DeclaredGroups(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Terminology = []config.TermGroup{
+		{Preferred: "sign in", Variants: []string{"log in", "logon"}},
+		{Preferred: "GitHub"},
+	}
+	cfg.Rules = config.Rules{"terminology/consistency": config.SeverityWarning}
+
+	results := analyzeCorpus(t, cfg, map[string]string{
+		"a.md": "Log in to the console. Then logon again.",
+		"b.md": "Use github to sign in.",
+	})
+
+	got := terminologyDiagnostics(results["a.md"])
+	if len(got) != 2 || got[0].Suggestions[0] != "Sign in" || got[1].Suggestions[0] != "sign in" {
+		t.Errorf("a.md diagnostics = %+v, want suggestions Sign in and sign in", got)
+	}
+	if results["a.md"].Status != StatusWarn {
+		t.Errorf("Status = %q, want warn after configured severity", results["a.md"].Status)
+	}
+
+	got = terminologyDiagnostics(results["b.md"])
+	if len(got) != 1 || got[0].Suggestions[0] != "GitHub" {
+		t.Errorf("b.md diagnostics = %+v, want one suggesting GitHub", got)
+	}
+}
@@ -1,15 +1,47 @@
 package analyzer
 
+import (
+	"github.com/adaptive-enforcement-lab/readability/pkg/notebook"
+	"github.com/adaptive-enforcement-lab/readability/pkg/snippets"
+)
+
 // Result contains all analysis metrics for a single file.
 type Result struct {
 	File        string       `json:"file"`
+	Section     string       `json:"section,omitempty"` // MkDocs nav section, set by CheckNav
 	Structural  Structural   `json:"structural"`
 	Headings    Headings     `json:"headings"`
 	Readability Readability  `json:"readability"`
 	Composition Composition  `json:"composition"`
 	Admonitions Admonitions  `json:"admonitions"`
+	Vocabulary  Vocabulary   `json:"vocabulary"`
 	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
 	Status      string       `json:"status"`
+
+	terms       []termUse        // Word occurrences for cross-document terminology checks
+	cells       notebook.Cells   // Notebook cells, for moving diagnostic lines into them
+	sources     snippets.Sources // Snippet includes, for moving diagnostic lines into the included files
+	docComments bool             // Go source file, scored per doc comment instead of as a whole
+}
+
+// Result status values.
+const (
+	StatusPass = "pass" // No errors or warnings
+	StatusWarn = "warn" // Warnings but no errors
+	StatusFail = "fail" // At least one error
+)
+
+// FailsOn reports whether the result fails a check at the given severity level.
+// Checking at warning fails files with errors or warnings; info fails any diagnostic.
+func (r *Result) FailsOn(level Severity) bool {
+	switch level {
+	case SeverityError:
+		return r.Status == StatusFail
+	case SeverityInfo:
+		return r.Status != StatusPass || len(r.Diagnostics) > 0
+	default:
+		return r.Status == StatusFail || r.Status == StatusWarn
+	}
 }
 
 // Severity represents the severity level of a diagnostic.
@@ -21,13 +53,27 @@ const (
 	SeverityInfo    Severity = "info"
 )
 
+// ParseSeverity converts a severity name to a Severity.
+// Returns false for unknown names, including "off".
+func ParseSeverity(name string) (Severity, bool) {
+	switch s := Severity(name); s {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return s, true
+	}
+	return "", false
+}
+
 // Diagnostic represents a single issue found during analysis.
 type Diagnostic struct {
 	Line     int      `json:"line"`             // Line number (1-based), 0 if not applicable
 	Column   int      `json:"column,omitempty"` // Column number (1-based), 0 if not applicable
+	Cell     int      `json:"cell,omitempty"`   // Notebook cell (1-based) that Line counts from, 0 for other files
+	File     string   `json:"file,omitempty"`   // Snippet file that Line counts from, "" for the analyzed file
 	Severity Severity `json:"severity"`         // error, warning, info
 	Rule     string   `json:"rule"`             // Rule ID (e.g., "readability/grade-level")
 	Message  string   `json:"message"`          // Human-readable message
+
+	Suggestions []string `json:"suggestions,omitempty"` // Replacement text, most likely first
 }
 
 // Admonitions contains admonition counts and details.
@@ -38,12 +84,15 @@ type Admonitions struct {
 
 // Structural contains basic document metrics.
 type Structural struct {
-	Lines              int     `json:"lines"`
-	Words              int     `json:"words"`
-	Sentences          int     `json:"sentences"`
-	Characters         int     `json:"characters"`
-	ReadingTimeMinutes int     `json:"reading_time_minutes"`
-	DashDensity        float64 `json:"dash_density"` // Mid-sentence dash pairs per 100 sentences
+	Lines                  int     `json:"lines"`
+	Words                  int     `json:"words"`
+	Sentences              int     `json:"sentences"`
+	Paragraphs             int     `json:"paragraphs"`
+	Characters             int     `json:"characters"`
+	ReadingTimeMinutes     int     `json:"reading_time_minutes"`
+	ReadingTimeSeconds     int     `json:"reading_time_seconds"`     // Summed across files for total estimates
+	DashDensity            float64 `json:"dash_density"`             // Mid-sentence dash pairs per 100 sentences
+	SentenceLengthVariance float64 `json:"sentence_length_variance"` // Variance of paragraph sentence lengths in words
 }
 
 // Headings contains heading counts by level.
@@ -66,13 +115,32 @@ type Readability struct {
 	SMOG               float64 `json:"smog"`
 }
 
+// Vocabulary contains word difficulty metrics.
+type Vocabulary struct {
+	RareWordRatio float64    `json:"rare_word_ratio"`      // Share of prose words outside the common word list
+	RareWords     []RareWord `json:"rare_words,omitempty"` // Most frequent rare words
+}
+
+// RareWord is a word outside the common word list and its number of uses.
+type RareWord struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
 // Composition contains content type breakdown.
 type Composition struct {
-	TotalLines     int     `json:"total_lines"`
-	ProseLines     int     `json:"prose_lines"`
-	CodeLines      int     `json:"code_lines"`
-	EmptyLines     int     `json:"empty_lines"`
-	CodeBlockRatio float64 `json:"code_block_ratio"`
+	TotalLines       int     `json:"total_lines"`
+	ProseLines       int     `json:"prose_lines"`
+	CodeLines        int     `json:"code_lines"`
+	TableLines       int     `json:"table_lines"`
+	ListLines        int     `json:"list_lines"`
+	AdmonitionLines  int     `json:"admonition_lines"`
+	HTMLLines        int     `json:"html_lines"`
+	FrontmatterLines int     `json:"frontmatter_lines"`
+	EmptyLines       int     `json:"empty_lines"`
+	CodeBlockRatio   float64 `json:"code_block_ratio"`
+	MarkdownCells    int     `json:"markdown_cells,omitempty"` // Jupyter notebooks only
+	CodeCells        int     `json:"code_cells,omitempty"`     // Jupyter notebooks only
 }
 
 // Thresholds defines limits for pass/fail checks.
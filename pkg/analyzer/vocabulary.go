@@ -0,0 +1,246 @@
+package analyzer
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed data/common-words.txt
+var commonWordsData string
+
+var (
+	commonWords     map[string]bool
+	commonWordsOnce sync.Once
+)
+
+const (
+	maxRareWords            = 10 // Rare words reported per document
+	minVocabularyWordLength = 3  // Shorter tokens are ignored
+)
+
+// loadCommonWords parses the embedded common word list on first use.
+func loadCommonWords() map[string]bool {
+	commonWordsOnce.Do(func() {
+		commonWords = make(map[string]bool)
+		for _, line := range strings.Split(commonWordsData, "\n") {
+			word := strings.TrimSpace(line)
+			if word == "" || strings.HasPrefix(word, "#") {
+				continue
+			}
+			commonWords[word] = true
+		}
+	})
+	return commonWords
+}
+
+// analyzeVocabulary measures how much of the prose uses uncommon words.
+// Words in the glossary are treated as common.
+func analyzeVocabulary(prose string, glossary map[string]bool) Vocabulary {
+	counts := make(map[string]int)
+	total := 0
+	rare := 0
+
+	for _, word := range vocabularyWords(prose) {
+		total++
+		if glossary[word] || isCommonWord(word) {
+			continue
+		}
+		rare++
+		counts[word]++
+	}
+
+	return Vocabulary{
+		RareWordRatio: calculateRatio(rare, total),
+		RareWords:     topRareWords(counts, maxRareWords),
+	}
+}
+
+// vocabularyWords splits text into lowercase words made only of letters.
+// Possessive endings are dropped. Tokens containing digits, underscores, or
+// contractions are skipped since they are not vocabulary in the usual sense,
+// as are words shorter than three letters (option flags, initials, units).
+func vocabularyWords(text string) []string {
+	var words []string
+	for _, token := range strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || (unicode.IsPunct(r) && r != '\'' && r != '’' && r != '_') || unicode.IsSymbol(r)
+	}) {
+		token = strings.Trim(token, "'’")
+		token = strings.TrimSuffix(strings.TrimSuffix(token, "'s"), "’s")
+		if utf8.RuneCountInString(token) < minVocabularyWordLength || !isAlphabetic(token) {
+			continue
+		}
+		words = append(words, strings.ToLower(token))
+	}
+	return words
+}
+
+// isAlphabetic reports whether s contains only letters.
+func isAlphabetic(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isCommonWord reports whether a lowercase word or one of its base forms
+// appears in the common word list.
+func isCommonWord(word string) bool {
+	words := loadCommonWords()
+	for _, form := range baseForms(word) {
+		if words[form] {
+			return true
+		}
+	}
+	return false
+}
+
+// derivations lists common suffixes that build new words from a base word,
+// with the endings to try in place of the suffix (configuration -> configure).
+var derivations = []struct {
+	suffix  string
+	endings []string
+}{
+	{"ation", []string{"", "e", "ate"}},
+	{"ion", []string{"", "e"}},
+	{"ment", []string{""}},
+	{"ness", []string{""}},
+	{"ful", []string{""}},
+	{"less", []string{""}},
+	{"able", []string{"", "e"}},
+	{"ity", []string{"", "e"}},
+	{"ize", []string{"", "e"}},
+	{"al", []string{""}},
+}
+
+// prefixes lists word prefixes that rarely make a word harder to read.
+var prefixes = []string{"un", "re", "dis", "pre", "non"}
+
+// baseForms returns the word followed by candidate base forms obtained by
+// undoing regular inflections, common derivational suffixes, and prefixes.
+// Candidates may not be real words; they are only used for list lookups.
+func baseForms(word string) []string {
+	forms := inflectionBases(word)
+	for _, form := range forms {
+		for _, d := range derivations {
+			if stem, ok := strings.CutSuffix(form, d.suffix); ok && len(stem) >= 3 {
+				for _, ending := range d.endings {
+					forms = append(forms, stem+ending)
+				}
+			}
+		}
+	}
+	for _, form := range forms {
+		for _, prefix := range prefixes {
+			if rest, ok := strings.CutPrefix(form, prefix); ok && len(rest) >= 4 {
+				forms = append(forms, rest)
+			}
+		}
+	}
+	return forms
+}
+
+// inflectionBases returns the word followed by candidate base forms obtained
+// by undoing regular English inflections (plurals, past tense, -ing, -er, -est, -ly).
+func inflectionBases(word string) []string {
+	forms := []string{word}
+	add := func(stem string, suffixes ...string) {
+		if len(stem) < 2 {
+			return
+		}
+		forms = append(forms, stem)
+		for _, suffix := range suffixes {
+			forms = append(forms, stem+suffix)
+		}
+		// Undo consonant doubling: stopped -> stop, bigger -> big
+		if n := len(stem); n >= 3 && stem[n-1] == stem[n-2] && !strings.ContainsRune("aeiou", rune(stem[n-1])) {
+			forms = append(forms, stem[:n-1])
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies"), strings.HasSuffix(word, "ied"), strings.HasSuffix(word, "ier"):
+		add(word[:len(word)-3], "y")
+	case strings.HasSuffix(word, "iest"):
+		add(word[:len(word)-4], "y")
+	case strings.HasSuffix(word, "ily"):
+		add(word[:len(word)-3], "y")
+	}
+
+	switch {
+	case strings.HasSuffix(word, "es"):
+		add(word[:len(word)-2])
+		add(word[:len(word)-1])
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		add(word[:len(word)-1])
+	case strings.HasSuffix(word, "ed"):
+		add(word[:len(word)-2], "e")
+	case strings.HasSuffix(word, "ing"):
+		add(word[:len(word)-3], "e")
+	case strings.HasSuffix(word, "est"):
+		add(word[:len(word)-3], "e")
+	case strings.HasSuffix(word, "er"):
+		add(word[:len(word)-2], "e")
+	case strings.HasSuffix(word, "ly"):
+		add(word[:len(word)-2], "le")
+	}
+
+	return forms
+}
+
+// topRareWords returns up to limit rare words ordered by count, then alphabetically.
+func topRareWords(counts map[string]int, limit int) []RareWord {
+	words := make([]RareWord, 0, len(counts))
+	for word, count := range counts {
+		words = append(words, RareWord{Word: word, Count: count})
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
+	})
+	if len(words) > limit {
+		words = words[:limit]
+	}
+	return words
+}
+
+// rareWordsMessage describes a rare word ratio violation and names the
+// most frequent rare words so authors know what to replace or add to the glossary.
+func rareWordsMessage(v Vocabulary, threshold float64) string {
+	msg := fmt.Sprintf("Rare word ratio %.2f exceeds threshold %.2f", v.RareWordRatio, threshold)
+	if len(v.RareWords) == 0 {
+		return msg
+	}
+	words := make([]string, 0, len(v.RareWords))
+	for _, w := range v.RareWords {
+		words = append(words, w.Word)
+	}
+	return msg + " (most frequent: " + strings.Join(words, ", ") + ")"
+}
+
+// glossary returns the words of the configured glossary terms.
+func (a *Analyzer) glossary() map[string]bool {
+	if a.Config == nil {
+		return nil
+	}
+	return glossaryWords(a.Config.Glossary)
+}
+
+// glossaryWords returns the lowercase words that make up the glossary terms.
+func glossaryWords(terms []string) map[string]bool {
+	words := make(map[string]bool)
+	for _, term := range terms {
+		for _, word := range vocabularyWords(term) {
+			words[word] = true
+		}
+	}
+	return words
+}
@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/config"
+)
+
+func TestIsCommonWord(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"the", true},
+		{"files", true},
+		{"stories", true},
+		{"stopped", true},
+		{"running", true},
+		{"easily", true},
+		{"bigger", true},
+		{"configuration", true},
+		{"unclear", true},
+		{"idempotent", false},
+		{"sidecar", false},
+		{"kubernetes", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := isCommonWord(tt.word); got != tt.want {
+				t.Errorf("isCommonWord(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVocabularyWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"lowercases", "Deploy The Service", []string{"deploy", "the", "service"}},
+		{"drops possessives", "the cluster's nodes", []string{"the", "cluster", "nodes"}},
+		{"splits hyphens", "well-known tool", []string{"well", "known", "tool"}},
+		{"skips short words", "a to be or go", nil},
+		{"skips digits and identifiers", "use v2 and max_lines", []string{"use", "and"}},
+		{"skips contractions", "don't panic", []string{"panic"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vocabularyWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("vocabularyWords(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeVocabulary(t *testing.T) {
+	prose := "The sidecar proxy is idempotent. Each sidecar runs beside the main service."
+
+	got := analyzeVocabulary(prose, nil)
+	// 11 counted words ("is" is too short), of which sidecar (twice), proxy, and idempotent are rare
+	if got.RareWordRatio != 4.0/11.0 {
+		t.Errorf("RareWordRatio = %v, want %v", got.RareWordRatio, 4.0/11.0)
+	}
+	wantWords := []RareWord{{"sidecar", 2}, {"idempotent", 1}, {"proxy", 1}}
+	if !reflect.DeepEqual(got.RareWords, wantWords) {
+		t.Errorf("RareWords = %v, want %v", got.RareWords, wantWords)
+	}
+
+	withGlossary := analyzeVocabulary(prose, glossaryWords([]string{"Sidecar proxy"}))
+	if withGlossary.RareWordRatio != 1.0/11.0 {
+		t.Errorf("RareWordRatio with glossary = %v, want %v", withGlossary.RareWordRatio, 1.0/11.0)
+	}
+}
+
+func TestAnalyzeVocabulary_Empty(t *testing.T) {
+	got := analyzeVocabulary("", nil)
+	if got.RareWordRatio != 0 || len(got.RareWords) != 0 {
+		t.Errorf("analyzeVocabulary(\"\") = %+v, want zero value", got)
+	}
+}
+
+func TestTopRareWords_Limit(t *testing.T) {
+	counts := map[string]int{"alpha": 1, "beta": 3, "gamma": 2, "delta": 1}
+	got := topRareWords(counts, 3)
+	want := []RareWord{{"beta", 3}, {"gamma", 2}, {"alpha", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("topRareWords() = %v, want %v", got, want)
+	}
+}
+
+func TestAnalyze_RareWords(t *testing.T) {
+	content := []byte("# Mesh\n\n" + strings.Repeat("The sidecar proxy keeps the mesh idempotent. ", 20))
+
+	tests := []struct {
+		name     string
+		ratio    float64
+		glossary []string
+		wantDiag bool
+	}{
+		{"disabled by default", 0, nil, false},
+		{"above threshold", 0.2, nil, true},
+		{"glossary terms excluded", 0.2, []string{"sidecar", "proxy", "mesh", "idempotent"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Thresholds.MaxRareWordRatio = tt.ratio
+			cfg.Glossary = tt.glossary
+			a := NewWithConfig(cfg)
+
+			result, err := a.Analyze("test.md", content)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			var found *Diagnostic
+			for i := range result.Diagnostics {
+				if result.Diagnostics[i].Rule == "readability/rare-words" {
+					found = &result.Diagnostics[i]
+				}
+			}
+			if (found != nil) != tt.wantDiag {
+				t.Fatalf("rare-words diagnostic present = %v, want %v (ratio %.2f)", found != nil, tt.wantDiag, result.Vocabulary.RareWordRatio)
+			}
+			if found != nil && !strings.Contains(found.Message, "sidecar") {
+				t.Errorf("Message should name the most frequent rare words, got %q", found.Message)
+			}
+		})
+	}
+}
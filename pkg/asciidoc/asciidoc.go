@@ -0,0 +1,522 @@
+// Package asciidoc parses AsciiDoc documents into the same shape as
+// markdown.Parse, so every rule and output format works on AsciiDoc pages.
+package asciidoc
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/adaptive-enforcement-lab/readability/internal/linekind"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// FrontmatterAttributes is the Frontmatter format for the attribute entries
+// in an AsciiDoc document header.
+const FrontmatterAttributes = "attributes"
+
+var (
+	sectionTitle    = regexp.MustCompile(`^(={1,6}|#{1,6})\s+(\S.*)$`)
+	attributeEntry  = regexp.MustCompile(`^:(!?\w[\w-]*!?):(?:\s+(.*))?$`)
+	blockAttributes = regexp.MustCompile(`^\[.*\]$`)
+	blockTitle      = regexp.MustCompile(`^\.[^.\s]`)
+	blockMacro      = regexp.MustCompile(`^([\w-]+)::\S*\[.*\]$`)
+	listItem        = regexp.MustCompile(`^\s*(?:\*{1,5}|-|\.{1,5}|\d+\.|[a-zA-Z]\.|[ivxIVX]+\))\s+(?:\[[ xX*]\]\s+)?(.*)$`)
+	descriptionItem = regexp.MustCompile(`^\s*(\S.*?)(?::{2,4}|;;)(?:\s+(.*))?$`)
+	admonitionLabel = regexp.MustCompile(`^(NOTE|TIP|IMPORTANT|WARNING|CAUTION):\s+(.*)$`)
+	delimiterLine   = regexp.MustCompile("^(?:-{4,}|\\.{4,}|={4,}|\\*{4,}|_{4,}|\\+{4,}|/{4,}|--|`{3}.*|[|,:!]={3,})$")
+	breakLine       = regexp.MustCompile(`^(?:'{3,}|<{3}|-{3}|\*{3}|_{3})$`)
+	tableCellSpec   = regexp.MustCompile(`\s+(?:\d+(?:\.\d+)?[+*]|\d*[<^>.][<^>.\d]*[adehlmsv]?|[adehlmsv])$`)
+)
+
+// admonitionTypes are the AsciiDoc admonition labels and block styles.
+var admonitionTypes = map[string]bool{
+	"NOTE": true, "TIP": true, "IMPORTANT": true, "WARNING": true, "CAUTION": true,
+}
+
+// codeStyles are block styles that make a paragraph or open block verbatim.
+var codeStyles = map[string]bool{
+	"source": true, "listing": true, "literal": true,
+}
+
+// attributes are the block attribute and title lines before a block.
+type attributes struct {
+	style string // First positional attribute, such as source or NOTE
+	title string
+	lines []int // Attribute and title lines, classified with the block
+}
+
+// parser walks the lines of a document and fills a ParseResult.
+type parser struct {
+	lines   []string
+	offsets []int // Byte offset of each line
+	kinds   []linekind.Kind
+	result  *markdown.ParseResult
+	prose   strings.Builder
+	blocks  []linekind.Block // Top-level blocks in order, for Heading.Next
+	cursor  linekind.Cursor  // Last column computed, so columns on a line are counted once
+}
+
+// Parse extracts prose content, code blocks, and headings from AsciiDoc.
+func Parse(content []byte) (*markdown.ParseResult, error) {
+	lines := strings.Split(string(content), "\n")
+	p := &parser{
+		lines:   make([]string, len(lines)),
+		offsets: make([]int, len(lines)),
+		kinds:   make([]linekind.Kind, len(lines)),
+		result: &markdown.ParseResult{
+			CodeBlocks:  make([]string, 0),
+			Headings:    make([]markdown.Heading, 0),
+			Admonitions: make([]markdown.Admonition, 0),
+			Segments:    make([]markdown.Segment, 0),
+			Paragraphs:  make([]markdown.Paragraph, 0),
+		},
+	}
+	offset := 0
+	for i, line := range lines {
+		p.offsets[i] = offset
+		p.kinds[i] = linekind.Prose // Until a block says otherwise
+		offset += len(line) + 1
+		p.lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	p.parseBlocks(p.header(), len(p.lines), linekind.Prose, 0)
+	linekind.MarkFollowing(p.blocks, p.result.Headings)
+	linekind.Count(p.result, p.kinds)
+	p.result.Prose = strings.Join(strings.Fields(p.prose.String()), " ")
+	return p.result, nil
+}
+
+// header parses the document header: attribute entries and comments, the
+// document title, then author, revision, and attribute entry lines up to
+// the first blank line. The attribute entries become the Frontmatter.
+// Returns the first body line.
+func (p *parser) header() int {
+	i := 0
+	for i < len(p.lines) && (attributeEntry.MatchString(p.lines[i]) || isComment(p.lines[i])) {
+		i++
+	}
+	if i == len(p.lines) {
+		return 0
+	}
+	m := sectionTitle.FindStringSubmatch(p.lines[i])
+	if m == nil || len(m[1]) != 1 {
+		return 0 // No document title, so no header
+	}
+
+	fm := &markdown.Frontmatter{
+		Format: FrontmatterAttributes,
+		Fields: make(map[string]any),
+		Lines:  make(map[string]int),
+	}
+	headerLine := func(j int) {
+		switch {
+		case isComment(p.lines[j]):
+			p.kinds[j] = linekind.HTML
+		case attributeEntry.MatchString(p.lines[j]):
+			p.kinds[j] = linekind.Frontmatter
+			a := attributeEntry.FindStringSubmatch(p.lines[j])
+			name := strings.Trim(a[1], "!")
+			if name != a[1] {
+				delete(fm.Fields, name) // :name!: unsets the attribute
+				delete(fm.Lines, name)
+				return
+			}
+			fm.Fields[name] = a[2]
+			fm.Lines[name] = j + 1
+		default:
+			p.kinds[j] = linekind.Frontmatter // Author and revision lines
+		}
+	}
+
+	for j := 0; j < i; j++ {
+		headerLine(j)
+	}
+	p.heading(i, m)
+	p.blocks = append(p.blocks, linekind.Block{Kind: markdown.BlockHeading, Heading: 0})
+	for i++; i < len(p.lines) && strings.TrimSpace(p.lines[i]) != ""; i++ {
+		headerLine(i)
+	}
+	if len(fm.Fields) > 0 {
+		p.result.Frontmatter = fm
+	}
+	return i
+}
+
+// parseBlocks parses the blocks in lines [start, end). kind is the kind of
+// paragraphs in this context: prose, or list in a list continuation.
+// depth is the nesting level of delimited blocks.
+func (p *parser) parseBlocks(start, end int, kind linekind.Kind, depth int) {
+	var attrs attributes
+	inList := false       // A list is open; blank lines do not end it
+	continuation := false // The previous line was a + list continuation
+
+	record := func(k markdown.BlockKind) {
+		if depth == 0 {
+			p.blocks = append(p.blocks, linekind.Block{Kind: k, Heading: -1})
+		}
+	}
+
+	for i := start; i < end; {
+		line := p.lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			p.kinds[i] = linekind.Empty
+			attrs = attributes{}
+			i++
+			continue
+		case trimmed == "+" && inList:
+			p.kinds[i] = linekind.List
+			continuation = true
+			i++
+			continue
+		case isComment(line), attributeEntry.MatchString(line):
+			p.kinds[i] = linekind.HTML
+			i++
+			continue
+		case blockAttributes.MatchString(trimmed):
+			if s := blockStyle(trimmed); s != "" {
+				attrs.style = s
+			}
+			attrs.lines = append(attrs.lines, i)
+			i++
+			continue
+		case blockTitle.MatchString(trimmed):
+			attrs.title = strings.TrimSpace(trimmed[1:])
+			attrs.lines = append(attrs.lines, i)
+			i++
+			continue
+		}
+
+		item := (listItem.MatchString(line) || descriptionItem.MatchString(line)) &&
+			!admonitionTypes[attrs.style] && !codeStyles[strings.ToLower(attrs.style)]
+		paragraphKind := kind
+		if continuation {
+			paragraphKind = linekind.List
+		} else if !item {
+			inList = false
+		}
+		continuation = false
+		a := attrs
+		attrs = attributes{}
+
+		switch {
+		case delimiterLine.MatchString(trimmed):
+			i = p.delimitedBlock(i, end, a, paragraphKind, depth, record)
+
+		case sectionTitle.MatchString(line):
+			linekind.Fill(p.kinds, p.lines, a.lines, linekind.Prose)
+			p.heading(i, sectionTitle.FindStringSubmatch(line))
+			if depth == 0 {
+				p.blocks = append(p.blocks, linekind.Block{Kind: markdown.BlockHeading, Heading: len(p.result.Headings) - 1})
+			}
+			i++
+
+		case breakLine.MatchString(trimmed):
+			linekind.Fill(p.kinds, p.lines, a.lines, linekind.Prose)
+			record(markdown.BlockBreak)
+			i++
+
+		case blockMacro.MatchString(trimmed):
+			if blockMacro.FindStringSubmatch(trimmed)[1] == "image" {
+				linekind.Fill(p.kinds, p.lines, a.lines, linekind.Prose)
+				p.result.Images++
+				record(markdown.BlockParagraph)
+			} else {
+				linekind.Fill(p.kinds, p.lines, append(a.lines, i), linekind.HTML) // include::, toc::, ifdef:: and others
+			}
+			i++
+
+		case admonitionLabel.MatchString(trimmed) && a.style == "":
+			label := admonitionLabel.FindStringSubmatch(trimmed)[1]
+			p.addAdmonition(i, label, a.title)
+			next := p.paragraphEnd(i, end)
+			linekind.Fill(p.kinds, p.lines, append(a.lines, linekind.Span(i, next)...), linekind.Admonition)
+			record(markdown.BlockAdmonition)
+			i = next
+
+		case item:
+			linekind.Fill(p.kinds, p.lines, a.lines, linekind.List)
+			if !inList {
+				record(markdown.BlockList)
+			}
+			inList = true
+			i = p.listItem(i, end)
+
+		default:
+			i = p.paragraph(i, end, a, paragraphKind, record)
+		}
+	}
+}
+
+// delimitedBlock parses a block between delimiter lines starting at i and
+// returns the line after it. An unclosed block runs to end.
+func (p *parser) delimitedBlock(i, end int, a attributes, kind linekind.Kind, depth int, record func(markdown.BlockKind)) int {
+	delimiter := strings.TrimSpace(p.lines[i])
+	closing := delimiter
+	if strings.HasPrefix(delimiter, "```") {
+		closing = "```"
+	}
+	closeLine := end
+	for j := i + 1; j < end; j++ {
+		if strings.TrimSpace(p.lines[j]) == closing {
+			closeLine = j
+			break
+		}
+	}
+	next := min(closeLine+1, end)
+	whole := append(a.lines, linekind.Span(i, next)...)
+	style := strings.ToLower(a.style)
+
+	switch {
+	case delimiter[0] == '/':
+		linekind.Fill(p.kinds, p.lines, whole, linekind.HTML) // Comment block
+	case delimiter[0] == '+':
+		linekind.Fill(p.kinds, p.lines, whole, linekind.HTML)
+		record(markdown.BlockHTML)
+	case strings.ContainsAny(delimiter[:1], "|,:!"):
+		linekind.Fill(p.kinds, p.lines, whole, linekind.Table)
+		p.table(i+1, closeLine, delimiter[0])
+		record(markdown.BlockTable)
+	case admonitionTypes[a.style]:
+		p.addAdmonition(first(a.lines, i), a.style, a.title)
+		linekind.Fill(p.kinds, p.lines, whole, linekind.Admonition)
+		record(markdown.BlockAdmonition)
+	case strings.ContainsAny(delimiter[:1], "-.`") && (delimiter != "--" || codeStyles[style]):
+		linekind.Fill(p.kinds, p.lines, whole, linekind.Code)
+		p.codeBlock(i+1, closeLine)
+		record(markdown.BlockCode)
+	default:
+		// Example, sidebar, quote, and open blocks hold other blocks
+		linekind.Fill(p.kinds, p.lines, a.lines, linekind.Prose)
+		p.parseBlocks(i+1, closeLine, kind, depth+1)
+		if delimiter[0] == '_' || style == "quote" || style == "verse" {
+			record(markdown.BlockQuote)
+		} else {
+			record(markdown.BlockParagraph)
+		}
+	}
+	return next
+}
+
+// paragraph parses a paragraph starting at i and returns the line after it.
+// Indented paragraphs and those with a source style are code; those with
+// an admonition style are admonitions.
+func (p *parser) paragraph(i, end int, a attributes, kind linekind.Kind, record func(markdown.BlockKind)) int {
+	next := p.paragraphEnd(i, end)
+	lines := append(a.lines, linekind.Span(i, next)...)
+
+	switch {
+	case admonitionTypes[a.style]:
+		p.addAdmonition(first(a.lines, i), a.style, a.title)
+		linekind.Fill(p.kinds, p.lines, lines, linekind.Admonition)
+		record(markdown.BlockAdmonition)
+		return next
+	case codeStyles[strings.ToLower(a.style)] || (kind != linekind.List && startsIndented(p.lines[i])):
+		linekind.Fill(p.kinds, p.lines, lines, linekind.Code)
+		p.codeBlock(i, next)
+		record(markdown.BlockCode)
+		return next
+	}
+
+	linekind.Fill(p.kinds, p.lines, lines, kind)
+	var text []string
+	for j := i; j < next; j++ {
+		if isComment(p.lines[j]) {
+			p.kinds[j] = linekind.HTML // Comments inside a paragraph are dropped
+			continue
+		}
+		runs := p.inlineText(j, 0, linekind.SegmentKind(kind), kind == linekind.Prose)
+		text = append(text, strings.Join(runs, ""))
+	}
+	if kind != linekind.List {
+		p.result.Paragraphs = append(p.result.Paragraphs, markdown.Paragraph{
+			Line: i + 1,
+			Text: strings.Join(strings.Fields(strings.Join(text, " ")), " "),
+		})
+	}
+	if kind == linekind.List {
+		record(markdown.BlockList)
+	} else {
+		record(markdown.BlockParagraph)
+	}
+	return next
+}
+
+// paragraphEnd returns the line after the paragraph starting at i: the next
+// blank line, delimiter line, or block attribute line.
+func (p *parser) paragraphEnd(i, end int) int {
+	for i++; i < end; i++ {
+		trimmed := strings.TrimSpace(p.lines[i])
+		if trimmed == "" || delimiterLine.MatchString(trimmed) || blockAttributes.MatchString(trimmed) {
+			break
+		}
+	}
+	return i
+}
+
+// listItem parses a list item and the lines that continue its text, and
+// returns the line after it. A new item, a blank line, a + continuation,
+// or a block ends the text.
+func (p *parser) listItem(i, end int) int {
+	p.listLine(i)
+	for i++; i < end; i++ {
+		line := p.lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "+" || listItem.MatchString(line) || descriptionItem.MatchString(line) ||
+			delimiterLine.MatchString(trimmed) || blockAttributes.MatchString(trimmed) || isComment(line) {
+			break
+		}
+		p.kinds[i] = linekind.List
+		p.inlineText(i, 0, markdown.SegmentList, false)
+	}
+	return i
+}
+
+// listLine records the text of a list item line: the item text, or the
+// term and description of a description list item.
+func (p *parser) listLine(i int) {
+	p.kinds[i] = linekind.List
+	line := p.lines[i]
+	if m := listItem.FindStringSubmatchIndex(line); m != nil {
+		p.inlineText(i, m[2], markdown.SegmentList, false)
+		return
+	}
+	m := descriptionItem.FindStringSubmatchIndex(line)
+	p.inlineRange(i, m[2], m[3], markdown.SegmentList, false)
+	if m[4] >= 0 {
+		p.inlineText(i, m[4], markdown.SegmentList, false)
+	}
+}
+
+// table records the cells of a table body in lines [start, end). sep is
+// the first character of the delimiter: | for cells, , and : for CSV and
+// DSV data, and ! for nested tables.
+func (p *parser) table(start, end int, sep byte) {
+	p.result.Tables++
+	for i := start; i < end; i++ {
+		line := p.lines[i]
+		from := 0
+		for from <= len(line) {
+			to := strings.IndexByte(line[from:], sep)
+			if to < 0 {
+				to = len(line)
+			} else {
+				to += from
+			}
+			cell := line[from:to]
+			if to < len(line) {
+				// The spec of the next cell, such as 2+ or a, ends this one
+				if loc := tableCellSpec.FindStringIndex(cell); loc != nil {
+					cell = cell[:loc[0]]
+				}
+			}
+			p.inlineRange(i, from, from+len(cell), markdown.SegmentTable, false)
+			from = to + 1
+		}
+	}
+}
+
+// codeBlock records the lines [start, end) as a code block.
+func (p *parser) codeBlock(start, end int) {
+	var code strings.Builder
+	for i := start; i < end; i++ {
+		code.WriteString(p.lines[i])
+		code.WriteString("\n")
+	}
+	p.result.CodeBlocks = append(p.result.CodeBlocks, code.String())
+}
+
+// heading records a section title line.
+func (p *parser) heading(i int, m []string) {
+	p.kinds[i] = linekind.Prose
+	start := len(p.lines[i]) - len(m[2])
+	text := p.inlineText(i, start, markdown.SegmentHeading, true)
+	p.result.Headings = append(p.result.Headings, markdown.Heading{
+		Line:  i + 1,
+		Level: len(m[1]),
+		Text:  strings.Join(strings.Fields(strings.Join(text, "")), " "),
+	})
+}
+
+// addAdmonition records an admonition starting at line i.
+func (p *parser) addAdmonition(i int, label, title string) {
+	p.result.Admonitions = append(p.result.Admonitions, markdown.Admonition{
+		Line:  i + 1,
+		Type:  strings.ToLower(label),
+		Title: title,
+	})
+}
+
+// inlineText records the visible text of line i from byte from onward as
+// segments, and as prose when prose is set. Returns the text of each run,
+// including monospace runs.
+func (p *parser) inlineText(i, from int, kind markdown.SegmentKind, prose bool) []string {
+	return p.inlineRange(i, from, len(p.lines[i]), kind, prose)
+}
+
+// inlineRange is inlineText for the bytes [from, to) of line i.
+func (p *parser) inlineRange(i, from, to int, kind markdown.SegmentKind, prose bool) []string {
+	line := p.lines[i][from:to]
+	var text []string
+	for _, r := range inlineRuns(line) {
+		value := line[r.start:r.end]
+		text = append(text, value)
+		if r.code || strings.TrimSpace(value) == "" {
+			continue
+		}
+		start := from + r.start
+		p.result.Segments = append(p.result.Segments, markdown.Segment{
+			Text:   value,
+			Line:   i + 1,
+			Column: p.cursor.Column(p.lines, i, start),
+			Offset: p.offsets[i] + start,
+			Kind:   kind,
+		})
+		if prose {
+			p.prose.WriteString(value)
+		}
+	}
+	if prose {
+		p.prose.WriteString(" ") // Line break
+	}
+	return text
+}
+
+// blockStyle returns the first positional attribute of a block attribute
+// line, such as source in [source,go] or NOTE in [NOTE]. Anchors and
+// attributes without a style return "".
+func blockStyle(trimmed string) string {
+	if strings.HasPrefix(trimmed, "[[") {
+		return "" // Block anchor
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(trimmed, "["), "]")
+	style, _, _ := strings.Cut(inner, ",")
+	style, _, _ = strings.Cut(style, "#") // [source#id]
+	style, _, _ = strings.Cut(style, ".") // [NOTE.role]
+	style = strings.TrimSpace(style)
+	if strings.ContainsAny(style, "=\"") {
+		return "" // Named attribute, such as [cols="1,2"]
+	}
+	return style
+}
+
+// isComment reports whether a line is a // line comment.
+func isComment(line string) bool {
+	return strings.HasPrefix(line, "//") && !strings.HasPrefix(line, "////")
+}
+
+// startsIndented reports whether a line starts with whitespace, which
+// makes a literal paragraph.
+func startsIndented(line string) bool {
+	return line != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+// first returns the first of lines, or fallback when there are none.
+func first(lines []int, fallback int) int {
+	if len(lines) > 0 {
+		return lines[0]
+	}
+	return fallback
+}
@@ -0,0 +1,293 @@
+package asciidoc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantProse string
+		wantCode  []string
+		wantAdm   []markdown.Admonition
+	}{
+		{
+			name:      "paragraphs",
+			content:   "First line\nof a paragraph.\n\nSecond paragraph.\n",
+			wantProse: "First line of a paragraph. Second paragraph.",
+		},
+		{
+			name:      "inline markup",
+			content:   "Use *bold*, _italic_ and `code` with a link:https://example.com[link] to <<setup,the setup>>.\n",
+			wantProse: "Use bold, italic and with a link to the setup.",
+		},
+		{
+			name:      "lone marks are text",
+			content:   "Write C++ in snake_case or 2 * 3.\n",
+			wantProse: "Write C++ in snake_case or 2 * 3.",
+		},
+		{
+			name:      "listing and literal blocks",
+			content:   "[source,go]\n----\nfmt.Println()\n----\n\n....\nliteral\n....\n\n  indented literal\n\nText.\n",
+			wantProse: "Text.",
+			wantCode:  []string{"fmt.Println()\n", "literal\n", "  indented literal\n"},
+		},
+		{
+			name:      "source paragraph",
+			content:   "[source,bash]\ngo test ./...\n\nText.\n",
+			wantProse: "Text.",
+			wantCode:  []string{"go test ./...\n"},
+		},
+		{
+			name:      "admonitions",
+			content:   "NOTE: A short note.\n\n[WARNING]\n.Careful\n====\nA longer warning.\n====\n\n[TIP]\nA tip paragraph.\n\nText.\n",
+			wantProse: "Text.",
+			wantAdm: []markdown.Admonition{
+				{Line: 1, Type: "note"},
+				{Line: 3, Type: "warning", Title: "Careful"},
+				{Line: 9, Type: "tip"},
+			},
+		},
+		{
+			name:      "example block holds prose",
+			content:   "====\nInside an example.\n====\n",
+			wantProse: "Inside an example.",
+		},
+		{
+			name:      "comments are dropped",
+			content:   "// A comment.\nShown text.\n\n////\nHidden block.\n////\n",
+			wantProse: "Shown text.",
+		},
+		{
+			name:      "lists and tables are not prose",
+			content:   "* One\n* Two\n+\nContinued.\n\n|===\n|Cell |Other\n|===\n\nText.\n",
+			wantProse: "Text.",
+		},
+		{
+			name:      "passthrough block",
+			content:   "++++\n<div>Raw</div>\n++++\n\nText.\n",
+			wantProse: "Text.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if result.Prose != tt.wantProse {
+				t.Errorf("Prose = %q, want %q", result.Prose, tt.wantProse)
+			}
+			if tt.wantCode != nil && !reflect.DeepEqual(result.CodeBlocks, tt.wantCode) {
+				t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, tt.wantCode)
+			}
+			if tt.wantAdm == nil {
+				tt.wantAdm = []markdown.Admonition{}
+			}
+			if !reflect.DeepEqual(result.Admonitions, tt.wantAdm) {
+				t.Errorf("Admonitions = %+v, want %+v", result.Admonitions, tt.wantAdm)
+			}
+		})
+	}
+}
+
+func TestParse_Headings(t *testing.T) {
+	content := "= Guide\n\nIntro.\n\n== Setup\n\n* Step one\n\n=== Install *now*\n\n----\ncode\n----\n\n== Done\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Heading{
+		{Line: 1, Level: 1, Text: "Guide", Next: markdown.BlockParagraph},
+		{Line: 5, Level: 2, Text: "Setup", Next: markdown.BlockList},
+		{Line: 9, Level: 3, Text: "Install now", Next: markdown.BlockCode},
+		{Line: 15, Level: 2, Text: "Done"},
+	}
+	if !reflect.DeepEqual(result.Headings, want) {
+		t.Errorf("Headings = %+v, want %+v", result.Headings, want)
+	}
+}
+
+func TestParse_Header(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantFM   map[string]any
+		wantFMLn int
+		wantBody string
+	}{
+		{
+			name:     "title, author, and attributes",
+			content:  "= Guide\nJane Doe <jane@example.com>\n:description: A short guide.\n:toc:\n\nBody text.\n",
+			wantFM:   map[string]any{"description": "A short guide.", "toc": ""},
+			wantFMLn: 3,
+			wantBody: "Guide Body text.",
+		},
+		{
+			name:     "title only",
+			content:  "= Guide\n\nBody text.\n",
+			wantBody: "Guide Body text.",
+		},
+		{
+			name:     "no document title",
+			content:  ":toc:\n\nBody text.\n",
+			wantBody: "Body text.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if result.Prose != tt.wantBody {
+				t.Errorf("Prose = %q, want %q", result.Prose, tt.wantBody)
+			}
+			if tt.wantFM == nil {
+				if result.Frontmatter != nil {
+					t.Errorf("Frontmatter = %+v, want nil", result.Frontmatter)
+				}
+				return
+			}
+			fm := result.Frontmatter
+			if fm == nil {
+				t.Fatal("Frontmatter = nil")
+			}
+			if fm.Format != FrontmatterAttributes {
+				t.Errorf("Format = %q, want %q", fm.Format, FrontmatterAttributes)
+			}
+			if !reflect.DeepEqual(fm.Fields, tt.wantFM) {
+				t.Errorf("Fields = %v, want %v", fm.Fields, tt.wantFM)
+			}
+			if fm.Lines["description"] != tt.wantFMLn {
+				t.Errorf("Lines[description] = %d, want %d", fm.Lines["description"], tt.wantFMLn)
+			}
+		})
+	}
+}
+
+func TestParse_LineComposition(t *testing.T) {
+	content := strings.Join([]string{
+		"= Guide",       // 1 prose
+		":toc:",         // 2 frontmatter
+		"",              // 3 empty
+		"Intro.",        // 4 prose
+		"// a comment",  // 5 html
+		"",              // 6 empty
+		"* One",         // 7 list
+		"* Two",         // 8 list
+		"",              // 9 empty
+		"----",          // 10 code
+		"code",          // 11 code
+		"----",          // 12 code
+		"",              // 13 empty
+		"NOTE: A note.", // 14 admonition
+		"",              // 15 empty
+		"|===",          // 16 table
+		"|A |B",         // 17 table
+		"|===",          // 18 table
+	}, "\n")
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := [...]int{result.TotalLines, result.EmptyLines, result.FrontmatterLines, result.HTMLLines,
+		result.ListLines, result.CodeLines, result.AdmonitionLines, result.TableLines}
+	want := [...]int{18, 5, 1, 1, 2, 3, 1, 3}
+	if got != want {
+		t.Errorf("Total, Empty, Frontmatter, HTML, List, Code, Admonition, Table lines = %v, want %v", got, want)
+	}
+	if result.Tables != 1 {
+		t.Errorf("Tables = %d, want 1", result.Tables)
+	}
+}
+
+func TestParse_Segments(t *testing.T) {
+	content := "= Café\n\nSee *the* docs.\n\n* Item `x` here\n\n|===\n|Cell one |Two\n|===\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Segment{
+		{Text: "Café", Line: 1, Column: 3, Offset: 2, Kind: markdown.SegmentHeading},
+		{Text: "See ", Line: 3, Column: 1, Offset: 9, Kind: markdown.SegmentProse},
+		{Text: "the", Line: 3, Column: 6, Offset: 14, Kind: markdown.SegmentProse},
+		{Text: " docs.", Line: 3, Column: 10, Offset: 18, Kind: markdown.SegmentProse},
+		{Text: "Item ", Line: 5, Column: 3, Offset: 28, Kind: markdown.SegmentList},
+		{Text: " here", Line: 5, Column: 11, Offset: 36, Kind: markdown.SegmentList},
+		{Text: "Cell one ", Line: 8, Column: 2, Offset: 49, Kind: markdown.SegmentTable},
+		{Text: "Two", Line: 8, Column: 12, Offset: 59, Kind: markdown.SegmentTable},
+	}
+	if !reflect.DeepEqual(result.Segments, want) {
+		t.Errorf("Segments =\n%+v\nwant\n%+v", result.Segments, want)
+	}
+}
+
+func TestInlineRuns(t *testing.T) {
+	tests := []struct {
+		line string
+		want string // Visible text, with code in brackets
+	}{
+		{"plain text", "plain text"},
+		{"*bold* and **strong**", "bold and strong"},
+		{"a `code` span", "a [code] span"},
+		{"`*not bold*`", "[*not bold*]"},
+		{"kbd:[Ctrl+C] then btn:[Save]", "Ctrl+C then Save"},
+		{"image:logo.png[Logo] next", " next"},
+		{"see <<intro>> and {product}", "see  and "},
+		{"[[anchor]]Text", "Text"},
+		{"a hard break +", "a hard break "},
+		{"C++ and snake_case", "C++ and snake_case"},
+		{"https://example.com[Example site]", "Example site"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			var got strings.Builder
+			for _, r := range inlineRuns(tt.line) {
+				if r.code {
+					got.WriteString("[" + tt.line[r.start:r.end] + "]")
+				} else {
+					got.WriteString(tt.line[r.start:r.end])
+				}
+			}
+			if got.String() != tt.want {
+				t.Errorf("inlineRuns(%q) = %q, want %q", tt.line, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("= Title\n:toc:\n\n== Section\n\nText with *bold*.\n"))
+	f.Add([]byte("[source,go]\n----\ncode\n----\n"))
+	f.Add([]byte("NOTE: note\n\n[WARNING]\n====\nwarn\n====\n"))
+	f.Add([]byte("|===\n|a |b\n2+|c\n|===\n"))
+	f.Add([]byte("* one\n+\n--\nopen\n--\n"))
+	f.Add([]byte("====\n"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		result, err := Parse(content)
+		if err != nil {
+			return
+		}
+		for _, s := range result.Segments {
+			if s.Offset < 0 || s.Offset+len(s.Text) > len(content) {
+				t.Fatalf("segment %+v out of range for %d bytes", s, len(content))
+			}
+			if string(content[s.Offset:s.Offset+len(s.Text)]) != s.Text {
+				t.Fatalf("segment %+v does not match content", s)
+			}
+		}
+	})
+}
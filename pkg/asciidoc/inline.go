@@ -0,0 +1,209 @@
+package asciidoc
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// run is a stretch of visible text in a line, as byte offsets [start, end).
+// Code runs come from monospace spans.
+type run struct {
+	start, end int
+	code       bool
+}
+
+var (
+	// textMacro matches inline macros whose bracket text is shown to the
+	// reader: link:url[text], xref:id[text], kbd:[Ctrl+C], and URLs with
+	// link text.
+	textMacro = regexp.MustCompile(`\b(?:link|xref|mailto|kbd|btn|menu|footnote|pass):[^\s\[]*\[([^\]]*)\]|\b(?:https?|ftp|irc)://[^\s\[]+\[([^\]]*)\]`)
+
+	// hiddenMacro matches inline macros that show no text: images, icons,
+	// anchors, and index terms.
+	hiddenMacro = regexp.MustCompile(`\b(?:image|icon|anchor|indexterm|indexterm2|footnoteref):[^\s\[]*\[[^\]]*\]`)
+
+	// crossReference matches <<id>> and <<id,text>>.
+	crossReference = regexp.MustCompile(`<<([^,>]+)(?:,\s*([^>]*))?>>`)
+
+	// attributeReference matches {name}, {set:name:value}, and counters.
+	attributeReference = regexp.MustCompile(`\{[\w-]+(?::[^}]*)?\}`)
+
+	// inlineAnchor matches [[id]], [[[bibliography]]], and [#id.role] before
+	// formatted text.
+	inlineAnchor = regexp.MustCompile(`\[\[\[?[^\]]*\]\]\]?|\[[#.][\w.#-]*\]`)
+)
+
+// formatMarks are the characters that wrap formatted text: bold, italic,
+// highlight, monospace, and passthrough.
+const formatMarks = "*_#`+"
+
+// inlineRuns returns the visible text runs of a line, with inline markup
+// removed. Formatting marks must come in pairs on the same line; a lone
+// mark, as in C++ or snake_case, is text.
+func inlineRuns(line string) []run {
+	hidden := make([]bool, len(line))
+	code := make([]bool, len(line))
+	hide := func(from, to int) {
+		for i := from; i < to; i++ {
+			hidden[i] = true
+		}
+	}
+
+	// Monospace spans first, so markup inside them stays as code
+	for _, span := range pairedMarks(line, '`', hidden) {
+		hide(span.start, span.innerStart)
+		hide(span.innerEnd, span.end)
+		for i := span.innerStart; i < span.innerEnd; i++ {
+			code[i] = true
+		}
+	}
+
+	visible := func(from, to int) bool {
+		for i := from; i < to; i++ {
+			if hidden[i] || code[i] {
+				return false
+			}
+		}
+		return true
+	}
+	for _, m := range textMacro.FindAllStringSubmatchIndex(line, -1) {
+		if !visible(m[0], m[1]) {
+			continue
+		}
+		// Keep the bracket text, hide the rest
+		text := [2]int{m[2], m[3]}
+		if text[0] < 0 {
+			text = [2]int{m[4], m[5]}
+		}
+		hide(m[0], text[0])
+		hide(text[1], m[1])
+	}
+	for _, m := range crossReference.FindAllStringSubmatchIndex(line, -1) {
+		if !visible(m[0], m[1]) {
+			continue
+		}
+		if m[4] < 0 {
+			hide(m[0], m[1]) // Renders as the target title, which is unknown here
+			continue
+		}
+		hide(m[0], m[4])
+		hide(m[5], m[1])
+	}
+	for _, re := range []*regexp.Regexp{hiddenMacro, attributeReference, inlineAnchor} {
+		for _, m := range re.FindAllStringIndex(line, -1) {
+			if visible(m[0], m[1]) {
+				hide(m[0], m[1])
+			}
+		}
+	}
+
+	for _, mark := range formatMarks {
+		if mark == '`' {
+			continue
+		}
+		for _, span := range pairedMarks(line, byte(mark), hidden) {
+			if visible(span.start, span.end) {
+				hide(span.start, span.innerStart)
+				hide(span.innerEnd, span.end)
+			}
+		}
+	}
+
+	// A trailing " +" is a hard line break
+	if strings.HasSuffix(line, " +") && !hidden[len(line)-1] {
+		hide(len(line)-1, len(line))
+	}
+
+	var runs []run
+	for i := 0; i < len(line); {
+		if hidden[i] {
+			i++
+			continue
+		}
+		r := run{start: i, code: code[i]}
+		for i < len(line) && !hidden[i] && code[i] == r.code {
+			i++
+		}
+		r.end = i
+		runs = append(runs, r)
+	}
+	return runs
+}
+
+// markSpan is a formatted span: marks at [start, innerStart) and
+// [innerEnd, end) around the text.
+type markSpan struct {
+	start, innerStart, innerEnd, end int
+}
+
+// pairedMarks finds spans wrapped in a mark. Doubled marks (**bold**) may
+// appear anywhere; single marks must sit at word boundaries, with the
+// text touching both marks. Doubled + marks are left alone, since C++ is
+// far more common than ++passthrough++.
+func pairedMarks(line string, mark byte, hidden []bool) []markSpan {
+	var spans []markSpan
+	for i := 0; i < len(line); i++ {
+		if line[i] != mark || hidden[i] {
+			continue
+		}
+		double := mark != '+' && i+1 < len(line) && line[i+1] == mark
+		if double {
+			closing := strings.Index(line[i+2:], string([]byte{mark, mark}))
+			if closing > 0 {
+				end := i + 2 + closing
+				spans = append(spans, markSpan{i, i + 2, end, end + 2})
+				i = end + 1
+				continue
+			}
+		}
+		if !opensSpan(line, i) {
+			continue
+		}
+		closed := false
+		for j := i + 2; j < len(line); j++ {
+			if line[j] == mark && closesSpan(line, j) {
+				spans = append(spans, markSpan{i, i + 1, j, j + 1})
+				i = j
+				closed = true
+				break
+			}
+		}
+		if !closed {
+			break // No later mark can close either
+		}
+	}
+	return spans
+}
+
+// opensSpan reports whether a single mark at i can open a span: it follows
+// the start of the line or a non-word character, and text follows it.
+func opensSpan(line string, i int) bool {
+	if i+1 >= len(line) || line[i+1] == ' ' || line[i+1] == line[i] {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(line[:i])
+	return !isWordRune(prev)
+}
+
+// closesSpan reports whether a single mark at j can close a span: text
+// precedes it and the end of the line or a non-word character follows.
+func closesSpan(line string, j int) bool {
+	if line[j-1] == ' ' {
+		return false
+	}
+	if j+1 == len(line) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(line[j+1:])
+	return !isWordRune(next)
+}
+
+// isWordRune reports whether r is part of a word.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -13,26 +13,178 @@ import (
 
 // Config represents the content analyzer configuration.
 type Config struct {
-	Thresholds Thresholds     `yaml:"thresholds" json:"thresholds" jsonschema:"description=Base readability thresholds applied to all files"`
-	Overrides  []PathOverride `yaml:"overrides,omitempty" json:"overrides,omitempty" jsonschema:"description=Path-specific threshold overrides (first match wins)"`
+	Thresholds  Thresholds     `yaml:"thresholds" json:"thresholds" jsonschema:"description=Base readability thresholds applied to all files"`
+	Overrides   []PathOverride `yaml:"overrides,omitempty" json:"overrides,omitempty" jsonschema:"description=Path-specific threshold overrides (first match wins)"`
+	ReadingTime ReadingTime    `yaml:"reading_time,omitempty" json:"reading_time,omitempty" jsonschema:"description=Reading time estimate model shared by all output formats"`
+	Rules       Rules          `yaml:"rules,omitempty" json:"rules,omitempty" jsonschema:"description=Severity per rule ID (error\\, warning\\, info\\, or off)"`
+	Glossary    []string       `yaml:"glossary,omitempty" json:"glossary,omitempty" jsonschema:"description=Project terms never counted as rare words (e.g.\\, product names and domain jargon)"`
+	Acronyms    Acronyms       `yaml:"acronyms,omitempty" json:"acronyms,omitempty" jsonschema:"description=Settings for the content/undefined-acronym rule"`
+	Terminology []TermGroup    `yaml:"terminology,omitempty" json:"terminology,omitempty" jsonschema:"description=Preferred terms and the variants to replace across all documents"`
+	Spelling    Spelling       `yaml:"spelling,omitempty" json:"spelling,omitempty" jsonschema:"description=Project words for the content/spelling rule"`
+
+	InclusiveLanguage InclusiveLanguage `yaml:"inclusive_language,omitempty" json:"inclusive_language,omitempty" jsonschema:"description=Additions to and exclusions from the built-in inclusive language rule pack"`
+	HeadingCase       HeadingCase       `yaml:"heading_case,omitempty" json:"heading_case,omitempty" jsonschema:"description=Capitalization style checked by the style/heading-case rule"`
+	Frontmatter       FrontmatterRules  `yaml:"frontmatter,omitempty" json:"frontmatter,omitempty" jsonschema:"description=Keys and values required in page frontmatter"`
+	MkDocs            string            `yaml:"mkdocs,omitempty" json:"mkdocs,omitempty" jsonschema:"description=Path to mkdocs.yml (relative to the config file). Checks the nav and groups output by nav section"`
+	HTML              HTMLInput         `yaml:"html,omitempty" json:"html,omitempty" jsonschema:"description=Settings for reading .html and .htm files"`
+	GoDoc             GoDocInput        `yaml:"go_doc,omitempty" json:"go_doc,omitempty" jsonschema:"description=Settings for reading doc comments in .go files"`
+	Snippets          SnippetsInput     `yaml:"snippets,omitempty" json:"snippets,omitempty" jsonschema:"description=Settings for expanding pymdownx.snippets includes (--8<--) in Markdown files"`
+	Templates         TemplatesInput    `yaml:"templates,omitempty" json:"templates,omitempty" jsonschema:"description=Template tags (Hugo shortcodes\\, Jinja and Liquid tags) to strip from Markdown files before analysis"`
 }
 
+// Rule severity levels accepted in the rules section.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityOff     = "off"
+)
+
+// Rules maps rule IDs (e.g., "content/admonitions") to a severity level.
+// Rules that are not listed keep their built-in severity.
+type Rules map[string]string
+
 // Thresholds defines limits for pass/fail checks.
 type Thresholds struct {
-	MaxGrade       float64 `yaml:"max_grade" json:"max_grade" jsonschema:"minimum=0,maximum=100,default=16,examples=12;14;16,description=Maximum Flesch-Kincaid grade level (12 = high school senior\\, 16 = college senior)"`
-	MaxARI         float64 `yaml:"max_ari" json:"max_ari" jsonschema:"minimum=0,maximum=100,default=16,examples=12;14;16,description=Maximum Automated Readability Index (similar to grade level)"`
-	MaxFog         float64 `yaml:"max_fog" json:"max_fog" jsonschema:"minimum=0,maximum=100,default=18,examples=14;16;18,description=Maximum Gunning Fog index (years of formal education needed)"`
-	MinEase        float64 `yaml:"min_ease" json:"min_ease" jsonschema:"minimum=-100,maximum=100,default=25,examples=30;40;50;-100,description=Minimum Flesch Reading Ease (0-100 scale\\, higher = easier). Use negative value to disable."`
-	MaxLines       int     `yaml:"max_lines" json:"max_lines" jsonschema:"minimum=1,maximum=10000,default=375,examples=250;375;500,description=Maximum lines of prose per file"`
-	MinWords       int     `yaml:"min_words" json:"min_words" jsonschema:"minimum=0,maximum=10000,default=100,examples=50;100;150,description=Minimum words before applying readability formulas (sparse docs are unreliable)"`
-	MinAdmonitions int     `yaml:"min_admonitions" json:"min_admonitions" jsonschema:"minimum=-1,maximum=100,default=1,examples=0;1;2;-1,description=Minimum MkDocs-style admonitions required (!!! note\\, !!! warning). Use -1 to disable."`
-	MaxDashDensity float64 `yaml:"max_dash_density" json:"max_dash_density" jsonschema:"minimum=-1,maximum=500,default=0,examples=0;2;5;-1,description=Maximum mid-sentence dash pairs per 100 sentences (detects AI-generated slop). Use -1 to disable. 0 = no dashes allowed."`
+	MaxGrade                  float64 `yaml:"max_grade" json:"max_grade" jsonschema:"minimum=0,maximum=100,default=16,examples=12;14;16,description=Maximum Flesch-Kincaid grade level (12 = high school senior\\, 16 = college senior)"`
+	MaxARI                    float64 `yaml:"max_ari" json:"max_ari" jsonschema:"minimum=0,maximum=100,default=16,examples=12;14;16,description=Maximum Automated Readability Index (similar to grade level)"`
+	MaxFog                    float64 `yaml:"max_fog" json:"max_fog" jsonschema:"minimum=0,maximum=100,default=18,examples=14;16;18,description=Maximum Gunning Fog index (years of formal education needed)"`
+	MinEase                   float64 `yaml:"min_ease" json:"min_ease" jsonschema:"minimum=-100,maximum=100,default=25,examples=30;40;50;-100,description=Minimum Flesch Reading Ease (0-100 scale\\, higher = easier). Use negative value to disable."`
+	MaxLines                  int     `yaml:"max_lines" json:"max_lines" jsonschema:"minimum=1,maximum=10000,default=375,examples=250;375;500,description=Maximum lines per file (see max_lines_scope for which lines count)"`
+	MinWords                  int     `yaml:"min_words" json:"min_words" jsonschema:"minimum=0,maximum=10000,default=100,examples=50;100;150,description=Minimum words before applying readability formulas (sparse docs are unreliable)"`
+	MinAdmonitions            int     `yaml:"min_admonitions" json:"min_admonitions" jsonschema:"minimum=-1,maximum=100,default=1,examples=0;1;2;-1,description=Minimum MkDocs-style admonitions required (!!! note\\, !!! warning). Use -1 to disable."`
+	MaxDashDensity            float64 `yaml:"max_dash_density" json:"max_dash_density" jsonschema:"minimum=-1,maximum=500,default=0,examples=0;2;5;-1,description=Maximum mid-sentence dash pairs per 100 sentences (detects AI-generated slop). Use -1 to disable. 0 = no dashes allowed."`
+	MaxRareWordRatio          float64 `yaml:"max_rare_word_ratio" json:"max_rare_word_ratio" jsonschema:"minimum=-1,maximum=1,default=0,examples=0;0.15;0.25;-1,description=Maximum share of prose words outside the common English word list (0 = not checked). Use -1 to disable in an override."`
+	MaxSentenceStartRun       int     `yaml:"max_sentence_start_run" json:"max_sentence_start_run" jsonschema:"minimum=-1,maximum=100,default=0,examples=0;2;3;-1,description=Maximum consecutive sentences starting with the same word (0 = not checked). Use -1 to disable in an override."`
+	MinSentenceLengthVariance float64 `yaml:"min_sentence_length_variance" json:"min_sentence_length_variance" jsonschema:"minimum=-1,maximum=1000,default=0,examples=0;4;9;-1,description=Minimum variance of sentence lengths in words across five consecutive sentences (0 = not checked). Use -1 to disable in an override."`
+	MaxParagraphWords         int     `yaml:"max_paragraph_words" json:"max_paragraph_words" jsonschema:"minimum=-1,maximum=10000,default=0,examples=0;120;150;-1,description=Maximum words in a single paragraph (0 = not checked). Use -1 to disable in an override."`
+	MaxParagraphSentences     int     `yaml:"max_paragraph_sentences" json:"max_paragraph_sentences" jsonschema:"minimum=-1,maximum=1000,default=0,examples=0;6;8;-1,description=Maximum sentences in a single paragraph (0 = not checked). Use -1 to disable in an override."`
+	MaxLinesScope             string  `yaml:"max_lines_scope" json:"max_lines_scope" jsonschema:"enum=all,enum=prose,default=all,description=Lines counted against max_lines: all lines or only prose lines (not code\\, tables\\, lists\\, admonitions\\, HTML\\, frontmatter\\, or blank lines)"`
 }
 
 // PathOverride allows different thresholds for specific paths.
 type PathOverride struct {
 	Path       string     `yaml:"path" json:"path" jsonschema:"minLength=1,examples=docs/developer-guide/;docs/user-guide/;api/;README.md,description=Path prefix to match (e.g.\\, 'docs/developer-guide/' or 'api/')"`
 	Thresholds Thresholds `yaml:"thresholds" json:"thresholds" jsonschema:"description=Threshold overrides for this path (inherits unspecified values from base)"`
+	Rules      Rules      `yaml:"rules,omitempty" json:"rules,omitempty" jsonschema:"description=Rule severity overrides for this path (inherits unlisted rules from base)"`
+
+	Frontmatter FrontmatterRules `yaml:"frontmatter,omitempty" json:"frontmatter,omitempty" jsonschema:"description=Frontmatter overrides for this path (required replaces the base list\\, fields are merged by key)"`
+}
+
+// ReadingTime defines how reading time estimates are calculated.
+// Prose is read at a fixed pace; code lines, images, and tables each add a fixed cost.
+type ReadingTime struct {
+	WordsPerMinute     int     `yaml:"words_per_minute" json:"words_per_minute" jsonschema:"minimum=1,maximum=1000,default=200,examples=150;200;250,description=Prose reading speed in words per minute"`
+	SecondsPerCodeLine float64 `yaml:"seconds_per_code_line" json:"seconds_per_code_line" jsonschema:"minimum=0,maximum=600,default=0,examples=0;2;5,description=Seconds added for each line inside code blocks"`
+	SecondsPerImage    float64 `yaml:"seconds_per_image" json:"seconds_per_image" jsonschema:"minimum=0,maximum=600,default=0,examples=0;10;12,description=Seconds added for each image"`
+	SecondsPerTable    float64 `yaml:"seconds_per_table" json:"seconds_per_table" jsonschema:"minimum=0,maximum=600,default=0,examples=0;15;30,description=Seconds added for each table"`
+}
+
+// Acronyms configures which acronyms may be used without being spelled out.
+type Acronyms struct {
+	Allow []string `yaml:"allow,omitempty" json:"allow,omitempty" jsonschema:"description=Acronyms readers know without expansion (added to the built-in list such as API and URL)"`
+}
+
+// TermGroup declares the preferred spelling of a term and its unwanted synonyms.
+// Case, hyphenation, and spacing variants of the preferred term are matched automatically.
+type TermGroup struct {
+	Preferred string   `yaml:"preferred" json:"preferred" jsonschema:"minLength=1,description=Term to use everywhere (one to three words)"`
+	Variants  []string `yaml:"variants,omitempty" json:"variants,omitempty" jsonschema:"description=Synonyms to replace with the preferred term"`
+}
+
+// Spelling lists words the spell checker accepts in addition to its English dictionary.
+type Spelling struct {
+	Words     []string `yaml:"words,omitempty" json:"words,omitempty" jsonschema:"description=Words to accept (matched case-insensitively)"`
+	WordLists []string `yaml:"word_lists,omitempty" json:"word_lists,omitempty" jsonschema:"description=Files with one accepted word per line (relative to the config file)"`
+}
+
+// InclusiveLanguage customizes the built-in rule pack used by content/inclusive-language.
+type InclusiveLanguage struct {
+	Terms   []InclusiveTerm `yaml:"terms,omitempty" json:"terms,omitempty" jsonschema:"description=Entries to add (an entry with the id of a built-in entry replaces it)"`
+	Exclude []string        `yaml:"exclude,omitempty" json:"exclude,omitempty" jsonschema:"description=IDs of built-in entries to turn off"`
+}
+
+// InclusiveTerm is one entry of the inclusive language rule pack: terms to
+// flag and the alternatives to suggest.
+type InclusiveTerm struct {
+	ID          string   `yaml:"id" json:"id" jsonschema:"minLength=1,description=Entry identifier shown in diagnostics and used by exclude"`
+	Terms       []string `yaml:"terms" json:"terms" jsonschema:"minItems=1,description=Words or phrases to flag (matched case-insensitively\\, with plural and -ed/-ing forms)"`
+	Suggestions []string `yaml:"suggestions,omitempty" json:"suggestions,omitempty" jsonschema:"description=Alternatives to suggest\\, best first"`
+}
+
+// Line scopes for max_lines.
+const (
+	MaxLinesScopeAll   = "all"   // Every line in the file
+	MaxLinesScopeProse = "prose" // Only prose lines
+)
+
+// Heading capitalization styles.
+const (
+	HeadingCaseSentence = "sentence"
+	HeadingCaseTitle    = "title"
+)
+
+// HeadingCase configures the style/heading-case rule. The rule runs only when a style is set.
+type HeadingCase struct {
+	Style    string   `yaml:"style,omitempty" json:"style,omitempty" jsonschema:"enum=sentence,enum=title,description=Capitalization style for headings (sentence: only the first word and proper nouns\\, title: all major words)"`
+	Preserve []string `yaml:"preserve,omitempty" json:"preserve,omitempty" jsonschema:"description=Proper nouns and product names whose spelling is kept as written"`
+}
+
+// Frontmatter field types.
+const (
+	FieldString  = "string"
+	FieldNumber  = "number"
+	FieldInteger = "integer"
+	FieldBoolean = "boolean"
+	FieldArray   = "array"
+	FieldObject  = "object"
+	FieldDate    = "date"
+)
+
+// FrontmatterRules declares the keys page frontmatter must contain and the
+// values each key accepts. Nothing is checked when both are empty.
+type FrontmatterRules struct {
+	Required []string                    `yaml:"required,omitempty" json:"required,omitempty" jsonschema:"description=Keys every page must define"`
+	Fields   map[string]FrontmatterField `yaml:"fields,omitempty" json:"fields,omitempty" jsonschema:"description=Constraints per key\\, checked when the key is present"`
+}
+
+// FrontmatterField constrains the value of one frontmatter key.
+type FrontmatterField struct {
+	Type      string   `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=string,enum=number,enum=integer,enum=boolean,enum=array,enum=object,enum=date,description=Expected value type (date accepts YAML and TOML dates or YYYY-MM-DD strings)"`
+	Allowed   []string `yaml:"allowed,omitempty" json:"allowed,omitempty" jsonschema:"description=Accepted values (for arrays\\, every item must be accepted)"`
+	MinLength int      `yaml:"min_length,omitempty" json:"min_length,omitempty" jsonschema:"minimum=0,description=Minimum characters for strings or items for arrays"`
+	MaxLength int      `yaml:"max_length,omitempty" json:"max_length,omitempty" jsonschema:"minimum=0,description=Maximum characters for strings or items for arrays (0 = no limit)"`
+}
+
+// HTMLInput configures how HTML files are read.
+type HTMLInput struct {
+	AdmonitionClasses []string `yaml:"admonition_classes,omitempty" json:"admonition_classes,omitempty" jsonschema:"description=CSS classes that mark admonition containers (replaces the built-in list: admonition\\, note\\, tip\\, warning\\, callout\\, and others)"`
+}
+
+// GoDocInput configures how doc comments in Go source files are read.
+type GoDocInput struct {
+	Enabled  bool `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"default=false,description=Analyze doc comments in .go files when checking a directory (test files\\, vendor\\, and testdata are skipped)"`
+	MinWords int  `yaml:"min_words,omitempty" json:"min_words,omitempty" jsonschema:"minimum=0,maximum=10000,default=15,examples=15;30,description=Minimum words in a doc comment for readability checks (shorter comments\\, such as one-liners\\, are skipped)"`
+}
+
+// SnippetsInput configures how pymdownx.snippets includes are expanded.
+type SnippetsInput struct {
+	Enabled  bool     `yaml:"enabled,omitempty" json:"enabled,omitempty" jsonschema:"default=false,description=Replace --8<-- include directives with the included text before analysis"`
+	BasePath []string `yaml:"base_path,omitempty" json:"base_path,omitempty" jsonschema:"description=Directories that snippet paths are relative to\\, tried in order (relative to the config file\\, default: the config file's directory). Matches the base_path option of pymdownx.snippets"`
+}
+
+// TemplatesInput configures which template tags are stripped from
+// Markdown files.
+type TemplatesInput struct {
+	Profiles       []string             `yaml:"profiles,omitempty" json:"profiles,omitempty" jsonschema:"enum=hugo,enum=jinja,enum=liquid,description=Built-in tag syntaxes to strip: hugo ({{< >}}\\, {{% %}}\\, {{ }})\\, jinja ({{ }}\\, {% %}\\, {# #}\\, as in MkDocs-macros)\\, liquid ({{ }}\\, {% %})"`
+	Delimiters     []TemplateDelimiters `yaml:"delimiters,omitempty" json:"delimiters,omitempty" jsonschema:"description=Custom tag delimiters to strip\\, in addition to the profiles"`
+	KeepPairedText bool                 `yaml:"keep_paired_text,omitempty" json:"keep_paired_text,omitempty" jsonschema:"default=false,description=Keep the text between paired tags (such as {{< note >}}...{{< /note >}} or {% if %}...{% endif %}) as prose instead of stripping it"`
+}
+
+// TemplateDelimiters is the opening and closing text of a custom template tag.
+type TemplateDelimiters struct {
+	Open  string `yaml:"open" json:"open" jsonschema:"minLength=1,description=Text that opens a tag"`
+	Close string `yaml:"close" json:"close" jsonschema:"minLength=1,description=Text that closes a tag"`
 }
 
 // DefaultConfig returns sensible defaults for technical documentation.
@@ -48,6 +200,12 @@ func DefaultConfig() *Config {
 			MinAdmonitions: 1,   // Require at least one MkDocs-style admonition
 			MaxDashDensity: 0,   // No mid-sentence dashes allowed (prevents AI slop)
 		},
+		ReadingTime: ReadingTime{
+			WordsPerMinute: 200, // Technical content pace; code, images, and tables add nothing by default
+		},
+		GoDoc: GoDocInput{
+			MinWords: 15, // Skip one-line doc comments
+		},
 	}
 }
 
@@ -75,6 +233,24 @@ func Load(path string) (*Config, error) {
 		return nil, err
 	}
 
+	// Word lists, mkdocs.yml, and snippet base paths are relative to the config file, not the working directory
+	for i, list := range cfg.Spelling.WordLists {
+		if !filepath.IsAbs(list) {
+			cfg.Spelling.WordLists[i] = filepath.Join(filepath.Dir(path), list)
+		}
+	}
+	if cfg.MkDocs != "" && !filepath.IsAbs(cfg.MkDocs) {
+		cfg.MkDocs = filepath.Join(filepath.Dir(path), cfg.MkDocs)
+	}
+	if cfg.Snippets.Enabled && len(cfg.Snippets.BasePath) == 0 {
+		cfg.Snippets.BasePath = []string{"."}
+	}
+	for i, base := range cfg.Snippets.BasePath {
+		if !filepath.IsAbs(base) {
+			cfg.Snippets.BasePath[i] = filepath.Join(filepath.Dir(path), base)
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -136,6 +312,58 @@ func FindConfigFile(startDir string) string {
 
 // ThresholdsForPath returns the appropriate thresholds for a given file path.
 func (c *Config) ThresholdsForPath(filePath string) Thresholds {
+	if override := c.overrideForPath(filePath); override != nil {
+		// Merge with defaults - override only specified values
+		return mergeThresholds(c.Thresholds, override.Thresholds)
+	}
+	return c.Thresholds
+}
+
+// RulesForPath returns the rule severities that apply to a given file path.
+// Rules listed in a matching override replace the base severity for that rule.
+func (c *Config) RulesForPath(filePath string) Rules {
+	override := c.overrideForPath(filePath)
+	if override == nil || len(override.Rules) == 0 {
+		return c.Rules
+	}
+
+	merged := make(Rules, len(c.Rules)+len(override.Rules))
+	for rule, severity := range c.Rules {
+		merged[rule] = severity
+	}
+	for rule, severity := range override.Rules {
+		merged[rule] = severity
+	}
+	return merged
+}
+
+// FrontmatterForPath returns the frontmatter rules that apply to a given file path.
+// A matching override replaces the required keys when it lists any (an empty
+// list clears them) and adds or replaces field constraints by key.
+func (c *Config) FrontmatterForPath(filePath string) FrontmatterRules {
+	override := c.overrideForPath(filePath)
+	if override == nil {
+		return c.Frontmatter
+	}
+
+	merged := FrontmatterRules{Required: c.Frontmatter.Required}
+	if override.Frontmatter.Required != nil {
+		merged.Required = override.Frontmatter.Required
+	}
+	if len(c.Frontmatter.Fields)+len(override.Frontmatter.Fields) > 0 {
+		merged.Fields = make(map[string]FrontmatterField)
+		for key, field := range c.Frontmatter.Fields {
+			merged.Fields[key] = field
+		}
+		for key, field := range override.Frontmatter.Fields {
+			merged.Fields[key] = field
+		}
+	}
+	return merged
+}
+
+// overrideForPath returns the first override matching the file path, or nil.
+func (c *Config) overrideForPath(filePath string) *PathOverride {
 	// Normalize path separators
 	normalizedPath := filepath.ToSlash(filePath)
 
@@ -147,18 +375,17 @@ func (c *Config) ThresholdsForPath(filePath string) Thresholds {
 	normalizedPath = strings.TrimPrefix(normalizedPath, "./")
 
 	// Check overrides in order (first match wins)
-	for _, override := range c.Overrides {
-		overridePath := filepath.ToSlash(override.Path)
+	for i := range c.Overrides {
+		overridePath := filepath.ToSlash(c.Overrides[i].Path)
 		// Check if override path appears anywhere in the file path
 		// This handles both relative paths (docs/guide.md) and
 		// absolute paths (/home/runner/work/repo/docs/guide.md)
 		if strings.HasPrefix(normalizedPath, overridePath) || strings.Contains(normalizedPath, "/"+overridePath) {
-			// Merge with defaults - override only specified values
-			return mergeThresholds(c.Thresholds, override.Thresholds)
+			return &c.Overrides[i]
 		}
 	}
 
-	return c.Thresholds
+	return nil
 }
 
 // mergeThresholds returns base thresholds with non-zero override values applied.
@@ -167,6 +394,12 @@ func (c *Config) ThresholdsForPath(filePath string) Thresholds {
 //   - MinEase: use any negative value (e.g., -100) to allow very low readability
 //   - MinAdmonitions: use -1 to disable the admonition requirement
 //   - MaxDashDensity: use -1 to disable dash density check
+//   - MaxRareWordRatio: use -1 to disable the rare word check
+//   - MaxSentenceStartRun: use -1 to disable the sentence start check
+//   - MinSentenceLengthVariance: use -1 to disable the sentence length check
+//   - MaxParagraphWords, MaxParagraphSentences: use -1 to disable the paragraph limit
+//
+// An empty MaxLinesScope inherits the base scope.
 func mergeThresholds(base, override Thresholds) Thresholds {
 	result := base
 	if override.MaxGrade > 0 {
@@ -193,5 +426,23 @@ func mergeThresholds(base, override Thresholds) Thresholds {
 	if override.MaxDashDensity >= 0 {
 		result.MaxDashDensity = override.MaxDashDensity
 	}
+	if override.MaxRareWordRatio != 0 {
+		result.MaxRareWordRatio = override.MaxRareWordRatio
+	}
+	if override.MaxSentenceStartRun != 0 {
+		result.MaxSentenceStartRun = override.MaxSentenceStartRun
+	}
+	if override.MinSentenceLengthVariance != 0 {
+		result.MinSentenceLengthVariance = override.MinSentenceLengthVariance
+	}
+	if override.MaxParagraphWords != 0 {
+		result.MaxParagraphWords = override.MaxParagraphWords
+	}
+	if override.MaxParagraphSentences != 0 {
+		result.MaxParagraphSentences = override.MaxParagraphSentences
+	}
+	if override.MaxLinesScope != "" {
+		result.MaxLinesScope = override.MaxLinesScope
+	}
 	return result
 }
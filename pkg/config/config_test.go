@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
@@ -608,3 +609,466 @@ func TestLoad_SchemaStructSyncDefense(t *testing.T) {
 	// Verify we got an error (defensive error handling worked)
 	t.Logf("Got error (as expected): %v", err)
 }
+
+func TestLoad_ReadingTime(t *testing.T) {
+	content := `reading_time:
+  seconds_per_code_line: 2
+  seconds_per_image: 12
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Unspecified fields keep their defaults
+	if cfg.ReadingTime.WordsPerMinute != 200 {
+		t.Errorf("WordsPerMinute = %v, want 200", cfg.ReadingTime.WordsPerMinute)
+	}
+	if cfg.ReadingTime.SecondsPerCodeLine != 2 {
+		t.Errorf("SecondsPerCodeLine = %v, want 2", cfg.ReadingTime.SecondsPerCodeLine)
+	}
+	if cfg.ReadingTime.SecondsPerImage != 12 {
+		t.Errorf("SecondsPerImage = %v, want 12", cfg.ReadingTime.SecondsPerImage)
+	}
+	if cfg.ReadingTime.SecondsPerTable != 0 {
+		t.Errorf("SecondsPerTable = %v, want 0", cfg.ReadingTime.SecondsPerTable)
+	}
+}
+
+func TestLoad_ReadingTimeInvalid(t *testing.T) {
+	content := `reading_time:
+  words_per_minute: 0
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := Load(configPath); err == nil {
+		t.Error("Expected schema error for words_per_minute below minimum")
+	}
+}
+
+func TestRulesForPath(t *testing.T) {
+	cfg := &Config{
+		Rules: Rules{
+			"content/admonitions": SeverityInfo,
+			"structure/max-lines": SeverityWarning,
+		},
+		Overrides: []PathOverride{
+			{Path: "docs/api/", Rules: Rules{"content/admonitions": SeverityOff}},
+			{Path: "docs/guides/"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantAdm  string
+		wantLine string
+	}{
+		{"base rules", "README.md", SeverityInfo, SeverityWarning},
+		{"override replaces listed rule", "docs/api/ref.md", SeverityOff, SeverityWarning},
+		{"override without rules inherits base", "docs/guides/start.md", SeverityInfo, SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := cfg.RulesForPath(tt.path)
+			if rules["content/admonitions"] != tt.wantAdm {
+				t.Errorf("content/admonitions = %q, want %q", rules["content/admonitions"], tt.wantAdm)
+			}
+			if rules["structure/max-lines"] != tt.wantLine {
+				t.Errorf("structure/max-lines = %q, want %q", rules["structure/max-lines"], tt.wantLine)
+			}
+		})
+	}
+
+	// Merging must not modify the base rules
+	if cfg.Rules["content/admonitions"] != SeverityInfo {
+		t.Error("RulesForPath modified base rules")
+	}
+}
+
+func TestLoad_Rules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "valid severities",
+			content: `rules:
+  content/admonitions: info
+  structure/max-lines: off
+overrides:
+  - path: docs/api/
+    rules:
+      readability/grade-level: warning
+`,
+		},
+		{
+			name: "unknown severity",
+			content: `rules:
+  content/admonitions: fatal
+`,
+			wantErr: true,
+		},
+		{
+			name: "malformed rule ID",
+			content: `rules:
+  admonitions: info
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".readability.yml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(configPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.Rules["structure/max-lines"] != SeverityOff {
+				t.Errorf("structure/max-lines = %q, want off", cfg.Rules["structure/max-lines"])
+			}
+			if cfg.Overrides[0].Rules["readability/grade-level"] != SeverityWarning {
+				t.Errorf("override rule = %q, want warning", cfg.Overrides[0].Rules["readability/grade-level"])
+			}
+		})
+	}
+}
+
+func TestLoad_Vocabulary(t *testing.T) {
+	content := `thresholds:
+  max_rare_word_ratio: 0.2
+glossary:
+  - Kubernetes
+  - pull request
+overrides:
+  - path: docs/reference/
+    thresholds:
+      max_rare_word_ratio: -1
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(cfg.Glossary) != 2 || cfg.Glossary[1] != "pull request" {
+		t.Errorf("Glossary = %v, want [Kubernetes pull request]", cfg.Glossary)
+	}
+	if got := cfg.ThresholdsForPath("docs/guide.md").MaxRareWordRatio; got != 0.2 {
+		t.Errorf("MaxRareWordRatio = %v, want 0.2", got)
+	}
+	if got := cfg.ThresholdsForPath("docs/reference/api.md").MaxRareWordRatio; got != -1 {
+		t.Errorf("MaxRareWordRatio for override = %v, want -1", got)
+	}
+}
+
+func TestLoad_Terminology(t *testing.T) {
+	content := `terminology:
+  - preferred: sign in
+    variants: [log in, login]
+  - preferred: GitHub
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Terminology) != 2 {
+		t.Fatalf("Terminology = %+v, want 2 groups", cfg.Terminology)
+	}
+	if cfg.Terminology[0].Preferred != "sign in" || len(cfg.Terminology[0].Variants) != 2 {
+		t.Errorf("Terminology[0] = %+v", cfg.Terminology[0])
+	}
+
+	missing := filepath.Join(tmpDir, "missing.yml")
+	if err := os.WriteFile(missing, []byte("terminology:\n  - variants: [login]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(missing); err == nil {
+		t.Error("Expected schema error for terminology entry without preferred")
+	}
+}
+
+func TestLoad_SpellingWordListsRelativeToConfig(t *testing.T) {
+	content := `spelling:
+  words: [kustomize]
+  word_lists: [words.txt, /etc/words.txt]
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []string{filepath.Join(tmpDir, "words.txt"), "/etc/words.txt"}
+	if len(cfg.Spelling.WordLists) != 2 || cfg.Spelling.WordLists[0] != want[0] || cfg.Spelling.WordLists[1] != want[1] {
+		t.Errorf("WordLists = %v, want %v", cfg.Spelling.WordLists, want)
+	}
+	if len(cfg.Spelling.Words) != 1 {
+		t.Errorf("Words = %v, want [kustomize]", cfg.Spelling.Words)
+	}
+}
+
+func TestMergeThresholds_SentenceMonotony(t *testing.T) {
+	base := Thresholds{MaxSentenceStartRun: 2, MinSentenceLengthVariance: 4}
+
+	tests := []struct {
+		name         string
+		override     Thresholds
+		wantRun      int
+		wantVariance float64
+	}{
+		{"inherit", Thresholds{}, 2, 4},
+		{"raise", Thresholds{MaxSentenceStartRun: 3, MinSentenceLengthVariance: 9}, 3, 9},
+		{"disable", Thresholds{MaxSentenceStartRun: -1, MinSentenceLengthVariance: -1}, -1, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeThresholds(base, tt.override)
+			if got.MaxSentenceStartRun != tt.wantRun || got.MinSentenceLengthVariance != tt.wantVariance {
+				t.Errorf("mergeThresholds() = %d, %v, want %d, %v",
+					got.MaxSentenceStartRun, got.MinSentenceLengthVariance, tt.wantRun, tt.wantVariance)
+			}
+		})
+	}
+}
+
+func TestLoad_ParagraphLimits(t *testing.T) {
+	content := `thresholds:
+  max_paragraph_words: 150
+  max_paragraph_sentences: 8
+overrides:
+  - path: docs/reference/
+    thresholds:
+      max_paragraph_words: -1
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got := cfg.ThresholdsForPath("docs/reference/api.md")
+	if got.MaxParagraphWords != -1 || got.MaxParagraphSentences != 8 {
+		t.Errorf("override thresholds = %d words, %d sentences, want -1 and 8", got.MaxParagraphWords, got.MaxParagraphSentences)
+	}
+}
+
+func TestLoad_InclusiveLanguage(t *testing.T) {
+	content := `inclusive_language:
+  exclude: [sanity-check]
+  terms:
+    - id: kill-process
+      terms: [kill the process]
+      suggestions: [stop the process]
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.InclusiveLanguage.Exclude) != 1 || len(cfg.InclusiveLanguage.Terms) != 1 {
+		t.Errorf("InclusiveLanguage = %+v", cfg.InclusiveLanguage)
+	}
+
+	missing := filepath.Join(tmpDir, "missing.yml")
+	if err := os.WriteFile(missing, []byte("inclusive_language:\n  terms:\n    - id: empty\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(missing); err == nil {
+		t.Error("Expected schema error for entry without terms")
+	}
+}
+
+func TestLoad_HeadingCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"sentence", "heading_case:\n  style: sentence\n  preserve: [Kubernetes]\n", false},
+		{"title", "heading_case:\n  style: title\n", false},
+		{"unknown style", "heading_case:\n  style: upper\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".readability.yml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			cfg, err := Load(configPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.HeadingCase.Style != tt.name {
+				t.Errorf("Style = %q, want %q", cfg.HeadingCase.Style, tt.name)
+			}
+		})
+	}
+}
+
+func TestFrontmatterForPath(t *testing.T) {
+	content := `frontmatter:
+  required: [title, description]
+  fields:
+    description:
+      type: string
+      max_length: 160
+    status:
+      allowed: [draft, published]
+overrides:
+  - path: docs/blog/
+    frontmatter:
+      required: [title, date]
+      fields:
+        date:
+          type: date
+        description:
+          max_length: 200
+`
+	configPath := filepath.Join(t.TempDir(), ".readability.yml")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	base := cfg.FrontmatterForPath("docs/guide.md")
+	if !reflect.DeepEqual(base.Required, []string{"title", "description"}) {
+		t.Errorf("base Required = %v", base.Required)
+	}
+	if len(base.Fields) != 2 {
+		t.Errorf("base Fields = %v, want 2 keys", base.Fields)
+	}
+
+	blog := cfg.FrontmatterForPath("docs/blog/post.md")
+	if !reflect.DeepEqual(blog.Required, []string{"title", "date"}) {
+		t.Errorf("blog Required = %v, want override to replace base", blog.Required)
+	}
+	if len(blog.Fields) != 3 || blog.Fields["date"].Type != FieldDate || blog.Fields["description"].MaxLength != 200 {
+		t.Errorf("blog Fields = %v, want merged by key", blog.Fields)
+	}
+	if _, ok := cfg.Frontmatter.Fields["date"]; ok {
+		t.Error("FrontmatterForPath modified the base rules")
+	}
+}
+
+func TestLoad_FrontmatterUnknownType(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".readability.yml")
+	content := "frontmatter:\n  fields:\n    title:\n      type: text\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Error("Load() error = nil, want schema error for unknown type")
+	}
+}
+
+func TestLoad_MkDocsRelativeToConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".readability.yml")
+	if err := os.WriteFile(configPath, []byte("mkdocs: site/mkdocs.yml\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := filepath.Join(dir, "site", "mkdocs.yml"); cfg.MkDocs != want {
+		t.Errorf("MkDocs = %q, want %q", cfg.MkDocs, want)
+	}
+}
+
+func TestLoad_SnippetBasePathsRelativeToConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"default", "snippets:\n  enabled: true\n", []string{"."}},
+		{"relative", "snippets:\n  enabled: true\n  base_path: [docs/includes, /abs]\n", []string{"docs/includes", "/abs"}},
+		{"disabled", "snippets:\n  enabled: false\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, ".readability.yml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			cfg, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			var want []string
+			for _, p := range tt.want {
+				if !filepath.IsAbs(p) {
+					p = filepath.Join(dir, p)
+				}
+				want = append(want, p)
+			}
+			if !reflect.DeepEqual(cfg.Snippets.BasePath, want) {
+				t.Errorf("BasePath = %q, want %q", cfg.Snippets.BasePath, want)
+			}
+		})
+	}
+}
+
+func TestMergeThresholds_MaxLinesScope(t *testing.T) {
+	base := Thresholds{MaxLinesScope: MaxLinesScopeProse}
+
+	if got := mergeThresholds(base, Thresholds{}); got.MaxLinesScope != MaxLinesScopeProse {
+		t.Errorf("inherit: MaxLinesScope = %q, want %q", got.MaxLinesScope, MaxLinesScopeProse)
+	}
+	if got := mergeThresholds(base, Thresholds{MaxLinesScope: MaxLinesScopeAll}); got.MaxLinesScope != MaxLinesScopeAll {
+		t.Errorf("override: MaxLinesScope = %q, want %q", got.MaxLinesScope, MaxLinesScopeAll)
+	}
+}
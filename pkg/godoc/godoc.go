@@ -0,0 +1,510 @@
+// Package godoc reads the doc comments of Go source files into the same
+// shape as markdown.Parse. Only comments that go doc publishes are read:
+// the package comment and the comments of exported types, functions, and
+// methods. Comment text follows the Go doc comment syntax
+// (https://go.dev/doc/comment): indented spans are code blocks or lists,
+// and lines starting with "# " are headings. Go identifiers in the text,
+// such as the package name, are left out of segments the way Markdown
+// leaves out inline code.
+package godoc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// headingLevel is the level of doc comment headings, as go/doc renders them.
+const headingLevel = 3
+
+// Unit is the doc comment of one symbol. Each unit is scored on its own.
+type Unit struct {
+	Symbol string // "package name", "type T", "func F", or "method T.M"
+	Line   int    // First line of the comment (1-based)
+	Prose  string // Paragraph and heading text
+}
+
+var (
+	// directive matches comment lines that are tool directives, not
+	// documentation, such as //go:generate or //nolint:errcheck.
+	directive = regexp.MustCompile(`^//(line |extern |export |[a-z0-9]+:[a-z0-9])`)
+
+	// linkDefinition matches a link target definition: [Text]: URL
+	linkDefinition = regexp.MustCompile(`^\[([^\]]+)\]:\s+\S+\s*$`)
+
+	// listMarker matches the start of a list item: a bullet or a number
+	// followed by a space or tab.
+	listMarker = regexp.MustCompile(`^[ \t]*(?:[-*+•]|[0-9]+[.)])[ \t]+`)
+
+	// docLink matches a bracketed doc link or link reference: [Name],
+	// [pkg.Name.Method], [*bytes.Buffer] or [link text].
+	docLink = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+	// symbolLink matches the text of a doc link to a Go symbol.
+	symbolLink = regexp.MustCompile(`^\*?(?:[\w./-]+\.)?\w+(?:\.\w+)?$`)
+
+	// identifier matches a word that may be a Go identifier.
+	identifier = regexp.MustCompile(`[\p{L}_][\p{L}\p{N}_]*`)
+
+	// majorVersion matches the major version suffix of an import path,
+	// as in /v6 or gopkg.in/yaml.v3.
+	majorVersion = regexp.MustCompile(`[./]v[0-9]+$`)
+)
+
+// line is one line of comment text.
+type line struct {
+	text   string // Text after the comment marker and common indent
+	offset int    // Byte offset of text in the file
+	number int    // Line number (1-based)
+}
+
+// reader collects the doc comments of one file.
+type reader struct {
+	content []byte
+	file    *token.File
+	idents  map[string]bool // Go identifiers that comments may mention
+	result  *markdown.ParseResult
+	prose   strings.Builder // Prose of the current unit
+	pending []int           // Headings waiting for the next block
+}
+
+// Parse extracts prose content, code blocks, and headings from the doc
+// comments of a Go source file. Line counts cover doc comment lines only.
+// The units hold each documented symbol in file order.
+func Parse(content []byte) (*markdown.ParseResult, []Unit, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &reader{
+		content: content,
+		file:    fset.File(f.Pos()),
+		idents:  identifiers(f),
+		result: &markdown.ParseResult{
+			CodeBlocks:  make([]string, 0),
+			Headings:    make([]markdown.Heading, 0),
+			Admonitions: make([]markdown.Admonition, 0),
+			Segments:    make([]markdown.Segment, 0),
+			Paragraphs:  make([]markdown.Paragraph, 0),
+		},
+	}
+
+	units := make([]Unit, 0)
+	add := func(symbol string, doc *ast.CommentGroup) {
+		if u, ok := r.unit(symbol, doc); ok {
+			units = append(units, u)
+		}
+	}
+	if f.Doc != nil {
+		add("package "+f.Name.Name, f.Doc)
+	}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(d.Specs) == 1 {
+					doc = d.Doc
+				}
+				if doc != nil && ts.Name.IsExported() {
+					add("type "+ts.Name.Name, doc)
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Doc == nil || !d.Name.IsExported() {
+				continue
+			}
+			symbol := "func " + d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := receiverType(d.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				symbol = "method " + recv + "." + d.Name.Name
+			}
+			add(symbol, d.Doc)
+		}
+	}
+
+	prose := make([]string, len(units))
+	for i, u := range units {
+		prose[i] = u.Prose
+	}
+	r.result.Prose = strings.Join(prose, " ")
+	return r.result, units, nil
+}
+
+// unit reads one doc comment. It reports false for comments that hold
+// only directives.
+func (r *reader) unit(symbol string, doc *ast.CommentGroup) (Unit, bool) {
+	lines := r.lines(doc)
+	if len(lines) == 0 {
+		return Unit{}, false
+	}
+	r.prose.Reset()
+	r.pending = r.pending[:0]
+	r.result.TotalLines += len(lines)
+
+	links := make(map[string]bool)
+	for _, l := range lines {
+		if m := linkDefinition.FindStringSubmatch(l.text); m != nil {
+			links[m[1]] = true
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		l := lines[i]
+		switch {
+		case blank(l):
+			r.result.EmptyLines++
+			i++
+
+		case indented(l):
+			j := i
+			for j < len(lines) && (blank(lines[j]) || indented(lines[j])) {
+				j++
+			}
+			for blank(lines[j-1]) {
+				j--
+			}
+			if listMarker.MatchString(l.text) {
+				r.list(lines[i:j], links)
+			} else {
+				r.code(lines[i:j])
+			}
+			i = j
+
+		default:
+			j := i
+			for j < len(lines) && !blank(lines[j]) && !indented(lines[j]) {
+				j++
+			}
+			setOff := (i == 0 || blank(lines[i-1])) && (j == len(lines) || blank(lines[j]))
+			switch {
+			case j == i+1 && setOff && strings.HasPrefix(l.text, "# ") && strings.TrimSpace(l.text[2:]) != "":
+				r.heading(l, 2)
+			case j == i+1 && isOldHeading(lines, i):
+				r.heading(l, 0)
+			case linkDefinitions(lines[i:j]):
+				r.result.HTMLLines += j - i
+			default:
+				r.paragraph(lines[i:j], links)
+			}
+			i = j
+		}
+	}
+
+	return Unit{
+		Symbol: symbol,
+		Line:   lines[0].number,
+		Prose:  strings.Join(strings.Fields(r.prose.String()), " "),
+	}, true
+}
+
+// lines returns the lines of a comment group with comment markers and the
+// common indent removed. Directive lines are left out.
+func (r *reader) lines(doc *ast.CommentGroup) []line {
+	var lines []line
+	for _, c := range doc.List {
+		start := r.file.Offset(c.Slash)
+		raw := string(r.content[start:r.file.Offset(c.End())])
+		if strings.HasPrefix(raw, "//") {
+			if directive.MatchString(raw) {
+				continue
+			}
+			text, offset := raw[2:], start+2
+			if strings.HasPrefix(text, " ") {
+				text, offset = text[1:], offset+1
+			}
+			lines = append(lines, line{strings.TrimSuffix(text, "\r"), offset, r.file.Line(c.Slash)})
+			continue
+		}
+
+		// Block comment: /* ... */
+		offset := start + 2
+		number := r.file.Line(c.Slash)
+		for i, text := range strings.Split(raw[2:len(raw)-2], "\n") {
+			lines = append(lines, line{strings.TrimSuffix(text, "\r"), offset, number + i})
+			offset += len(text) + 1
+		}
+	}
+
+	// Remove the indent shared by all non-blank lines
+	prefix := ""
+	first := true
+	for _, l := range lines {
+		if blank(l) {
+			continue
+		}
+		indent := l.text[:len(l.text)-len(strings.TrimLeft(l.text, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i := range lines {
+		if blank(lines[i]) {
+			lines[i].text = ""
+			continue
+		}
+		lines[i].text = lines[i].text[len(prefix):]
+		lines[i].offset += len(prefix)
+	}
+	return lines
+}
+
+// heading records a heading line whose text starts at byte skip.
+func (r *reader) heading(l line, skip int) {
+	text := strings.TrimSpace(l.text[skip:])
+	r.result.Headings = append(r.result.Headings, markdown.Heading{
+		Line:  l.number,
+		Level: headingLevel,
+		Text:  text,
+	})
+	r.block(markdown.BlockHeading)
+	r.pending = append(r.pending, len(r.result.Headings)-1)
+	r.segments(text, l.offset+skip+strings.Index(l.text[skip:], text), l.number, markdown.SegmentHeading)
+	r.prose.WriteString(text + " ")
+}
+
+// paragraph records a span of unindented lines.
+func (r *reader) paragraph(lines []line, links map[string]bool) {
+	var text strings.Builder
+	for _, l := range lines {
+		for _, run := range visibleRuns(l.text, links) {
+			r.segments(l.text[run[0]:run[1]], l.offset+run[0], l.number, markdown.SegmentProse)
+			text.WriteString(l.text[run[0]:run[1]])
+		}
+		text.WriteString(" ")
+	}
+	r.result.Paragraphs = append(r.result.Paragraphs, markdown.Paragraph{
+		Line: lines[0].number,
+		Text: strings.Join(strings.Fields(text.String()), " "),
+	})
+	r.block(markdown.BlockParagraph)
+	r.prose.WriteString(text.String())
+}
+
+// list records an indented span that starts with a list marker. Items are
+// not prose, as in Markdown.
+func (r *reader) list(lines []line, links map[string]bool) {
+	for _, l := range lines {
+		if blank(l) {
+			r.result.EmptyLines++
+			continue
+		}
+		r.result.ListLines++
+		text, offset := l.text, l.offset
+		if m := listMarker.FindString(text); m != "" {
+			text, offset = text[len(m):], offset+len(m)
+		}
+		for _, run := range visibleRuns(text, links) {
+			r.segments(text[run[0]:run[1]], offset+run[0], l.number, markdown.SegmentList)
+		}
+	}
+	r.block(markdown.BlockList)
+}
+
+// code records an indented span as a code block.
+func (r *reader) code(lines []line) {
+	prefix := ""
+	for i, l := range lines {
+		if blank(l) {
+			continue
+		}
+		indent := l.text[:len(l.text)-len(strings.TrimLeft(l.text, " \t"))]
+		if i == 0 {
+			prefix = indent
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	var code strings.Builder
+	for _, l := range lines {
+		if !blank(l) {
+			code.WriteString(l.text[len(prefix):])
+		}
+		code.WriteString("\n")
+	}
+	r.result.CodeBlocks = append(r.result.CodeBlocks, code.String())
+	r.result.CodeLines += len(lines)
+	r.block(markdown.BlockCode)
+}
+
+// block records a block for the headings waiting for one.
+func (r *reader) block(kind markdown.BlockKind) {
+	for _, i := range r.pending {
+		r.result.Headings[i].Next = kind
+	}
+	r.pending = r.pending[:0]
+}
+
+// segments records the text around the Go identifiers in text, which
+// starts at byte offset of the file.
+func (r *reader) segments(text string, offset, number int, kind markdown.SegmentKind) {
+	from := 0
+	for _, loc := range identifier.FindAllStringIndex(text, -1) {
+		if !r.idents[text[loc[0]:loc[1]]] {
+			continue
+		}
+		r.segment(text[from:loc[0]], offset+from, number, kind)
+		from = loc[1]
+	}
+	r.segment(text[from:], offset+from, number, kind)
+}
+
+// segment records text that starts at byte offset of the file, without the
+// whitespace around it.
+func (r *reader) segment(text string, offset, number int, kind markdown.SegmentKind) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return
+	}
+	offset += strings.Index(text, trimmed)
+	lineStart := r.file.Offset(r.file.LineStart(number))
+	r.result.Segments = append(r.result.Segments, markdown.Segment{
+		Text:   trimmed,
+		Line:   number,
+		Column: utf8.RuneCount(r.content[lineStart:offset]) + 1,
+		Offset: offset,
+		Kind:   kind,
+	})
+}
+
+// visibleRuns returns the byte ranges of text left when the brackets of doc
+// links and link references are removed: "See [Reader]." shows "See
+// Reader.".
+func visibleRuns(text string, links map[string]bool) [][2]int {
+	var runs [][2]int
+	from := 0
+	for _, m := range docLink.FindAllStringSubmatchIndex(text, -1) {
+		inner := text[m[2]:m[3]]
+		if !links[inner] && !symbolLink.MatchString(inner) {
+			continue
+		}
+		runs = append(runs, [2]int{from, m[0]}, [2]int{m[2], m[3]})
+		from = m[1]
+	}
+	return append(runs, [2]int{from, len(text)})
+}
+
+// isOldHeading reports whether line i is a heading in the syntax used
+// before "# " headings: a single capitalized line without punctuation,
+// set off by blank lines and followed by a paragraph. The rules match
+// go/doc/comment.
+func isOldHeading(lines []line, i int) bool {
+	if i <= 0 || !blank(lines[i-1]) || i+2 >= len(lines) || !blank(lines[i+1]) ||
+		blank(lines[i+2]) || indented(lines[i+2]) {
+		return false
+	}
+	text := strings.TrimSpace(lines[i].text)
+	first, _ := utf8.DecodeRuneInString(text)
+	if !unicode.IsLetter(first) || !unicode.IsUpper(first) {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(text)
+	if !unicode.IsLetter(last) && !unicode.IsDigit(last) {
+		return false
+	}
+	if strings.ContainsAny(text, ";:!?+*/=[]{}_^°&§~%#@<\">\\") {
+		return false
+	}
+	// Allow "'" only for the possessive "'s"
+	for rest, ok := text, true; ; {
+		if _, rest, ok = strings.Cut(rest, "'"); !ok {
+			break
+		}
+		if rest != "s" && !strings.HasPrefix(rest, "s ") {
+			return false
+		}
+	}
+	// Allow "." only inside words, as in "go.dev"
+	for rest, ok := text, true; ; {
+		if _, rest, ok = strings.Cut(rest, "."); !ok {
+			break
+		}
+		if rest == "" || strings.HasPrefix(rest, " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// linkDefinitions reports whether every line of a span defines a link
+// target. Such spans are not shown.
+func linkDefinitions(lines []line) bool {
+	for _, l := range lines {
+		if !linkDefinition.MatchString(l.text) {
+			return false
+		}
+	}
+	return true
+}
+
+// identifiers returns the names that doc comments in a file mention as
+// code: the package name, the names of imported packages, and the
+// exported identifiers declared or used in the file.
+func identifiers(f *ast.File) map[string]bool {
+	idents := map[string]bool{f.Name.Name: true}
+	for _, imp := range f.Imports {
+		if imp.Name != nil {
+			idents[imp.Name.Name] = true
+			continue
+		}
+		importPath := strings.Trim(imp.Path.Value, "`\"")
+		idents[path.Base(majorVersion.ReplaceAllString(importPath, ""))] = true
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.IsExported() {
+			idents[id.Name] = true
+		}
+		return true
+	})
+	return idents
+}
+
+// receiverType returns the type name of a method receiver.
+func receiverType(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// blank reports whether a line holds only whitespace.
+func blank(l line) bool {
+	return strings.TrimSpace(l.text) == ""
+}
+
+// indented reports whether a line starts with a space or tab.
+func indented(l line) bool {
+	return l.text != "" && (l.text[0] == ' ' || l.text[0] == '\t')
+}
@@ -0,0 +1,261 @@
+package godoc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantUnits []Unit
+		wantCode  []string
+	}{
+		{
+			name:    "package, type, func, and method",
+			content: "// Package store keeps records.\npackage store\n\n// Record is one entry.\ntype Record struct{}\n\n// Save writes a record.\nfunc Save() {}\n\n// Load reads it back.\nfunc (r *Record) Load() {}\n",
+			wantUnits: []Unit{
+				{Symbol: "package store", Line: 1, Prose: "Package store keeps records."},
+				{Symbol: "type Record", Line: 4, Prose: "Record is one entry."},
+				{Symbol: "func Save", Line: 7, Prose: "Save writes a record."},
+				{Symbol: "method Record.Load", Line: 10, Prose: "Load reads it back."},
+			},
+		},
+		{
+			name:    "unexported symbols are skipped",
+			content: "package p\n\n// record is internal.\ntype record struct{}\n\n// save is internal.\nfunc save() {}\n\n// Load is on an unexported type.\nfunc (r record) Load() {}\n\nvar _ = 1\n",
+		},
+		{
+			name:    "grouped types and generic receivers",
+			content: "package p\n\n// Pair holds two values.\ntype Pair[T any] struct{}\n\ntype (\n\t// Left is the first.\n\tLeft int\n\tRight int\n)\n\n// First returns the first value.\nfunc (p Pair[T]) First() {}\n",
+			wantUnits: []Unit{
+				{Symbol: "type Pair", Line: 3, Prose: "Pair holds two values."},
+				{Symbol: "type Left", Line: 7, Prose: "Left is the first."},
+				{Symbol: "method Pair.First", Line: 12, Prose: "First returns the first value."},
+			},
+		},
+		{
+			name:    "directives are not documentation",
+			content: "package p\n\n//go:generate stringer -type=Mode\n\n// Mode is a mode.\n//\n//nolint:revive\ntype Mode int\n\n//go:noinline\nfunc Run() {}\n",
+			wantUnits: []Unit{
+				{Symbol: "type Mode", Line: 5, Prose: "Mode is a mode."},
+			},
+		},
+		{
+			name:    "code blocks and lists are not prose",
+			content: "package p\n\n// Run starts the job:\n//\n//\tjob := New()\n//\tjob.Run()\n//\n// Options:\n//   - Fast skips checks.\n//   - Slow runs them.\n//\n// It returns when done.\nfunc Run() {}\n",
+			wantUnits: []Unit{
+				{Symbol: "func Run", Line: 3, Prose: "Run starts the job: Options: It returns when done."},
+			},
+			wantCode: []string{"job := New()\njob.Run()\n"},
+		},
+		{
+			name:    "doc links keep their text",
+			content: "package p\n\n// Copy is like [io.Copy] but uses a [*Buffer], see [the spec].\n//\n// [the spec]: https://go.dev/ref/spec\nfunc Copy() {}\n",
+			wantUnits: []Unit{
+				{Symbol: "func Copy", Line: 3, Prose: "Copy is like io.Copy but uses a *Buffer, see the spec."},
+			},
+		},
+		{
+			name:    "block comments",
+			content: "package p\n\n/*\n   Wait blocks until\n   the queue is empty.\n*/\nfunc Wait() {}\n",
+			wantUnits: []Unit{
+				{Symbol: "func Wait", Line: 3, Prose: "Wait blocks until the queue is empty."},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, units, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if tt.wantUnits == nil {
+				tt.wantUnits = []Unit{}
+			}
+			if !reflect.DeepEqual(units, tt.wantUnits) {
+				t.Errorf("units = %+v, want %+v", units, tt.wantUnits)
+			}
+			if tt.wantCode == nil {
+				tt.wantCode = []string{}
+			}
+			if !reflect.DeepEqual(result.CodeBlocks, tt.wantCode) {
+				t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, _, err := Parse([]byte("# Not Go\n")); err == nil {
+		t.Error("Parse() error = nil, want an error")
+	}
+}
+
+func TestParse_Headings(t *testing.T) {
+	content := strings.Join([]string{
+		"// Package guide shows headings.", // 1
+		"//",                               // 2
+		"// # Setup",                       // 3
+		"//",                               // 4
+		"//\tgo get example.com/guide",     // 5
+		"//",                               // 6
+		"// Old Style Heading",             // 7
+		"//",                               // 8
+		"// Text after it.",                // 9
+		"//",                               // 10
+		"// Not a heading.",                // 11
+		"//",                               // 12
+		"// More text.",                    // 13
+		"package guide",
+	}, "\n")
+
+	result, _, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Heading{
+		{Line: 3, Level: 3, Text: "Setup", Next: markdown.BlockCode},
+		{Line: 7, Level: 3, Text: "Old Style Heading", Next: markdown.BlockParagraph},
+	}
+	if !reflect.DeepEqual(result.Headings, want) {
+		t.Errorf("Headings = %+v, want %+v", result.Headings, want)
+	}
+}
+
+func TestParse_LineComposition(t *testing.T) {
+	content := strings.Join([]string{
+		"// Package p counts lines.",          // 1 prose
+		"//",                                  // 2 empty
+		"//   - One item.",                    // 3 list
+		"//",                                  // 4 empty
+		"// Run it:",                          // 5 prose
+		"//",                                  // 6 empty
+		"//\tcode()",                          // 7 code
+		"//",                                  // 8 empty
+		"// See [the docs].",                  // 9 prose
+		"//",                                  // 10 empty
+		"// [the docs]: https://example.com/", // 11 link definition
+		"package p",
+		"",
+		"func helper() {}",
+	}, "\n")
+
+	result, _, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := [...]int{result.TotalLines, result.EmptyLines, result.ListLines, result.CodeLines, result.HTMLLines}
+	want := [...]int{11, 5, 1, 1, 1}
+	if got != want {
+		t.Errorf("Total, Empty, List, Code, HTML lines = %v, want %v", got, want)
+	}
+	if len(result.Paragraphs) != 3 || result.Paragraphs[2].Line != 9 {
+		t.Errorf("Paragraphs = %+v, want three with the last on line 9", result.Paragraphs)
+	}
+}
+
+func TestParse_Segments(t *testing.T) {
+	content := "// Package p is a café\n// for [Reader] types.\n//\n//   - Item here\npackage p\n"
+
+	result, _, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Segment{
+		{Text: "Package", Line: 1, Column: 4, Offset: 3, Kind: markdown.SegmentProse},
+		{Text: "is a café", Line: 1, Column: 14, Offset: 13, Kind: markdown.SegmentProse},
+		{Text: "for", Line: 2, Column: 4, Offset: 27, Kind: markdown.SegmentProse},
+		{Text: "Reader", Line: 2, Column: 9, Offset: 32, Kind: markdown.SegmentProse},
+		{Text: "types.", Line: 2, Column: 17, Offset: 40, Kind: markdown.SegmentProse},
+		{Text: "Item here", Line: 4, Column: 8, Offset: 57, Kind: markdown.SegmentList},
+	}
+	if !reflect.DeepEqual(result.Segments, want) {
+		t.Errorf("Segments =\n%+v\nwant\n%+v", result.Segments, want)
+	}
+}
+
+func TestParse_IdentifiersNotSegments(t *testing.T) {
+	content := `// Package asciidoc reads pages for godoc.
+package asciidoc
+
+import (
+	"strings"
+
+	yml "gopkg.in/yaml.v3"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ReadingTime sets the pace. Unlike strings.Builder, yml, and jsonschema,
+// its readingTime field is private.
+type ReadingTime struct {
+	readingTime int
+	text        strings.Builder
+}
+`
+
+	result, _, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var got []string
+	for _, s := range result.Segments {
+		got = append(got, s.Text)
+	}
+	want := []string{"Package", "reads pages for godoc.", "sets the pace. Unlike", ".", ",", ", and", ",", "its readingTime field is private."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Segments = %q, want %q", got, want)
+	}
+	if !strings.Contains(result.Prose, "Package asciidoc reads") || !strings.Contains(result.Prose, "ReadingTime sets") {
+		t.Errorf("Prose = %q, want identifiers kept", result.Prose)
+	}
+}
+
+func TestIsOldHeading(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Setup Guide", true},
+		{"Using go.dev", true},
+		{"The Reader's Role", true},
+		{"lowercase start", false},
+		{"Ends with a period.", false},
+		{"Has: punctuation", false},
+		{"Quotes 'here'", false},
+	}
+
+	for _, tt := range tests {
+		lines := []line{{}, {text: tt.text}, {}, {text: "Paragraph."}}
+		if got := isOldHeading(lines, 1); got != tt.want {
+			t.Errorf("isOldHeading(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("// Package p does things.\n//\n// # Heading\n//\n//\tcode()\npackage p\n"))
+	f.Add([]byte("package p\n\n// T is [io.Reader].\n//   - item\ntype T int\n"))
+	f.Add([]byte("package p\n\n/*\n\tF runs.\n*/\nfunc F() {}\n"))
+	f.Add([]byte("package p\n\n// M is a method.\nfunc (t *T[K]) M() {}\n"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		result, _, err := Parse(content)
+		if err != nil {
+			return
+		}
+		for _, s := range result.Segments {
+			if s.Offset < 0 || s.Offset+len(s.Text) > len(content) {
+				t.Fatalf("segment %+v out of range for %d bytes", s, len(content))
+			}
+			if string(content[s.Offset:s.Offset+len(s.Text)]) != s.Text {
+				t.Fatalf("segment %+v does not match content", s)
+			}
+		}
+	})
+}
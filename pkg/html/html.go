@@ -0,0 +1,595 @@
+// Package html parses HTML documents into the same shape as
+// markdown.Parse, so hand-written and generated HTML pages get the same
+// rules and thresholds as Markdown.
+package html
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	xhtml "golang.org/x/net/html"
+
+	"github.com/adaptive-enforcement-lab/readability/internal/linekind"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// FrontmatterMeta is the Frontmatter format for the <title> and <meta>
+// tags in the document head.
+const FrontmatterMeta = "meta"
+
+// DefaultAdmonitionClasses are the CSS classes that mark admonition
+// containers when Options.AdmonitionClasses is empty. They cover MkDocs,
+// Sphinx, and Bootstrap output.
+var DefaultAdmonitionClasses = []string{
+	"admonition", "note", "tip", "hint", "important", "warning", "caution",
+	"danger", "attention", "error", "callout", "alert",
+}
+
+// Options configures how HTML is read.
+type Options struct {
+	AdmonitionClasses []string // CSS classes that mark admonitions; DefaultAdmonitionClasses when empty
+}
+
+// skipped are the elements whose content is never text: scripts, styles,
+// navigation, and embedded markup.
+var skipped = map[string]bool{
+	"script": true, "style": true, "nav": true, "template": true,
+	"noscript": true, "svg": true, "math": true, "iframe": true,
+	"object": true, "select": true, "button": true,
+}
+
+// blocks are the elements that start a new paragraph.
+var blocks = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"body": true, "caption": true, "dd": true, "details": true, "dialog": true,
+	"div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true,
+	"h3": true, "h4": true, "h5": true, "h6": true, "head": true, "header": true,
+	"hr": true, "html": true, "li": true, "main": true, "ol": true, "p": true,
+	"pre": true, "section": true, "summary": true, "table": true, "tbody": true,
+	"td": true, "tfoot": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// inlineCode are the inline elements that hold code.
+var inlineCode = map[string]bool{
+	"code": true, "kbd": true, "samp": true, "var": true, "tt": true,
+}
+
+// closedBy lists, for elements with optional end tags, the start tags that
+// close them.
+var closedBy = map[string]map[string]bool{
+	"p":  blocks,
+	"li": {"li": true},
+	"dt": {"dt": true, "dd": true},
+	"dd": {"dt": true, "dd": true},
+	"tr": {"tr": true, "tbody": true, "tfoot": true},
+	"td": {"td": true, "th": true, "tr": true, "tbody": true, "tfoot": true},
+	"th": {"td": true, "th": true, "tr": true, "tbody": true, "tfoot": true},
+}
+
+// entity matches a character reference.
+var entity = regexp.MustCompile(`^&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[A-Za-z][A-Za-z0-9]*);?`)
+
+// element is an open element.
+type element struct {
+	tag        string
+	admonition bool // Counted as an admonition
+	title      bool // Holds the title of the enclosing admonition
+}
+
+// parser walks the tokens of a document and fills a ParseResult.
+type parser struct {
+	content     []byte
+	lineStarts  []int // Byte offset of each line
+	kinds       []linekind.Kind
+	admonitions map[string]bool
+	result      *markdown.ParseResult
+
+	stack     []element
+	prose     strings.Builder
+	paragraph strings.Builder // Text of the open paragraph, including inline code
+	paraLine  int             // Line of the open paragraph (0-based), or -1
+	paraKind  linekind.Kind   // Kind of the open paragraph
+	code      strings.Builder // Text of the open <pre>
+	heading   *markdown.Heading
+	title     strings.Builder // Title text of the innermost open admonition
+	titled    bool            // The innermost open admonition has a title element
+	pending   []int           // Headings waiting for the next block
+}
+
+// Parse extracts prose content, code blocks, and headings from HTML.
+func Parse(content []byte, opts Options) (*markdown.ParseResult, error) {
+	classes := opts.AdmonitionClasses
+	if len(classes) == 0 {
+		classes = DefaultAdmonitionClasses
+	}
+	p := &parser{
+		content:     content,
+		lineStarts:  []int{0},
+		admonitions: make(map[string]bool, len(classes)),
+		paraLine:    -1,
+		result: &markdown.ParseResult{
+			CodeBlocks:  make([]string, 0),
+			Headings:    make([]markdown.Heading, 0),
+			Admonitions: make([]markdown.Admonition, 0),
+			Segments:    make([]markdown.Segment, 0),
+			Paragraphs:  make([]markdown.Paragraph, 0),
+		},
+	}
+	for _, class := range classes {
+		p.admonitions[strings.ToLower(class)] = true
+	}
+	for i, b := range content {
+		if b == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+	p.kinds = make([]linekind.Kind, len(p.lineStarts))
+
+	z := xhtml.NewTokenizer(bytes.NewReader(content))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			if z.Err() != io.EOF {
+				return nil, z.Err()
+			}
+			break
+		}
+		raw := len(z.Raw())
+		switch tt {
+		case xhtml.TextToken:
+			p.text(offset, offset+raw)
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			p.startTag(z, tt, offset, offset+raw)
+		case xhtml.EndTagToken:
+			name, _ := z.TagName()
+			p.endTag(string(name), offset, offset+raw)
+		default: // Comments and doctypes
+			p.mark(offset, offset+raw, linekind.HTML)
+		}
+		offset += raw
+	}
+	p.flush()
+	for len(p.stack) > 0 {
+		p.pop()
+	}
+
+	linekind.Count(p.result, p.kinds)
+	p.result.Prose = strings.Join(strings.Fields(p.prose.String()), " ")
+	return p.result, nil
+}
+
+// startTag handles a start tag in the bytes [start, end).
+func (p *parser) startTag(z *xhtml.Tokenizer, tt xhtml.TokenType, start, end int) {
+	name, hasAttr := z.TagName()
+	tag := string(name)
+	attrs := make(map[string]string)
+	for hasAttr {
+		var key, value []byte
+		key, value, hasAttr = z.TagAttr()
+		attrs[string(key)] = string(value)
+	}
+
+	// Close elements whose end tag may be left out
+	for len(p.stack) > 0 {
+		top := p.stack[len(p.stack)-1].tag
+		if !closedBy[top][tag] {
+			break
+		}
+		p.pop()
+	}
+	if blocks[tag] || tag == "br" {
+		p.flush()
+	}
+	p.mark(start, end, p.tagKind(tag))
+
+	switch tag {
+	case "img":
+		if !p.skipping() {
+			p.result.Images++
+		}
+	case "br":
+		p.paragraph.WriteString(" ")
+		p.prose.WriteString(" ")
+	case "hr":
+		p.block(markdown.BlockBreak)
+	case "meta":
+		if p.inside("body") == 0 {
+			key := attrs["name"]
+			if key == "" {
+				key = attrs["property"]
+			}
+			if key != "" {
+				p.field(strings.ToLower(key), attrs["content"], start, end)
+			}
+		}
+	}
+	if tt == xhtml.SelfClosingTagToken || isVoid(tag) {
+		return
+	}
+
+	e := element{tag: tag}
+	if !p.skipping() {
+		e.admonition = p.admonition(tag, attrs, start)
+		e.title = !e.admonition && !p.titled && p.inside("admonition") > 0 &&
+			(tag == "summary" || hasClass(attrs, "admonition-title") || hasClass(attrs, "title"))
+		if e.title {
+			p.titled = true
+			p.title.Reset()
+		}
+	}
+	p.stack = append(p.stack, e)
+	p.mark(start, end, p.tagKind(tag))
+
+	switch {
+	case p.skipping() || p.inside("admonition") > 0:
+	case strings.HasPrefix(tag, "h") && len(tag) == 2 && tag[1] >= '1' && tag[1] <= '6':
+		if p.inside("ul", "ol", "dl", "table", "pre") == 0 {
+			p.heading = &markdown.Heading{Line: p.line(start) + 1, Level: int(tag[1] - '0')}
+		}
+	case tag == "pre":
+		p.code.Reset()
+	}
+}
+
+// endTag handles an end tag in the bytes [start, end).
+func (p *parser) endTag(tag string, start, end int) {
+	p.mark(start, end, p.tagKind(tag))
+	if p.inside(tag) == 0 {
+		return // Stray end tag
+	}
+	if blocks[tag] {
+		p.flush()
+	}
+	for len(p.stack) > 0 {
+		top := p.stack[len(p.stack)-1].tag
+		p.pop()
+		if top == tag {
+			break
+		}
+	}
+}
+
+// pop closes the innermost open element.
+func (p *parser) pop() {
+	e := p.stack[len(p.stack)-1]
+	if blocks[e.tag] {
+		p.flush()
+	}
+	p.stack = p.stack[:len(p.stack)-1]
+	if p.skipping() {
+		return
+	}
+
+	switch {
+	case e.title:
+		adm := &p.result.Admonitions[len(p.result.Admonitions)-1]
+		adm.Title = strings.Join(strings.Fields(p.title.String()), " ")
+	case e.admonition:
+		if p.inside("admonition") == 0 {
+			p.block(markdown.BlockAdmonition)
+		}
+	case p.inside("admonition") > 0:
+	case p.heading != nil && strings.HasPrefix(e.tag, "h") && len(e.tag) == 2:
+		p.heading.Text = strings.Join(strings.Fields(p.heading.Text), " ")
+		p.result.Headings = append(p.result.Headings, *p.heading)
+		p.heading = nil
+		p.block(markdown.BlockHeading)
+	case e.tag == "pre" && p.inside("pre") == 0:
+		code := strings.TrimPrefix(p.code.String(), "\n")
+		if code != "" && !strings.HasSuffix(code, "\n") {
+			code += "\n"
+		}
+		p.result.CodeBlocks = append(p.result.CodeBlocks, code)
+		p.block(markdown.BlockCode)
+	case e.tag == "table" && p.inside("table") == 0:
+		p.result.Tables++
+		p.block(markdown.BlockTable)
+	case (e.tag == "ul" || e.tag == "ol" || e.tag == "dl") && p.inside("ul", "ol", "dl", "table") == 0:
+		p.block(markdown.BlockList)
+	case e.tag == "blockquote" && p.inside("blockquote", "ul", "ol", "dl", "table") == 0:
+		p.block(markdown.BlockQuote)
+	}
+}
+
+// text handles a text token in the bytes [start, end).
+func (p *parser) text(start, end int) {
+	if p.skipping() {
+		p.mark(start, end, linekind.HTML)
+		return
+	}
+	raw := string(p.content[start:end])
+	if p.inside("title") > 0 && p.inside("body") == 0 {
+		p.field("title", xhtml.UnescapeString(raw), start, end)
+		return
+	}
+	decoded := xhtml.UnescapeString(raw)
+
+	switch {
+	case p.inside("pre") > 0:
+		p.code.WriteString(decoded)
+		p.mark(start, end, linekind.Code)
+		p.markBlank(start, end, linekind.Code)
+		return
+	case p.inside("admonition") > 0:
+		if p.insideTitle() {
+			p.title.WriteString(decoded)
+		}
+		p.mark(start, end, linekind.Admonition)
+		return
+	}
+
+	kind, segment := p.context()
+	code := false
+	for _, e := range p.stack {
+		code = code || inlineCode[e.tag]
+	}
+	if p.heading != nil {
+		p.heading.Text += decoded
+	} else {
+		if p.paraLine < 0 && strings.TrimSpace(decoded) != "" {
+			p.paraLine = p.line(start + len(raw) - len(strings.TrimLeft(raw, " \t\r\n")))
+			p.paraKind = kind
+		}
+		p.paragraph.WriteString(decoded)
+	}
+	if code {
+		if strings.TrimSpace(decoded) != "" {
+			p.mark(start, end, kind)
+		}
+		return
+	}
+	if kind == linekind.Prose && (p.heading != nil || p.inside("ul", "ol", "dl", "table") == 0) {
+		p.prose.WriteString(decoded)
+	}
+
+	// One segment per line and entity-free run, so segments match the
+	// source bytes
+	for _, r := range p.runs(start, end) {
+		piece := string(p.content[r[0]:r[1]])
+		trimmed := strings.TrimSpace(piece)
+		if trimmed == "" {
+			continue
+		}
+		at := r[0] + strings.Index(piece, trimmed)
+		line := p.line(at)
+		p.mark(at, at+len(trimmed), kind)
+		p.result.Segments = append(p.result.Segments, markdown.Segment{
+			Text:   trimmed,
+			Line:   line + 1,
+			Column: utf8.RuneCount(p.content[p.lineStarts[line]:at]) + 1,
+			Offset: at,
+			Kind:   segment,
+		})
+	}
+}
+
+// runs splits the text in [start, end) at line breaks and character
+// references, returning the byte ranges between them.
+func (p *parser) runs(start, end int) [][2]int {
+	var runs [][2]int
+	from := start
+	for i := start; i < end; i++ {
+		switch p.content[i] {
+		case '\n':
+			runs = append(runs, [2]int{from, i})
+			from = i + 1
+		case '&':
+			ref := entity.Find(p.content[i:end])
+			if ref == nil || xhtml.UnescapeString(string(ref)) == string(ref) {
+				continue
+			}
+			runs = append(runs, [2]int{from, i})
+			i += len(ref) - 1
+			from = i + 1
+		}
+	}
+	return append(runs, [2]int{from, end})
+}
+
+// context returns the line and segment kind of text at the current
+// position.
+func (p *parser) context() (linekind.Kind, markdown.SegmentKind) {
+	switch {
+	case p.heading != nil:
+		return linekind.Prose, markdown.SegmentHeading
+	case p.inside("table") > 0:
+		return linekind.Table, markdown.SegmentTable
+	case p.inside("ul", "ol", "dl") > 0:
+		return linekind.List, markdown.SegmentList
+	}
+	return linekind.Prose, markdown.SegmentProse
+}
+
+// tagKind returns the kind of a line holding the given tag.
+func (p *parser) tagKind(tag string) linekind.Kind {
+	switch {
+	case p.skipping() || skipped[tag]:
+		return linekind.HTML
+	case p.inside("pre") > 0 || tag == "pre":
+		return linekind.Code
+	case p.inside("admonition") > 0:
+		return linekind.Admonition
+	case p.inside("table") > 0 || tag == "table":
+		return linekind.Table
+	case p.inside("ul", "ol", "dl") > 0 || tag == "ul" || tag == "ol" || tag == "dl":
+		return linekind.List
+	}
+	return linekind.HTML
+}
+
+// admonition reports whether an element is an admonition container and
+// records it. The type is the first other class, so class="admonition
+// warning" is a warning.
+func (p *parser) admonition(tag string, attrs map[string]string, start int) bool {
+	classes := strings.Fields(strings.ToLower(attrs["class"]))
+	matched := ""
+	for _, class := range classes {
+		if p.admonitions[class] {
+			matched = class
+			break
+		}
+	}
+	if matched == "" {
+		return false
+	}
+	kind := matched
+	for _, class := range classes {
+		if class != matched && class != "admonition" {
+			kind = class
+			break
+		}
+	}
+	p.flush()
+	p.result.Admonitions = append(p.result.Admonitions, markdown.Admonition{
+		Line:        p.line(start) + 1,
+		Type:        kind,
+		Collapsible: tag == "details",
+	})
+	p.titled = false
+	return true
+}
+
+// flush ends the open paragraph.
+func (p *parser) flush() {
+	text := strings.Join(strings.Fields(p.paragraph.String()), " ")
+	p.paragraph.Reset()
+	line := p.paraLine
+	p.paraLine = -1
+	p.prose.WriteString(" ")
+	if text == "" || line < 0 || p.paraKind != linekind.Prose {
+		return
+	}
+	p.result.Paragraphs = append(p.result.Paragraphs, markdown.Paragraph{Line: line + 1, Text: text})
+	if p.inside("blockquote") == 0 {
+		p.block(markdown.BlockParagraph)
+	}
+}
+
+// block records a block for the headings waiting for one. Blocks inside
+// lists, tables, and admonitions belong to those.
+func (p *parser) block(kind markdown.BlockKind) {
+	if kind != markdown.BlockHeading && p.inside("ul", "ol", "dl", "table", "admonition") > 0 {
+		return
+	}
+	for _, i := range p.pending {
+		p.result.Headings[i].Next = kind
+	}
+	p.pending = p.pending[:0]
+	if kind == markdown.BlockHeading {
+		p.pending = append(p.pending, len(p.result.Headings)-1)
+	}
+}
+
+// field records a frontmatter field from the document head.
+func (p *parser) field(name, value string, start, end int) {
+	fm := p.result.Frontmatter
+	if fm == nil {
+		fm = &markdown.Frontmatter{
+			Format: FrontmatterMeta,
+			Fields: make(map[string]any),
+			Lines:  make(map[string]int),
+		}
+		p.result.Frontmatter = fm
+	}
+	fm.Fields[name] = strings.Join(strings.Fields(value), " ")
+	fm.Lines[name] = p.line(start) + 1
+	p.mark(start, end, linekind.Frontmatter)
+}
+
+// inside returns how many open elements have one of the given tags. The
+// tag "admonition" counts admonition containers.
+func (p *parser) inside(tags ...string) int {
+	n := 0
+	for _, e := range p.stack {
+		for _, tag := range tags {
+			if e.tag == tag || (tag == "admonition" && e.admonition) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// insideTitle reports whether an admonition title element is open.
+func (p *parser) insideTitle() bool {
+	for _, e := range p.stack {
+		if e.title {
+			return true
+		}
+	}
+	return false
+}
+
+// skipping reports whether a skipped element is open.
+func (p *parser) skipping() bool {
+	for _, e := range p.stack {
+		if skipped[e.tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// line returns the 0-based line of byte offset.
+func (p *parser) line(offset int) int {
+	lo, hi := 0, len(p.lineStarts)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if p.lineStarts[mid] <= offset {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
+
+// mark raises the kind of the lines holding non-space bytes in [start, end).
+func (p *parser) mark(start, end int, kind linekind.Kind) {
+	for i := start; i < end; {
+		line := p.line(i)
+		next := end
+		if line+1 < len(p.lineStarts) {
+			next = min(end, p.lineStarts[line+1])
+		}
+		if len(bytes.TrimSpace(p.content[i:next])) > 0 && kind > p.kinds[line] {
+			p.kinds[line] = kind
+		}
+		i = next
+	}
+}
+
+// markBlank raises the kind of blank lines inside [start, end), such as
+// the empty lines of a code block.
+func (p *parser) markBlank(start, end int, kind linekind.Kind) {
+	for line := p.line(start) + 1; line < len(p.lineStarts) && p.lineStarts[line] < end; line++ {
+		if kind > p.kinds[line] {
+			p.kinds[line] = kind
+		}
+	}
+}
+
+// hasClass reports whether an element's class attribute holds class.
+func hasClass(attrs map[string]string, class string) bool {
+	for _, c := range strings.Fields(attrs["class"]) {
+		if strings.EqualFold(c, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// isVoid reports whether an element never has content or an end tag.
+func isVoid(tag string) bool {
+	switch tag {
+	case "area", "base", "br", "col", "embed", "hr", "img", "input", "link",
+		"meta", "param", "source", "track", "wbr":
+		return true
+	}
+	return false
+}
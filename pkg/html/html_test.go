@@ -0,0 +1,220 @@
+package html
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		opts      Options
+		wantProse string
+		wantCode  []string
+		wantAdm   []markdown.Admonition
+	}{
+		{
+			name:      "paragraphs",
+			content:   "<p>First line\nof a paragraph.</p>\n\n<p>Second paragraph.\n",
+			wantProse: "First line of a paragraph. Second paragraph.",
+		},
+		{
+			name:      "inline markup and entities",
+			content:   "<p>Use <b>bold</b>, <em>italic</em> and <code>code</code> with a <a href=\"/\">link</a> &amp; more.</p>\n",
+			wantProse: "Use bold, italic and with a link & more.",
+		},
+		{
+			name:      "script, style, and nav are skipped",
+			content:   "<nav><a href=\"/\">Home</a></nav>\n<style>p { color: red }</style>\n<p>Shown.</p>\n<script>var x = \"<p>\";</script>\n",
+			wantProse: "Shown.",
+		},
+		{
+			name:      "pre blocks",
+			content:   "<pre><code class=\"language-go\">\nfmt.Println()\n</code></pre>\n<pre>x := 1</pre>\n<p>Text.</p>\n",
+			wantProse: "Text.",
+			wantCode:  []string{"fmt.Println()\n", "x := 1\n"},
+		},
+		{
+			name: "admonitions",
+			content: "<div class=\"admonition warning\">\n<p class=\"admonition-title\">Careful</p>\n<p>Warning text.</p>\n</div>\n" +
+				"<details class=\"note\"><summary>More</summary><p>Hidden.</p></details>\n" +
+				"<aside class=\"callout\">A callout.</aside>\n<p>Text.</p>\n",
+			wantProse: "Text.",
+			wantAdm: []markdown.Admonition{
+				{Line: 1, Type: "warning", Title: "Careful"},
+				{Line: 5, Type: "note", Title: "More", Collapsible: true},
+				{Line: 6, Type: "callout"},
+			},
+		},
+		{
+			name:      "configured admonition classes",
+			content:   "<div class=\"note\">Not an admonition.</div>\n<div class=\"box info\">An info box.</div>\n",
+			opts:      Options{AdmonitionClasses: []string{"box"}},
+			wantProse: "Not an admonition.",
+			wantAdm:   []markdown.Admonition{{Line: 2, Type: "info"}},
+		},
+		{
+			name:      "lists and tables are not prose",
+			content:   "<ul>\n<li>One\n<li>Two\n</ul>\n<table><tr><td>Cell<td>Other</table>\n<p>Text.</p>\n",
+			wantProse: "Text.",
+		},
+		{
+			name:      "comments are dropped",
+			content:   "<!-- A comment. -->\n<p>Shown text.</p>\n",
+			wantProse: "Shown text.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content), tt.opts)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if result.Prose != tt.wantProse {
+				t.Errorf("Prose = %q, want %q", result.Prose, tt.wantProse)
+			}
+			if tt.wantCode != nil && !reflect.DeepEqual(result.CodeBlocks, tt.wantCode) {
+				t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, tt.wantCode)
+			}
+			if tt.wantAdm == nil {
+				tt.wantAdm = []markdown.Admonition{}
+			}
+			if !reflect.DeepEqual(result.Admonitions, tt.wantAdm) {
+				t.Errorf("Admonitions = %+v, want %+v", result.Admonitions, tt.wantAdm)
+			}
+		})
+	}
+}
+
+func TestParse_Headings(t *testing.T) {
+	content := "<h1>Guide</h1>\n<p>Intro.</p>\n<h2 id=\"setup\">Setup</h2>\n<ul><li>Step one</li></ul>\n" +
+		"<h3>Install <code>now</code></h3>\n<pre>code</pre>\n<h2>Done</h2>\n"
+
+	result, err := Parse([]byte(content), Options{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Heading{
+		{Line: 1, Level: 1, Text: "Guide", Next: markdown.BlockParagraph},
+		{Line: 3, Level: 2, Text: "Setup", Next: markdown.BlockList},
+		{Line: 5, Level: 3, Text: "Install now", Next: markdown.BlockCode},
+		{Line: 7, Level: 2, Text: "Done"},
+	}
+	if !reflect.DeepEqual(result.Headings, want) {
+		t.Errorf("Headings = %+v, want %+v", result.Headings, want)
+	}
+}
+
+func TestParse_Head(t *testing.T) {
+	content := "<html>\n<head>\n<title>Guide &amp; Notes</title>\n<meta name=\"description\" content=\"A short guide.\">\n" +
+		"<meta charset=\"utf-8\">\n</head>\n<body><p>Body text.</p></body>\n</html>\n"
+
+	result, err := Parse([]byte(content), Options{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.Prose != "Body text." {
+		t.Errorf("Prose = %q, want %q", result.Prose, "Body text.")
+	}
+	fm := result.Frontmatter
+	if fm == nil {
+		t.Fatal("Frontmatter = nil")
+	}
+	if fm.Format != FrontmatterMeta {
+		t.Errorf("Format = %q, want %q", fm.Format, FrontmatterMeta)
+	}
+	wantFields := map[string]any{"title": "Guide & Notes", "description": "A short guide."}
+	if !reflect.DeepEqual(fm.Fields, wantFields) {
+		t.Errorf("Fields = %v, want %v", fm.Fields, wantFields)
+	}
+	if fm.Lines["description"] != 4 {
+		t.Errorf("Lines[description] = %d, want 4", fm.Lines["description"])
+	}
+}
+
+func TestParse_LineComposition(t *testing.T) {
+	content := strings.Join([]string{
+		"<html>",                       // 1 html
+		"<title>Guide</title>",         // 2 frontmatter
+		"",                             // 3 empty
+		"<h1>Guide</h1>",               // 4 prose
+		"<p>Intro.</p>",                // 5 prose
+		"<!-- a comment -->",           // 6 html
+		"<ul>",                         // 7 list
+		"<li>One</li>",                 // 8 list
+		"</ul>",                        // 9 list
+		"<pre>",                        // 10 code
+		"code",                         // 11 code
+		"</pre>",                       // 12 code
+		"<div class=\"note\">",         // 13 admonition
+		"A note.",                      // 14 admonition
+		"</div>",                       // 15 admonition
+		"<table><tr><td>A<td>B",        // 16 table
+		"</table>",                     // 17 table
+		"<img src=\"a.png\" alt=\"\">", // 18 html
+	}, "\n")
+
+	result, err := Parse([]byte(content), Options{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := [...]int{result.TotalLines, result.EmptyLines, result.FrontmatterLines, result.HTMLLines,
+		result.ListLines, result.CodeLines, result.AdmonitionLines, result.TableLines}
+	want := [...]int{18, 1, 1, 3, 3, 3, 3, 2}
+	if got != want {
+		t.Errorf("Total, Empty, Frontmatter, HTML, List, Code, Admonition, Table lines = %v, want %v", got, want)
+	}
+	if result.Tables != 1 || result.Images != 1 {
+		t.Errorf("Tables, Images = %d, %d, want 1, 1", result.Tables, result.Images)
+	}
+}
+
+func TestParse_Segments(t *testing.T) {
+	content := "<h1>Café</h1>\n<p>See <em>the</em> docs\n&amp; notes.</p>\n<ul><li>Item <code>x</code> here</li></ul>\n<table><tr><td>Cell one</td></tr></table>\n"
+
+	result, err := Parse([]byte(content), Options{})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Segment{
+		{Text: "Café", Line: 1, Column: 5, Offset: 4, Kind: markdown.SegmentHeading},
+		{Text: "See", Line: 2, Column: 4, Offset: 18, Kind: markdown.SegmentProse},
+		{Text: "the", Line: 2, Column: 12, Offset: 26, Kind: markdown.SegmentProse},
+		{Text: "docs", Line: 2, Column: 21, Offset: 35, Kind: markdown.SegmentProse},
+		{Text: "notes.", Line: 3, Column: 7, Offset: 46, Kind: markdown.SegmentProse},
+		{Text: "Item", Line: 4, Column: 9, Offset: 65, Kind: markdown.SegmentList},
+		{Text: "here", Line: 4, Column: 29, Offset: 85, Kind: markdown.SegmentList},
+		{Text: "Cell one", Line: 5, Column: 16, Offset: 115, Kind: markdown.SegmentTable},
+	}
+	if !reflect.DeepEqual(result.Segments, want) {
+		t.Errorf("Segments =\n%+v\nwant\n%+v", result.Segments, want)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("<html><head><title>T</title></head><body><h1>Title</h1><p>Text &amp; more.</p></body></html>"))
+	f.Add([]byte("<pre><code>code\n</code></pre>"))
+	f.Add([]byte("<div class=\"admonition note\"><p class=\"admonition-title\">N</p><p>x</p></div>"))
+	f.Add([]byte("<table><tr><td>a<td>b</table><ul><li>x<li>y</ul>"))
+	f.Add([]byte("<p>unclosed <b>bold\n<script>x</script>"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		result, err := Parse(content, Options{})
+		if err != nil {
+			return
+		}
+		for _, s := range result.Segments {
+			if s.Offset < 0 || s.Offset+len(s.Text) > len(content) {
+				t.Fatalf("segment %+v out of range for %d bytes", s, len(content))
+			}
+			if string(content[s.Offset:s.Offset+len(s.Text)]) != s.Text {
+				t.Fatalf("segment %+v does not match content", s)
+			}
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// alertMarker matches the first line of a GitHub alert: > [!NOTE]
+var alertMarker = regexp.MustCompile(`^>\s*\[!([A-Za-z]+)\]\s*$`)
+
+// alertTypes lists the alert types GitHub renders. Other markers render as
+// plain blockquotes.
+var alertTypes = map[string]bool{
+	"note":      true,
+	"tip":       true,
+	"important": true,
+	"warning":   true,
+	"caution":   true,
+}
+
+// alert is a GitHub alert blockquote and its type.
+type alert struct {
+	lineRange
+	kind string
+}
+
+// alertType returns the lowercase type of the GitHub alert opened on a
+// trimmed line, or "".
+func alertType(trimmed []byte) string {
+	m := alertMarker.FindSubmatch(trimmed)
+	if m == nil {
+		return ""
+	}
+	kind := strings.ToLower(string(m[1]))
+	if !alertTypes[kind] {
+		return ""
+	}
+	return kind
+}
+
+// githubAlerts finds GitHub alert blockquotes outside fenced code. An alert
+// runs from its marker through the following quoted lines, including lazy
+// continuation lines that extend a quoted paragraph.
+func githubAlerts(lines [][]byte, fenced []bool) []alert {
+	var alerts []alert
+	i := 0
+	for i < len(lines) {
+		kind := alertType(bytes.TrimSpace(lines[i]))
+		if fenced[i] || kind == "" {
+			i++
+			continue
+		}
+
+		start := i
+		paragraph := false // Previous line continues a quoted paragraph
+		for i++; i < len(lines); i++ {
+			trimmed := bytes.TrimSpace(lines[i])
+			if len(trimmed) == 0 {
+				break
+			}
+			quoted := trimmed[0] == '>'
+			if !quoted && !paragraph {
+				break
+			}
+			paragraph = !quoted || len(bytes.TrimSpace(trimmed[1:])) > 0
+		}
+		alerts = append(alerts, alert{lineRange{start, i}, kind})
+	}
+	return alerts
+}
@@ -0,0 +1,302 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// lineKind is the kind of content on a source line.
+type lineKind int
+
+const (
+	lineProse lineKind = iota
+	lineEmpty
+	lineList
+	lineTable
+	lineHTML
+	lineCode
+	lineAdmonition
+	lineFrontmatter
+)
+
+// lineRange is a run of source lines [start, end), 0-based.
+type lineRange struct {
+	start, end int
+}
+
+// kindRange is a run of lines whose kind is known before parsing, such as
+// syntax that is blanked because goldmark does not understand it.
+type kindRange struct {
+	lineRange
+	kind lineKind
+}
+
+// frontmatterRange returns the lines of the frontmatter block, including
+// both delimiters. Returns false if there is no closed block.
+func frontmatterRange(lines [][]byte) (lineRange, bool) {
+	delimiter := bytes.TrimSpace(lines[0])
+	if !bytes.Equal(delimiter, []byte("---")) && !bytes.Equal(delimiter, []byte("+++")) {
+		return lineRange{}, false
+	}
+	for i := 1; i < len(lines); i++ {
+		if bytes.Equal(bytes.TrimSpace(lines[i]), delimiter) {
+			return lineRange{0, i + 1}, true
+		}
+	}
+	return lineRange{}, false
+}
+
+// fencedLines marks the lines inside fenced code blocks (``` or ~~~),
+// including the fences, found by scanning rather than parsing. It lets
+// admonition detection skip examples of admonition syntax in code.
+func fencedLines(lines [][]byte) []bool {
+	fenced := make([]bool, len(lines))
+	var fence []byte
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		switch {
+		case fence != nil:
+			fenced[i] = true
+			if bytes.HasPrefix(trimmed, fence) {
+				fence = nil
+			}
+		case fenceMarker(trimmed) != nil:
+			fenced[i] = true
+			fence = fenceMarker(trimmed)
+		}
+	}
+	return fenced
+}
+
+// emptyFences returns the fenced code blocks with no info string and no
+// content: an opening fence directly followed by its closing fence. The
+// parsed block records no position for them, so they are found by
+// scanning, in document order.
+func emptyFences(lines [][]byte) []lineRange {
+	var ranges []lineRange
+	var fence []byte
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		switch {
+		case fence != nil:
+			if bytes.HasPrefix(trimmed, fence) {
+				fence = nil
+			}
+		case fenceMarker(trimmed) != nil:
+			fence = fenceMarker(trimmed)
+			bare := len(bytes.Trim(trimmed, string(fence[:1]))) == 0
+			if bare && i+1 < len(lines) && bytes.HasPrefix(bytes.TrimSpace(lines[i+1]), fence) {
+				ranges = append(ranges, lineRange{i, i + 2})
+			}
+		}
+	}
+	return ranges
+}
+
+// admonitionRanges finds admonition blocks: a line starting with !!!, ???
+// or ???+ followed by blank lines or lines indented deeper than the marker,
+// pymdownx admonition or details blocks up to their closing fence, and
+// GitHub alert blockquotes. Ranges may nest.
+func admonitionRanges(lines [][]byte) []lineRange {
+	var ranges []lineRange
+	fenced := fencedLines(lines)
+	i := 0
+	for i < len(lines) {
+		if fenced[i] || admonitionMarker(bytes.TrimSpace(lines[i])) == "" {
+			i++
+			continue
+		}
+
+		start, indent := i, indentWidth(lines[i])
+		i++
+		for i < len(lines) {
+			next := lines[i]
+			if len(bytes.TrimSpace(next)) > 0 && indentWidth(next) <= indent {
+				break // A line at or left of the marker ends the admonition
+			}
+			i++
+		}
+		ranges = append(ranges, lineRange{start, i})
+	}
+
+	for _, b := range pymdownBlocks(lines, fenced) {
+		if b.admonition() {
+			ranges = append(ranges, lineRange{b.start, min(b.end+1, len(lines))})
+		}
+	}
+	for _, a := range githubAlerts(lines, fenced) {
+		ranges = append(ranges, a.lineRange)
+	}
+	return ranges
+}
+
+// fenceMarker returns the fence that opens a code block on this line
+// (``` or ~~~), or nil.
+func fenceMarker(trimmed []byte) []byte {
+	for _, marker := range [][]byte{[]byte("```"), []byte("~~~")} {
+		if bytes.HasPrefix(trimmed, marker) {
+			return marker
+		}
+	}
+	return nil
+}
+
+// classifyLines decides what each source line holds and stores the counts
+// in result. Code, tables, lists, and HTML come from the goldmark AST, so
+// the counts agree with the parsed document. Frontmatter and admonitions
+// are blanked before parsing and come from their line ranges, as do the
+// known ranges of other blanked syntax.
+//
+// Nested blocks take the kind of the innermost block: a code block inside
+// a list counts as code. Blank lines are empty unless they are inside a
+// code block. All remaining lines (paragraphs, headings, quotes) are prose.
+func classifyLines(doc ast.Node, content []byte, known []kindRange, result *ParseResult) {
+	lines := bytes.Split(content, []byte("\n"))
+	kinds := make([]lineKind, len(lines))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			kinds[i] = lineEmpty
+		}
+	}
+
+	fixed := func(r lineRange, kind lineKind) {
+		for i := r.start; i < r.end; i++ {
+			if kinds[i] != lineEmpty {
+				kinds[i] = kind
+			}
+		}
+	}
+	if r, ok := frontmatterRange(lines); ok {
+		fixed(r, lineFrontmatter)
+	}
+	for _, r := range admonitionRanges(lines) {
+		fixed(r, lineAdmonition)
+	}
+	for _, r := range known {
+		fixed(r.lineRange, r.kind)
+	}
+
+	index := newLineIndex(content)
+	empty := emptyFences(lines)
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		var kind lineKind
+		switch node.(type) {
+		case *ast.List:
+			kind = lineList
+		case *extast.Table:
+			kind = lineTable
+		case *ast.HTMLBlock:
+			kind = lineHTML
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			kind = lineCode
+		default:
+			return ast.WalkContinue, nil
+		}
+
+		r, ok := blockRange(node, index, lines, &empty)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		for i := r.start; i < r.end && i < len(kinds); i++ {
+			switch {
+			case kinds[i] == lineFrontmatter || kinds[i] == lineAdmonition:
+			case kinds[i] == lineEmpty && kind != lineCode:
+			default:
+				kinds[i] = kind
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	result.TotalLines = len(lines)
+	for _, kind := range kinds {
+		switch kind {
+		case lineEmpty:
+			result.EmptyLines++
+		case lineList:
+			result.ListLines++
+		case lineTable:
+			result.TableLines++
+		case lineHTML:
+			result.HTMLLines++
+		case lineCode:
+			result.CodeLines++
+		case lineAdmonition:
+			result.AdmonitionLines++
+		case lineFrontmatter:
+			result.FrontmatterLines++
+		}
+	}
+}
+
+// blockRange returns the source lines a block spans, from the first to the
+// last line any of its descendants records. Fenced code blocks are widened
+// to include their fences, and HTML blocks their closing line. A fenced
+// block with no content and no info string records no lines at all, so it
+// takes the next range from empty, the bare fence pairs not yet matched.
+func blockRange(node ast.Node, index lineIndex, lines [][]byte, empty *[]lineRange) (lineRange, bool) {
+	first, last := -1, -1
+	add := func(start, stop int) {
+		// stop is one past the last byte; a trailing newline still
+		// belongs to the line it ends
+		from, to := index.line(start)-1, index.line(stop-1)-1
+		if first < 0 || from < first {
+			first = from
+		}
+		if to > last {
+			last = to
+		}
+	}
+
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if t, ok := n.(*ast.Text); ok && t.Segment.Len() > 0 {
+			add(t.Segment.Start, t.Segment.Stop)
+		}
+		if n.Type() == ast.TypeBlock {
+			segments := n.Lines()
+			for i := 0; i < segments.Len(); i++ {
+				if s := segments.At(i); s.Len() > 0 {
+					add(s.Start, s.Stop)
+				}
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	switch n := node.(type) {
+	case *ast.FencedCodeBlock:
+		switch {
+		case first >= 0:
+			first-- // Opening fence
+		case n.Info != nil:
+			first = index.line(n.Info.Segment.Start) - 1
+			last = first
+		case len(*empty) > 0:
+			r := (*empty)[0]
+			*empty = (*empty)[1:]
+			return r, true
+		default:
+			return lineRange{}, false
+		}
+		if last+1 < len(lines) && fenceMarker(bytes.TrimSpace(lines[last+1])) != nil {
+			last++ // Closing fence
+		}
+	case *ast.HTMLBlock:
+		if n.HasClosure() {
+			add(n.ClosureLine.Start, n.ClosureLine.Stop)
+		}
+	}
+
+	if first < 0 {
+		return lineRange{}, false
+	}
+	return lineRange{first, last + 1}, true
+}
@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Frontmatter formats.
+const (
+	FrontmatterYAML = "yaml" // Enclosed in --- lines
+	FrontmatterTOML = "toml" // Enclosed in +++ lines
+)
+
+// Frontmatter is the metadata block at the start of a page.
+type Frontmatter struct {
+	Format string         // FrontmatterYAML or FrontmatterTOML
+	Fields map[string]any // Top-level keys and their decoded values
+	Lines  map[string]int // Line number (1-based) of each top-level key
+	Err    error          // Set when the block is not valid YAML or TOML; Fields is then empty
+}
+
+// tomlKeyPattern matches a top-level TOML key assignment or table header.
+var tomlKeyPattern = regexp.MustCompile(`^\s*(?:\[\s*)?["']?([A-Za-z0-9_.-]+?)["']?\s*(?:=|\])`)
+
+// extractFrontmatter parses the frontmatter block, or returns nil if the
+// content does not start with one.
+func extractFrontmatter(content []byte) *Frontmatter {
+	lines := bytes.Split(content, []byte("\n"))
+	delimiter := string(bytes.TrimSpace(lines[0]))
+	var format string
+	switch delimiter {
+	case "---":
+		format = FrontmatterYAML
+	case "+++":
+		format = FrontmatterTOML
+	default:
+		return nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if string(bytes.TrimSpace(lines[i])) == delimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil // Unclosed, treated as content like blankFrontmatter does
+	}
+
+	body := bytes.Join(lines[1:end], []byte("\n"))
+	fm := &Frontmatter{
+		Format: format,
+		Fields: make(map[string]any),
+		Lines:  make(map[string]int),
+	}
+	if format == FrontmatterYAML {
+		fm.Err = parseYAMLFrontmatter(body, fm)
+	} else {
+		fm.Err = parseTOMLFrontmatter(body, fm)
+	}
+	if fm.Err != nil {
+		fm.Fields = make(map[string]any)
+	}
+	return fm
+}
+
+// parseYAMLFrontmatter decodes a YAML mapping and records each key's line.
+func parseYAMLFrontmatter(body []byte, fm *Frontmatter) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return fmt.Errorf("invalid YAML frontmatter: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil // Empty block
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid YAML frontmatter: expected key-value pairs")
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		var decoded any
+		if err := value.Decode(&decoded); err != nil {
+			return fmt.Errorf("invalid YAML frontmatter: %w", err)
+		}
+		fm.Fields[key.Value] = decoded
+		fm.Lines[key.Value] = key.Line + 1 // Body starts on line 2
+	}
+	return nil
+}
+
+// parseTOMLFrontmatter decodes a TOML document and finds each top-level key's
+// line by scanning for its assignment or table header.
+func parseTOMLFrontmatter(body []byte, fm *Frontmatter) error {
+	if _, err := toml.Decode(string(body), &fm.Fields); err != nil {
+		return fmt.Errorf("invalid TOML frontmatter: %w", err)
+	}
+	inTable := false
+	for i, line := range strings.Split(string(body), "\n") {
+		m := tomlKeyPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		isHeader := strings.HasPrefix(strings.TrimSpace(line), "[")
+		if inTable && !isHeader {
+			continue // Key inside a table, not top-level
+		}
+		inTable = inTable || isHeader
+		key := m[1]
+		if isHeader {
+			key = strings.SplitN(key, ".", 2)[0]
+		}
+		if _, seen := fm.Lines[key]; !seen {
+			fm.Lines[key] = i + 2 // Body starts on line 2
+		}
+	}
+	return nil
+}
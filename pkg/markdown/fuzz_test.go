@@ -167,3 +167,75 @@ func FuzzParseAdmonition(f *testing.F) {
 		_ = result
 	})
 }
+
+// FuzzParseMDX tests the ParseMDX function with arbitrary MDX input.
+// JSX tags and expressions may be unclosed or span lines.
+func FuzzParseMDX(f *testing.F) {
+	seeds := []string{
+		"import Tabs from '@theme/Tabs';\n\n<Tabs>\n  Text.\n</Tabs>",
+		"<Button onClick={() => go('>')} />",
+		"{/* it's a comment */}",
+		":::note\nBody.\n:::",
+		"::::info\n:::tip\n",
+		"<",
+		"{",
+		"`<Tabs>",
+		"<Tabs\n  value=\"a\"\n>",
+		"\\<Tabs>",
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		result, err := ParseMDX(data)
+		if err != nil {
+			return
+		}
+		if result.TotalLines < 1 {
+			t.Errorf("TotalLines = %d, want >= 1", result.TotalLines)
+		}
+		for i, s := range result.Segments {
+			if s.Offset < 0 || s.Offset+len(s.Text) > len(data) {
+				t.Errorf("Segment[%d] Offset %d out of range", i, s.Offset)
+			}
+		}
+	})
+}
+
+// FuzzParseWithOptions tests template stripping with arbitrary input.
+// Tags may be unclosed, nested, or span lines.
+func FuzzParseWithOptions(f *testing.F) {
+	seeds := []string{
+		"{{< notice >}}\nText.\n{{< /notice >}}",
+		"{% if x %}a{% else %}b{% endif %}",
+		"{% raw %}{{ x }}{% endraw",
+		"{# comment\n\n#}",
+		"{{",
+		"`{{ x }}` {{ y }}",
+		"```\n{{ x }}\n```\n{{ y }}",
+		"{{< x />}}{{% /x %}}",
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+	opts := Options{Templates: TemplateSyntax{
+		Profiles:   []string{TemplateHugo, TemplateJinja, TemplateLiquid},
+		Delimiters: []Delimiters{{Open: "<%", Close: "%>"}},
+	}}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		result, err := ParseWithOptions(data, opts)
+		if err != nil {
+			t.Fatalf("ParseWithOptions() error = %v", err)
+		}
+		if result.TotalLines < 1 {
+			t.Errorf("TotalLines = %d, want >= 1", result.TotalLines)
+		}
+		for i, s := range result.Segments {
+			if s.Offset < 0 || s.Offset+len(s.Text) > len(data) {
+				t.Errorf("Segment[%d] Offset %d out of range", i, s.Offset)
+			}
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Material for MkDocs wraps content in containers that plain Markdown does
+// not know about. Admonitions (!!!), collapsible details (??? and ???+),
+// and pymdownx admonition or details blocks are excluded from prose.
+// Content tabs (=== "Title") and other pymdownx blocks (/// tab, /// html)
+// only wrap ordinary content, so their syntax lines are blanked and tab
+// content is dedented before parsing.
+
+// tabIndent is the indentation of content inside a content tab.
+const tabIndent = 4
+
+var (
+	// blockHeader matches a pymdownx block header: /// name | title
+	blockHeader = regexp.MustCompile(`^(/{3,})\s*([A-Za-z][\w-]*)\s*(?:\|\s*(.*))?$`)
+
+	// blockOption matches a "key: value" option line after a block header.
+	blockOption = regexp.MustCompile(`^\s*([\w-]+)\s*:\s*(.*)$`)
+)
+
+// admonitionBlocks lists the pymdownx blocks that render as admonitions
+// or details, so they count as admonitions rather than content.
+var admonitionBlocks = map[string]bool{
+	"admonition": true,
+	"details":    true,
+	"note":       true,
+	"attention":  true,
+	"caution":    true,
+	"danger":     true,
+	"error":      true,
+	"tip":        true,
+	"hint":       true,
+	"warning":    true,
+	"important":  true,
+}
+
+// admonitionMarker returns the marker that opens an admonition on a
+// trimmed line: !!! for a plain block, ??? or ???+ for a collapsible one.
+// Returns "" for other lines.
+func admonitionMarker(trimmed []byte) string {
+	for _, marker := range []string{"!!!", "???+", "???"} {
+		if bytes.HasPrefix(trimmed, []byte(marker)) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// isTabMarker reports whether a trimmed line opens a content tab:
+// === "Title", ===! "Title" or ===+ "Title".
+func isTabMarker(trimmed []byte) bool {
+	rest, ok := bytes.CutPrefix(trimmed, []byte("==="))
+	if !ok {
+		return false
+	}
+	rest = bytes.TrimLeft(rest, "!+")
+	return len(rest) > 1 && (rest[0] == ' ' || rest[0] == '\t') &&
+		bytes.HasPrefix(bytes.TrimSpace(rest), []byte(`"`))
+}
+
+// indentWidth returns the width of a line's leading whitespace, counting
+// a tab as four columns.
+func indentWidth(line []byte) int {
+	width := 0
+	for _, b := range line {
+		switch b {
+		case ' ':
+			width++
+		case '\t':
+			width += tabIndent
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// pymdownBlock is a pymdownx block: a /// name | title header, optional
+// indented options, content, and a closing fence with the same number of
+// slashes.
+type pymdownBlock struct {
+	start   int // Header line (0-based)
+	body    int // First line after the options
+	end     int // Closing fence line, or len(lines) if the block is unclosed
+	name    string
+	title   string
+	options map[string]string
+}
+
+// admonition reports whether the block renders as an admonition or details.
+func (b pymdownBlock) admonition() bool {
+	return admonitionBlocks[b.name]
+}
+
+// pymdownBlocks finds pymdownx blocks outside fenced code. Nested blocks
+// use more slashes on the outer block, so a closing fence closes the
+// innermost open block with the same number of slashes.
+func pymdownBlocks(lines [][]byte, fenced []bool) []pymdownBlock {
+	type open struct {
+		block   pymdownBlock
+		slashes int
+	}
+	var blocks []pymdownBlock
+	var stack []open
+
+	for i := 0; i < len(lines); i++ {
+		if fenced[i] {
+			continue
+		}
+		trimmed := bytes.TrimSpace(lines[i])
+
+		if len(trimmed) >= 3 && len(bytes.TrimLeft(trimmed, "/")) == 0 {
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].slashes == len(trimmed) {
+					stack[j].block.end = i
+					blocks = append(blocks, stack[j].block)
+					stack = append(stack[:j], stack[j+1:]...)
+					break
+				}
+			}
+			continue
+		}
+
+		m := blockHeader.FindSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		block := pymdownBlock{
+			start:   i,
+			name:    strings.ToLower(string(m[2])),
+			title:   strings.TrimSpace(string(m[3])),
+			options: make(map[string]string),
+		}
+		// Options are indented key: value lines right after the header
+		for i+1 < len(lines) && indentWidth(lines[i+1]) >= tabIndent && len(bytes.TrimSpace(lines[i+1])) > 0 {
+			i++
+			if opt := blockOption.FindSubmatch(lines[i]); opt != nil {
+				block.options[string(opt[1])] = strings.TrimSpace(string(opt[2]))
+			}
+		}
+		block.body = i + 1
+		stack = append(stack, open{block: block, slashes: len(m[1])})
+	}
+
+	// An unclosed block runs to the end of the document
+	for _, o := range stack {
+		o.block.end = len(lines)
+		blocks = append(blocks, o.block)
+	}
+	return blocks
+}
+
+// blockAdmonition converts a pymdownx admonition or details block to an
+// Admonition. The type comes from the type option when set.
+func blockAdmonition(b pymdownBlock) Admonition {
+	adm := Admonition{
+		Line:        b.start + 1,
+		Type:        b.name,
+		Title:       strings.Trim(b.title, `"`),
+		Collapsible: b.name == "details",
+	}
+	if t := b.options["type"]; t != "" {
+		adm.Type = t
+	}
+	return adm
+}
+
+// unwrapContainers blanks the syntax lines of content tabs and of pymdownx
+// blocks that wrap ordinary content, and dedents tab content so goldmark
+// does not read it as an indented code block. Dedenting moves the leading
+// whitespace to the end of the line, which keeps line numbers and line
+// lengths. The returned shifts hold the bytes moved on each line, so
+// positions can be mapped back to the original file.
+func unwrapContainers(content []byte) ([]byte, []int) {
+	lines := bytes.Split(bytes.Clone(content), []byte("\n"))
+	fenced := fencedLines(lines)
+	shifts := make([]int, len(lines))
+
+	for _, b := range pymdownBlocks(lines, fenced) {
+		if b.admonition() {
+			continue // Blanked with the other admonitions
+		}
+		for i := b.start; i < b.body; i++ {
+			blank(lines[i])
+		}
+		if b.end < len(lines) {
+			blank(lines[b.end])
+		}
+	}
+
+	var tabs []int // Indent of each open tab marker, innermost last
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) > 0 {
+			indent := indentWidth(line)
+			for len(tabs) > 0 && indent <= tabs[len(tabs)-1] {
+				tabs = tabs[:len(tabs)-1]
+			}
+		}
+		if !fenced[i] && isTabMarker(trimmed) {
+			tabs = append(tabs, indentWidth(line))
+			blank(line)
+			continue
+		}
+		if len(tabs) > 0 && len(trimmed) > 0 {
+			shifts[i] = dedent(line, tabIndent*len(tabs))
+		}
+	}
+
+	return bytes.Join(lines, []byte("\n")), shifts
+}
+
+// blank replaces every byte of a line with a space.
+func blank(line []byte) {
+	for i := range line {
+		line[i] = ' '
+	}
+}
+
+// dedent removes up to width columns of leading whitespace from a line in
+// place, padding the end with spaces so the length is unchanged. A
+// trailing carriage return stays last. Returns the number of bytes moved.
+func dedent(line []byte, width int) int {
+	n, columns := 0, 0
+	for n < len(line) && columns < width && (line[n] == ' ' || line[n] == '\t') {
+		if line[n] == '\t' {
+			columns += tabIndent
+		} else {
+			columns++
+		}
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+
+	end := len(line)
+	if line[end-1] == '\r' {
+		end--
+	}
+	copy(line, line[n:end])
+	for i := end - n; i < end; i++ {
+		line[i] = ' '
+	}
+	return n
+}
@@ -0,0 +1,321 @@
+package markdown
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/util"
+)
+
+// MDX is Markdown with JSX, as used by Docusaurus. ESM statements (import
+// and export), JSX tags, and {expressions} are blanked before parsing, so
+// the text children of components stay in the prose. Docusaurus :::type
+// blocks are admonitions. MDX has no indented code blocks, since JSX
+// children are often indented.
+
+var (
+	// esmStatement matches the first line of an import or export statement.
+	esmStatement = regexp.MustCompile(`^(import|export)[\s{*]`)
+
+	// directiveHeader matches the opening line of a Docusaurus admonition:
+	// :::note, :::tip Title or :::info[Title]
+	directiveHeader = regexp.MustCompile(`^(:{3,})\s*([A-Za-z][\w-]*)\s*(.*)$`)
+)
+
+// mdxSyntax holds what stripMDX found, for admonition and line counts.
+type mdxSyntax struct {
+	admonitions []Admonition
+	known       []kindRange // ESM, admonition, and JSX-only lines
+}
+
+// ParseMDX extracts prose content, code blocks, and headings from MDX.
+func ParseMDX(content []byte) (*ParseResult, error) {
+	return parse(content, true, Options{})
+}
+
+// mdxMarkdown returns a goldmark instance without the indented code block
+// parser, matching how MDX reads indentation. Paragraphs open on indented
+// lines instead.
+func mdxMarkdown() goldmark.Markdown {
+	codeBlock := reflect.TypeOf(parser.NewCodeBlockParser())
+	paragraph := reflect.TypeOf(parser.NewParagraphParser())
+	var blocks []util.PrioritizedValue
+	for _, v := range parser.DefaultBlockParsers() {
+		switch reflect.TypeOf(v.Value) {
+		case codeBlock:
+			continue
+		case paragraph:
+			v.Value = indentedParagraphParser{parser.NewParagraphParser()}
+		}
+		blocks = append(blocks, v)
+	}
+	p := parser.NewParser(
+		parser.WithBlockParsers(blocks...),
+		parser.WithInlineParsers(parser.DefaultInlineParsers()...),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+	)
+	return goldmark.New(goldmark.WithParser(p), goldmark.WithExtensions(extension.GFM))
+}
+
+// indentedParagraphParser is a paragraph parser that also opens on lines
+// indented four or more spaces.
+type indentedParagraphParser struct {
+	parser.BlockParser
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser.
+func (indentedParagraphParser) CanAcceptIndentedLine() bool {
+	return true
+}
+
+// stripMDX blanks Docusaurus admonitions, ESM statements, JSX tags, and
+// expressions. Newlines are kept so line numbers and byte offsets stay
+// unchanged.
+func stripMDX(content []byte) ([]byte, mdxSyntax) {
+	var syntax mdxSyntax
+	lines := bytes.Split(bytes.Clone(content), []byte("\n"))
+	fenced := fencedLines(lines)
+
+	for _, d := range directiveBlocks(lines, fenced) {
+		syntax.admonitions = append(syntax.admonitions, d.admonition)
+		syntax.known = append(syntax.known, kindRange{d.lineRange, lineAdmonition})
+		for i := d.start; i < d.end; i++ {
+			blank(lines[i])
+		}
+	}
+
+	for _, r := range esmRanges(lines, fenced) {
+		syntax.known = append(syntax.known, kindRange{r, lineCode})
+		for i := r.start; i < r.end; i++ {
+			blank(lines[i])
+		}
+	}
+
+	for i, touched := range blankJSX(lines, fenced) {
+		if touched && len(bytes.TrimSpace(lines[i])) == 0 {
+			syntax.known = append(syntax.known, kindRange{lineRange{i, i + 1}, lineHTML})
+		}
+	}
+
+	return bytes.Join(lines, []byte("\n")), syntax
+}
+
+// directive is a Docusaurus admonition block and its line range, including
+// the closing fence.
+type directive struct {
+	lineRange
+	admonition Admonition
+}
+
+// directiveBlocks finds :::type blocks outside fenced code. Nested blocks
+// use more colons on the outer block, so a closing fence closes the
+// innermost open block with the same number of colons.
+func directiveBlocks(lines [][]byte, fenced []bool) []directive {
+	type open struct {
+		block  directive
+		colons int
+	}
+	var blocks []directive
+	var stack []open
+
+	for i, line := range lines {
+		if fenced[i] {
+			continue
+		}
+		trimmed := bytes.TrimSpace(line)
+
+		if len(trimmed) >= 3 && len(bytes.TrimLeft(trimmed, ":")) == 0 {
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].colons == len(trimmed) {
+					stack[j].block.end = i + 1
+					blocks = append(blocks, stack[j].block)
+					stack = append(stack[:j], stack[j+1:]...)
+					break
+				}
+			}
+			continue
+		}
+
+		m := directiveHeader.FindSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		title := strings.TrimSpace(string(m[3]))
+		if strings.HasPrefix(title, "[") {
+			title, _, _ = strings.Cut(title[1:], "]")
+		}
+		stack = append(stack, open{
+			block: directive{
+				lineRange: lineRange{start: i},
+				admonition: Admonition{
+					Line:  i + 1,
+					Type:  strings.ToLower(string(m[2])),
+					Title: title,
+				},
+			},
+			colons: len(m[1]),
+		})
+	}
+
+	// An unclosed block runs to the end of the document
+	for _, o := range stack {
+		o.block.end = len(lines)
+		blocks = append(blocks, o.block)
+	}
+	return blocks
+}
+
+// esmRanges finds import and export statements. A statement starts at the
+// beginning of a line outside a paragraph and runs to the next blank line.
+func esmRanges(lines [][]byte, fenced []bool) []lineRange {
+	var ranges []lineRange
+	i := 0
+	for i < len(lines) {
+		afterBlank := i == 0 || len(bytes.TrimSpace(lines[i-1])) == 0
+		if fenced[i] || !afterBlank || !esmStatement.Match(lines[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(lines) && len(bytes.TrimSpace(lines[i])) > 0 {
+			i++
+		}
+		ranges = append(ranges, lineRange{start, i})
+	}
+	return ranges
+}
+
+// blankJSX blanks JSX tags and {expressions} outside fenced code and
+// inline code spans, keeping the text between tags. Tags may span lines.
+// Returns the lines that held a tag or expression.
+func blankJSX(lines [][]byte, fenced []bool) []bool {
+	touched := make([]bool, len(lines))
+
+	// Scan the lines as one stream so tags can span line breaks
+	type pos struct{ line, col int }
+	var stream []pos
+	for i, line := range lines {
+		if fenced[i] {
+			continue
+		}
+		for j := range line {
+			stream = append(stream, pos{i, j})
+		}
+		stream = append(stream, pos{i, len(line)}) // Line break
+	}
+	at := func(k int) byte {
+		p := stream[k]
+		if p.col == len(lines[p.line]) {
+			return '\n'
+		}
+		return lines[p.line][p.col]
+	}
+
+	for k := 0; k < len(stream); k++ {
+		switch c := at(k); {
+		case c == '\\':
+			k++ // Escaped character
+		case c == '`':
+			k = skipCodeSpan(k, len(stream), at)
+		case c == '<' && k+1 < len(stream) && isTagStart(at(k+1)),
+			c == '{':
+			end := closingDelimiter(k, len(stream), at)
+			if end < 0 {
+				continue
+			}
+			for ; k <= end; k++ {
+				p := stream[k]
+				if p.col < len(lines[p.line]) {
+					lines[p.line][p.col] = ' '
+					touched[p.line] = true
+				}
+			}
+			k = end
+		}
+	}
+	return touched
+}
+
+// isTagStart reports whether a byte after < opens a JSX tag: a name, a
+// closing slash, or the > of a fragment.
+func isTagStart(c byte) bool {
+	return c == '/' || c == '>' || c == '_' || c == '$' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// skipCodeSpan returns the index of the last backtick of the code span
+// opening at k, or of the opening backtick run if the span is unclosed.
+func skipCodeSpan(k, n int, at func(int) byte) int {
+	run := 0
+	for k+run < n && at(k+run) == '`' {
+		run++
+	}
+	for j := k + run; j < n; {
+		if at(j) != '`' {
+			j++
+			continue
+		}
+		closing := 0
+		for j+closing < n && at(j+closing) == '`' {
+			closing++
+		}
+		if closing == run {
+			return j + closing - 1
+		}
+		j += closing
+	}
+	return k + run - 1
+}
+
+// closingDelimiter returns the index of the > that closes the tag opening
+// at k, or of the } that closes the expression opening at k. Quoted
+// strings, comments, and nested braces are skipped. The search stops at a
+// blank line. Returns -1 if there is none.
+func closingDelimiter(k, n int, at func(int) byte) int {
+	depth := 0
+	var quote byte
+	comment := false
+	blankLine := false // No text since the last line break
+	for j := k; j < n; j++ {
+		c := at(j)
+		if c == '\n' {
+			if blankLine {
+				return -1
+			}
+			blankLine = true
+		} else if c != ' ' && c != '\t' {
+			blankLine = false
+		}
+		switch {
+		case comment:
+			if c == '*' && j+1 < n && at(j+1) == '/' {
+				comment = false
+				j++
+			}
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '/' && j+1 < n && at(j+1) == '*':
+			comment = true
+			j++
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 && at(k) == '{' {
+				return j
+			}
+		case c == '>' && depth == 0 && at(k) == '<':
+			return j
+		}
+	}
+	return -1
+}
@@ -2,7 +2,9 @@ package markdown
 
 import (
 	"bytes"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
@@ -13,20 +15,65 @@ import (
 
 // ParseResult contains extracted content from a markdown file.
 type ParseResult struct {
-	Prose       string
-	CodeBlocks  []string
-	Headings    []Heading
-	Admonitions []Admonition
-	TotalLines  int
-	CodeLines   int
-	EmptyLines  int
+	Prose            string
+	CodeBlocks       []string
+	Headings         []Heading
+	Admonitions      []Admonition
+	TotalLines       int
+	CodeLines        int // Fenced and indented code blocks, including fences
+	EmptyLines       int
+	TableLines       int
+	ListLines        int
+	HTMLLines        int
+	AdmonitionLines  int // Admonition title and body lines
+	FrontmatterLines int // Frontmatter block, including delimiters
+	Images           int
+	Tables           int
+	Segments         []Segment    // Text outside code, in document order
+	Paragraphs       []Paragraph  // Paragraph blocks, including those in lists and blockquotes
+	Frontmatter      *Frontmatter // Page metadata, nil when the page has none
 }
 
-// Admonition represents a MkDocs-style admonition block.
+// Paragraph is a paragraph block and its text, with inline markup removed.
+// Inline code is kept since readers read it as part of the paragraph.
+type Paragraph struct {
+	Line int // Line number (1-based) of the first line
+	Text string
+}
+
+// Segment is a run of text from a single source line outside code.
+// Rules that report a position use segments instead of the flattened Prose.
+type Segment struct {
+	Text   string
+	Line   int         // Line number (1-based)
+	Column int         // Column of the first character (1-based, in characters)
+	Offset int         // Byte offset of Text in the original file
+	Kind   SegmentKind // Block the text belongs to
+}
+
+// Position returns the line and column of byte i in the segment text.
+// Segment text never spans lines, so only the column changes.
+func (s Segment) Position(i int) (line, column int) {
+	i = min(max(i, 0), len(s.Text))
+	return s.Line, s.Column + utf8.RuneCountInString(s.Text[:i])
+}
+
+// SegmentKind identifies the kind of block a segment belongs to.
+type SegmentKind string
+
+const (
+	SegmentProse   SegmentKind = "prose"   // Paragraphs and other running text
+	SegmentHeading SegmentKind = "heading" // Heading text
+	SegmentList    SegmentKind = "list"    // List item text
+	SegmentTable   SegmentKind = "table"   // Table cell text
+)
+
+// Admonition represents a MkDocs-style admonition block or a GitHub alert.
 type Admonition struct {
-	Line  int    // Line number (1-based)
-	Type  string // note, warning, tip, etc.
-	Title string // optional custom title
+	Line        int    // Line number (1-based)
+	Type        string // note, warning, tip, etc.
+	Title       string // optional custom title
+	Collapsible bool   // ??? or ???+ details block
 }
 
 // Heading represents a markdown heading.
@@ -34,17 +81,62 @@ type Heading struct {
 	Line  int // Line number (1-based)
 	Level int
 	Text  string
+	Next  BlockKind // Block right after the heading, "" at the end of the document
 }
 
+// BlockKind identifies the kind of a top-level block.
+type BlockKind string
+
+const (
+	BlockParagraph  BlockKind = "paragraph"
+	BlockHeading    BlockKind = "heading"
+	BlockList       BlockKind = "list"
+	BlockCode       BlockKind = "code"
+	BlockTable      BlockKind = "table"
+	BlockAdmonition BlockKind = "admonition"
+	BlockQuote      BlockKind = "blockquote"
+	BlockHTML       BlockKind = "html"
+	BlockBreak      BlockKind = "break" // Thematic break (---)
+)
+
 // Parse extracts prose content, code blocks, and headings from markdown.
 func Parse(content []byte) (*ParseResult, error) {
-	// Strip frontmatter and admonition blocks before parsing to exclude them from prose
-	cleanedContent := stripFrontmatter(content)
-	cleanedContent = stripAdmonitions(cleanedContent)
+	return parse(content, false, Options{})
+}
+
+// ParseWithOptions is Parse with template tags blanked as opts selects.
+// It returns an error for an unknown template profile.
+func ParseWithOptions(content []byte, opts Options) (*ParseResult, error) {
+	return parse(content, false, opts)
+}
+
+// parse extracts prose and structure from markdown, or from MDX when mdx
+// is set.
+func parse(content []byte, mdx bool, opts Options) (*ParseResult, error) {
+	// Blank out frontmatter and admonition blocks before parsing to exclude them from prose.
+	// Blanking keeps byte offsets, so AST positions match the original content.
+	cleanedContent := blankFrontmatter(content)
+	cleanedContent, templates, templateShifts, err := stripTemplates(cleanedContent, opts.Templates)
+	if err != nil {
+		return nil, err
+	}
+	var syntax mdxSyntax
+	if mdx {
+		cleanedContent, syntax = stripMDX(cleanedContent)
+	}
+	syntax.known = append(syntax.known, templates...)
+	cleanedContent = blankAdmonitions(cleanedContent)
+	cleanedContent, shifts := unwrapContainers(cleanedContent)
+	for i, n := range templateShifts {
+		shifts[i] += n
+	}
 
 	md := goldmark.New(
 		goldmark.WithExtensions(extension.GFM), // Enable GitHub Flavored Markdown (includes tables)
 	)
+	if mdx {
+		md = mdxMarkdown()
+	}
 	reader := text.NewReader(cleanedContent)
 	doc := md.Parser().Parse(reader)
 
@@ -52,14 +144,20 @@ func Parse(content []byte) (*ParseResult, error) {
 		CodeBlocks:  make([]string, 0),
 		Headings:    make([]Heading, 0),
 		Admonitions: make([]Admonition, 0),
+		Frontmatter: extractFrontmatter(content),
 	}
 
 	prose := extractAST(doc, cleanedContent, result)
+	result.Segments = extractSegments(doc, cleanedContent)
+	shiftSegments(result.Segments, shifts)
+	result.Paragraphs = extractParagraphs(doc, cleanedContent)
 	// Normalize whitespace: collapse multiple spaces to single space
 	prose = strings.Join(strings.Fields(prose), " ")
 	result.Prose = strings.TrimSpace(prose)
 
-	countLines(content, result)
+	extractAdmonitions(content, syntax.admonitions, result)
+	classifyLines(doc, content, syntax.known, result)
+	markFollowingBlocks(doc, cleanedContent, result)
 
 	return result, nil
 }
@@ -80,6 +178,10 @@ func extractAST(doc ast.Node, content []byte, result *ParseResult) string {
 			result.CodeBlocks = append(result.CodeBlocks, extractCodeBlock(n, content))
 		case *ast.CodeBlock:
 			result.CodeBlocks = append(result.CodeBlocks, extractCodeBlock(n, content))
+		case *ast.Image:
+			result.Images++
+		case *extast.Table:
+			result.Tables++
 		case *ast.Text:
 			extractText(n, content, &proseBuilder)
 		case *ast.String:
@@ -92,6 +194,194 @@ func extractAST(doc ast.Node, content []byte, result *ParseResult) string {
 	return proseBuilder.String()
 }
 
+// markFollowingBlocks records the kind of block that follows each heading.
+// Admonitions are blanked before parsing, so an admonition between a heading
+// and its next AST sibling is found by line number.
+func markFollowingBlocks(doc ast.Node, content []byte, result *ParseResult) {
+	index := newLineIndex(content)
+	i := 0
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		if i < len(result.Headings) {
+			result.Headings[i].Next = followingBlock(heading, index, result)
+		}
+		i++
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// followingBlock returns the kind of block after a heading.
+func followingBlock(heading *ast.Heading, index lineIndex, result *ParseResult) BlockKind {
+	headingLine := blockLine(heading, index)
+	next := heading.NextSibling()
+	nextLine := result.TotalLines + 1
+	if next != nil {
+		nextLine = blockLine(next, index)
+	}
+	for _, adm := range result.Admonitions {
+		if adm.Line > headingLine && adm.Line < nextLine {
+			return BlockAdmonition
+		}
+	}
+
+	switch next.(type) {
+	case nil:
+		return ""
+	case *ast.Paragraph, *ast.TextBlock:
+		return BlockParagraph
+	case *ast.Heading:
+		return BlockHeading
+	case *ast.List:
+		return BlockList
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		return BlockCode
+	case *extast.Table:
+		return BlockTable
+	case *ast.Blockquote:
+		return BlockQuote
+	case *ast.HTMLBlock:
+		return BlockHTML
+	case *ast.ThematicBreak:
+		return BlockBreak
+	}
+	return BlockParagraph
+}
+
+// blockLine returns the first source line of a block, found through its
+// first descendant that records line segments. Containers such as lists
+// and tables do not record lines themselves.
+func blockLine(node ast.Node, index lineIndex) int {
+	for n := node; n != nil; n = n.FirstChild() {
+		if n.Type() == ast.TypeBlock && n.Lines().Len() > 0 {
+			return index.line(n.Lines().At(0).Start)
+		}
+	}
+	return 0
+}
+
+// extractSegments collects text nodes outside code with their source
+// position. Adjacent text nodes that goldmark splits apart are merged into
+// one segment. Blanking keeps byte offsets, so positions in content match
+// the original file.
+func extractSegments(doc ast.Node, content []byte) []Segment {
+	index := newLineIndex(content)
+	segments := make([]Segment, 0)
+	lastStop := -1
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		n, ok := node.(*ast.Text)
+		if !entering || !ok || n.Segment.Len() == 0 || isInsideCodeBlock(n.Parent()) {
+			return ast.WalkContinue, nil
+		}
+
+		value := string(n.Segment.Value(content))
+		if n.Segment.Start == lastStop && len(segments) > 0 {
+			segments[len(segments)-1].Text += value
+		} else {
+			line := index.line(n.Segment.Start)
+			segments = append(segments, Segment{
+				Text:   value,
+				Line:   line,
+				Column: utf8.RuneCount(content[index[line-1]:n.Segment.Start]) + 1,
+				Offset: n.Segment.Start,
+				Kind:   segmentKind(n.Parent()),
+			})
+		}
+		lastStop = n.Segment.Stop
+		return ast.WalkContinue, nil
+	})
+
+	return segments
+}
+
+// shiftSegments maps segment positions on dedented lines back to the
+// original file. shifts holds the bytes moved on each line.
+func shiftSegments(segments []Segment, shifts []int) {
+	for i := range segments {
+		if n := shifts[segments[i].Line-1]; n > 0 {
+			segments[i].Column += n
+			segments[i].Offset += n
+		}
+	}
+}
+
+// extractParagraphs collects paragraph blocks with their starting line.
+func extractParagraphs(doc ast.Node, content []byte) []Paragraph {
+	index := newLineIndex(content)
+	paragraphs := make([]Paragraph, 0)
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		n, ok := node.(*ast.Paragraph)
+		if !entering || !ok || n.Lines().Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+		paragraphs = append(paragraphs, Paragraph{
+			Line: index.line(n.Lines().At(0).Start),
+			Text: paragraphText(n, content),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+
+	return paragraphs
+}
+
+// paragraphText joins the text of all inline nodes in a paragraph.
+// Line breaks become spaces.
+func paragraphText(n ast.Node, content []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := node.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(content))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// segmentKind returns the kind of block that contains the node.
+func segmentKind(node ast.Node) SegmentKind {
+	switch {
+	case isInsideTable(node):
+		return SegmentTable
+	case isInsideList(node):
+		return SegmentList
+	case isInsideHeading(node):
+		return SegmentHeading
+	}
+	return SegmentProse
+}
+
+// lineIndex maps byte offsets to 1-based line numbers.
+type lineIndex []int
+
+// newLineIndex records the offset at which each line of content starts.
+func newLineIndex(content []byte) lineIndex {
+	starts := lineIndex{0}
+	for i, b := range content {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// line returns the 1-based line number containing offset.
+func (idx lineIndex) line(offset int) int {
+	return sort.Search(len(idx), func(i int) bool { return idx[i] > offset })
+}
+
 // extractHeading extracts a heading from an AST node.
 func extractHeading(n *ast.Heading, content []byte) Heading {
 	line := 1
@@ -141,122 +431,102 @@ func extractString(n *ast.String, builder *strings.Builder) {
 	builder.WriteString(" ")
 }
 
-// stripFrontmatter removes YAML (---) or TOML (+++) frontmatter from content.
+// blankFrontmatter replaces YAML (---) or TOML (+++) frontmatter with spaces.
 // Frontmatter is metadata at the start of a file enclosed in delimiters.
-func stripFrontmatter(content []byte) []byte {
-	lines := bytes.Split(content, []byte("\n"))
-
-	// Check if file starts with frontmatter delimiter
-	firstLine := bytes.TrimSpace(lines[0])
-	if !bytes.Equal(firstLine, []byte("---")) && !bytes.Equal(firstLine, []byte("+++")) {
-		return content // No frontmatter
+// Newlines are kept so line numbers and byte offsets stay unchanged.
+func blankFrontmatter(content []byte) []byte {
+	r, ok := frontmatterRange(bytes.Split(content, []byte("\n")))
+	if !ok {
+		return content // No frontmatter, or no closing delimiter
 	}
-
-	delimiter := firstLine
-
-	// Find closing delimiter (must match opening)
-	for i := 1; i < len(lines); i++ {
-		if bytes.Equal(bytes.TrimSpace(lines[i]), delimiter) {
-			// Found closing delimiter, return everything after it
-			if i+1 < len(lines) {
-				return bytes.Join(lines[i+1:], []byte("\n"))
-			}
-			return []byte{}
-		}
-	}
-
-	// No closing delimiter found, return original content
-	return content
+	return blankLines(content, []lineRange{r})
 }
 
-// stripAdmonitions removes MkDocs-style admonition blocks from content.
+// blankAdmonitions replaces MkDocs-style admonition blocks with spaces.
 // Admonitions are lines starting with !!! followed by indented content.
-func stripAdmonitions(content []byte) []byte {
-	lines := bytes.Split(content, []byte("\n"))
-	var result [][]byte
-	i := 0
-
-	for i < len(lines) {
-		line := lines[i]
-		trimmed := bytes.TrimSpace(line)
+// Newlines are kept so line numbers and byte offsets stay unchanged.
+func blankAdmonitions(content []byte) []byte {
+	ranges := admonitionRanges(bytes.Split(content, []byte("\n")))
+	if len(ranges) == 0 {
+		return content
+	}
+	return blankLines(content, ranges)
+}
 
-		// Check if this is an admonition start
-		if bytes.HasPrefix(trimmed, []byte("!!!")) {
-			// Skip the !!! line
-			i++
-			// Skip all following indented lines (admonition content)
-			for i < len(lines) {
-				nextLine := lines[i]
-				// If line is indented (starts with spaces/tabs), it's admonition content
-				if len(nextLine) > 0 && (nextLine[0] == ' ' || nextLine[0] == '\t') {
-					i++
-					continue
-				}
-				// If line is empty, could be part of admonition or end of it
-				if len(bytes.TrimSpace(nextLine)) == 0 {
-					i++
-					continue
-				}
-				// Non-indented, non-empty line - end of admonition
-				break
+// blankLines returns a copy of content with every byte on the given line
+// ranges (0-based, end exclusive) replaced by a space, except newlines.
+// Ranges may overlap or nest. The copy is made once and blanked in place.
+func blankLines(content []byte, ranges []lineRange) []byte {
+	blanked := bytes.Clone(content)
+	lines := bytes.Split(blanked, []byte("\n")) // Slices share blanked's bytes
+
+	// Count the ranges open on each line, so each line is blanked once
+	open := make([]int, len(lines)+1)
+	for _, r := range ranges {
+		start, end := max(r.start, 0), min(r.end, len(lines))
+		if start < end {
+			open[start]++
+			open[end]--
+		}
+	}
+	depth := 0
+	for i, line := range lines {
+		depth += open[i]
+		if depth > 0 {
+			for j := range line {
+				line[j] = ' '
 			}
-			continue
 		}
-
-		result = append(result, line)
-		i++
 	}
-
-	return bytes.Join(result, []byte("\n"))
+	return blanked
 }
 
-// countLines counts total, code, and empty lines, and detects admonitions.
-func countLines(content []byte, result *ParseResult) {
+// extractAdmonitions detects MkDocs-style admonitions, including nested
+// ones, outside fenced code: !!! type or !!! type "title", collapsible
+// ??? and ???+ blocks, pymdownx admonition and details blocks, and GitHub
+// alerts (> [!NOTE]). Admonitions found by other syntax, such as MDX, are
+// passed in as found.
+func extractAdmonitions(content []byte, found []Admonition, result *ParseResult) {
+	result.Admonitions = append(result.Admonitions, found...)
 	lines := bytes.Split(content, []byte("\n"))
-	result.TotalLines = len(lines)
-
-	inCodeBlock := false
+	fenced := fencedLines(lines)
 	for lineNum, line := range lines {
 		trimmed := bytes.TrimSpace(line)
-
-		if bytes.HasPrefix(trimmed, []byte("```")) {
-			inCodeBlock = !inCodeBlock
-			result.CodeLines++
+		if fenced[lineNum] || admonitionMarker(trimmed) == "" {
 			continue
 		}
-
-		if inCodeBlock {
-			result.CodeLines++
-			continue
+		if adm := parseAdmonition(string(trimmed)); adm != nil {
+			adm.Line = lineNum + 1 // 1-based line numbers
+			result.Admonitions = append(result.Admonitions, *adm)
 		}
-
-		if len(trimmed) == 0 {
-			result.EmptyLines++
-			continue
-		}
-
-		// Detect MkDocs-style admonitions: !!! type or !!! type "title"
-		if bytes.HasPrefix(trimmed, []byte("!!!")) {
-			if adm := parseAdmonition(string(trimmed)); adm != nil {
-				adm.Line = lineNum + 1 // 1-based line numbers
-				result.Admonitions = append(result.Admonitions, *adm)
-			}
+	}
+	for _, b := range pymdownBlocks(lines, fenced) {
+		if b.admonition() {
+			result.Admonitions = append(result.Admonitions, blockAdmonition(b))
 		}
 	}
+	for _, a := range githubAlerts(lines, fenced) {
+		result.Admonitions = append(result.Admonitions, Admonition{Line: a.start + 1, Type: a.kind})
+	}
+	sort.SliceStable(result.Admonitions, func(i, j int) bool {
+		return result.Admonitions[i].Line < result.Admonitions[j].Line
+	})
 }
 
 // parseAdmonition parses a MkDocs-style admonition line.
-// Formats: !!! note, !!! warning "Custom Title", !!! tip inline
+// Formats: !!! note, !!! warning "Custom Title", !!! tip inline,
+// ??? note "Collapsed", ???+ note "Expanded"
 func parseAdmonition(line string) *Admonition {
-	// Remove the !!! prefix
-	line = strings.TrimPrefix(line, "!!!")
+	// Remove the !!!, ??? or ???+ prefix
+	marker := admonitionMarker([]byte(line))
+	line = strings.TrimPrefix(line, marker)
 	line = strings.TrimSpace(line)
 
 	if line == "" {
 		return nil
 	}
 
-	adm := &Admonition{}
+	adm := &Admonition{Collapsible: marker != "!!!"}
 
 	// Check for quoted title: !!! type "title"
 	if idx := strings.Index(line, "\""); idx != -1 {
@@ -315,14 +585,42 @@ func isInsideList(node ast.Node) bool {
 	return false
 }
 
-// extractHeadingText extracts the text content from a heading node.
+// isInsideHeading checks if a node is inside a heading.
+func isInsideHeading(node ast.Node) bool {
+	for node != nil {
+		if _, ok := node.(*ast.Heading); ok {
+			return true
+		}
+		node = node.Parent()
+	}
+	return false
+}
+
+// extractHeadingText extracts the text content from a heading node,
+// including emphasized and linked text. Inline code keeps its backticks.
 // This replaces the deprecated n.Text() method.
 func extractHeadingText(n *ast.Heading, source []byte) string {
 	var buf bytes.Buffer
-	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
-		if text, ok := child.(*ast.Text); ok {
-			buf.Write(text.Segment.Value(source))
+	_ = ast.Walk(n, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
 		}
-	}
+		switch t := node.(type) {
+		case *ast.CodeSpan:
+			buf.WriteByte('`')
+			for child := t.FirstChild(); child != nil; child = child.NextSibling() {
+				if text, ok := child.(*ast.Text); ok {
+					buf.Write(text.Segment.Value(source))
+				}
+			}
+			buf.WriteByte('`')
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			buf.Write(t.Segment.Value(source))
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
 	return buf.String()
 }
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -147,13 +148,14 @@ func TestParse_Headings(t *testing.T) {
 			name:       "heading with formatting",
 			content:    "# **Bold** heading",
 			wantLevels: []int{1},
-			// Parser strips markdown formatting, leaving just the suffix
-			wantTexts: []string{" heading"},
+			// Formatting is stripped, its text is kept
+			wantTexts: []string{"Bold heading"},
 		},
 		{
 			name:       "heading with inline code",
 			content:    "# The `code` heading",
 			wantLevels: []int{1},
+			wantTexts:  []string{"The `code` heading"},
 		},
 	}
 
@@ -224,11 +226,52 @@ func TestParse_Admonitions(t *testing.T) {
 			wantCount: 1,
 			wantTypes: []string{"note"},
 		},
+		{
+			name:       "collapsible details",
+			content:    "??? note \"Closed\"\n    Hidden.\n\n???+ tip\n    Open.",
+			wantCount:  2,
+			wantTypes:  []string{"note", "tip"},
+			wantTitles: []string{"Closed", ""},
+		},
+		{
+			name:       "pymdownx blocks",
+			content:    "/// warning | Careful\nBody.\n///\n\n/// details | More\n    type: tip\n\nBody.\n///\n\n/// tab | One\nTab.\n///",
+			wantCount:  2,
+			wantTypes:  []string{"warning", "tip"},
+			wantTitles: []string{"Careful", "More"},
+		},
+		{
+			name:      "admonition inside content tab",
+			content:   "=== \"Tab\"\n\n    !!! note\n        Note.\n\n    Tab text.",
+			wantCount: 1,
+			wantTypes: []string{"note"},
+		},
+		{
+			name:      "github alerts",
+			content:   "> [!NOTE]\n> Useful info.\n\n> [!warning]\n> Careful.\nLazy line.\n\n> [!CAUTION]",
+			wantCount: 3,
+			wantTypes: []string{"note", "warning", "caution"},
+		},
+		{
+			name:      "unknown alert type is a plain blockquote",
+			content:   "> [!FOO]\n> Quoted.",
+			wantCount: 0,
+		},
+		{
+			name:      "github alert inside code block ignored",
+			content:   "```\n> [!TIP]\n> Example.\n```",
+			wantCount: 0,
+		},
 		{
 			name:      "admonition inside code block ignored",
 			content:   "```\n!!! note\n    This should not be counted.\n```",
 			wantCount: 0,
 		},
+		{
+			name:      "collapsible and pymdownx block inside code block ignored",
+			content:   "```\n??? note\n    Hidden.\n/// note\nBody.\n///\n```",
+			wantCount: 0,
+		},
 		{
 			name:      "all common types",
 			content:   "!!! note\n\n!!! warning\n\n!!! tip\n\n!!! info\n\n!!! danger\n\n!!! example\n\n!!! abstract\n\n!!! question",
@@ -301,6 +344,17 @@ func TestParseAdmonition(t *testing.T) {
 			line:     "!!! note+",
 			wantType: "note",
 		},
+		{
+			name:     "collapsed details",
+			line:     "??? question",
+			wantType: "question",
+		},
+		{
+			name:      "expanded details with title",
+			line:      "???+ example \"Open by default\"",
+			wantType:  "example",
+			wantTitle: "Open by default",
+		},
 		{
 			name:      "complex title with spaces",
 			line:      "!!! example \"This Is A Long Title\"",
@@ -643,3 +697,574 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestParse_Segments(t *testing.T) {
+	content := "---\ntitle: Test\n---\n# Title\n\nFirst *line*\nsecond line.\n\n- item\n\n| A |\n|---|\n| cell |\n\n```\ncode\n```\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Segment{
+		{Text: "Title", Line: 4, Column: 3, Offset: 22, Kind: SegmentHeading},
+		{Text: "First ", Line: 6, Column: 1, Offset: 29, Kind: SegmentProse},
+		{Text: "line", Line: 6, Column: 8, Offset: 36, Kind: SegmentProse},
+		{Text: "second line.", Line: 7, Column: 1, Offset: 42, Kind: SegmentProse}, // Split by goldmark, merged back
+		{Text: "item", Line: 9, Column: 3, Offset: 58, Kind: SegmentList},
+		{Text: "A", Line: 11, Column: 3, Offset: 66, Kind: SegmentTable},
+		{Text: "cell", Line: 13, Column: 3, Offset: 78, Kind: SegmentTable},
+	}
+	if len(result.Segments) != len(want) {
+		t.Fatalf("got %d segments, want %d: %+v", len(result.Segments), len(want), result.Segments)
+	}
+	for i, seg := range result.Segments {
+		if seg != want[i] {
+			t.Errorf("Segments[%d] = %+v, want %+v", i, seg, want[i])
+		}
+	}
+}
+
+func TestParse_HeadingLinesAfterStrippedBlocks(t *testing.T) {
+	content := "---\ntitle: Test\n---\n\n!!! note\n    Body.\n\n## Section\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Headings) != 1 || result.Headings[0].Line != 8 {
+		t.Errorf("Headings = %+v, want one heading on line 8", result.Headings)
+	}
+}
+
+func TestParse_Paragraphs(t *testing.T) {
+	content := "# Title\n\nFirst *line* with `code`\nsecond line.\n\n- tight item\n\n> Quoted text.\n\n!!! note\n    Admonition text.\n\nLast paragraph."
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Paragraph{
+		{Line: 3, Text: "First line with code second line."},
+		{Line: 8, Text: "Quoted text."},
+		{Line: 13, Text: "Last paragraph."},
+	}
+	if len(result.Paragraphs) != len(want) {
+		t.Fatalf("got %d paragraphs, want %d: %+v", len(result.Paragraphs), len(want), result.Paragraphs)
+	}
+	for i, p := range result.Paragraphs {
+		if p != want[i] {
+			t.Errorf("Paragraphs[%d] = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+func TestParse_HeadingNextBlock(t *testing.T) {
+	content := "# Intro\n\nSome text.\n\n## List\n\n- item\n\n## Code\n\n```\nx\n```\n\n## Table\n\n| A |\n|---|\n| b |\n\n## Note\n\n!!! note\n    Body.\n\n## Nested\n### Child\n\n> Quote\n\n## Last\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []BlockKind{BlockParagraph, BlockList, BlockCode, BlockTable, BlockAdmonition, BlockHeading, BlockQuote, ""}
+	if len(result.Headings) != len(want) {
+		t.Fatalf("got %d headings, want %d", len(result.Headings), len(want))
+	}
+	for i, h := range result.Headings {
+		if h.Next != want[i] {
+			t.Errorf("Headings[%d] %q Next = %q, want %q", i, h.Text, h.Next, want[i])
+		}
+	}
+}
+
+func TestParse_Frontmatter(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantFormat string
+		wantLines  map[string]int
+		wantErr    bool
+	}{
+		{
+			name:       "yaml",
+			content:    "---\ntitle: Guide\ntags:\n  - cli\ndescription: Short.\n---\n# Guide\n",
+			wantFormat: FrontmatterYAML,
+			wantLines:  map[string]int{"title": 2, "tags": 3, "description": 5},
+		},
+		{
+			name:       "toml",
+			content:    "+++\ntitle = \"Guide\"\ntags = [\"cli\"]\n\n[params]\nweight = 2\n+++\n# Guide\n",
+			wantFormat: FrontmatterTOML,
+			wantLines:  map[string]int{"title": 2, "tags": 3, "params": 5},
+		},
+		{
+			name:       "invalid yaml",
+			content:    "---\ntitle: [unclosed\n---\n",
+			wantFormat: FrontmatterYAML,
+			wantLines:  map[string]int{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			fm := result.Frontmatter
+			if fm == nil {
+				t.Fatal("Frontmatter = nil")
+			}
+			if fm.Format != tt.wantFormat || (fm.Err != nil) != tt.wantErr {
+				t.Errorf("Format = %q, Err = %v, want %q, wantErr %v", fm.Format, fm.Err, tt.wantFormat, tt.wantErr)
+			}
+			if len(fm.Lines) != len(tt.wantLines) {
+				t.Errorf("Lines = %v, want %v", fm.Lines, tt.wantLines)
+			}
+			for key, line := range tt.wantLines {
+				if fm.Lines[key] != line {
+					t.Errorf("Lines[%q] = %d, want %d", key, fm.Lines[key], line)
+				}
+				if _, ok := fm.Fields[key]; !ok {
+					t.Errorf("Fields missing %q", key)
+				}
+			}
+		})
+	}
+
+	result, err := Parse([]byte("# No frontmatter\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.Frontmatter != nil {
+		t.Errorf("Frontmatter = %+v, want nil", result.Frontmatter)
+	}
+}
+
+func TestParse_LineComposition(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    [7]int // code, table, list, html, admonition, frontmatter, empty
+	}{
+		{
+			name:    "tilde fence",
+			content: "Text.\n\n~~~go\nx := 1\n\ny := 2\n~~~\n",
+			want:    [7]int{5, 0, 0, 0, 0, 0, 2},
+		},
+		{
+			name:    "empty fence",
+			content: "Text.\n\n```\n```\n\nMore text.\n\n~~~\n~~~\n",
+			want:    [7]int{4, 0, 0, 0, 0, 0, 4},
+		},
+		{
+			name:    "empty fence in list",
+			content: "- one\n\n  ```\n  ```\n",
+			want:    [7]int{2, 0, 1, 0, 0, 0, 2},
+		},
+		{
+			name:    "indented code",
+			content: "Text.\n\n    one\n    two\n",
+			want:    [7]int{2, 0, 0, 0, 0, 0, 2},
+		},
+		{
+			name:    "table",
+			content: "| a | b |\n|---|---|\n| 1 | 2 |\n",
+			want:    [7]int{0, 3, 0, 0, 0, 0, 1},
+		},
+		{
+			name:    "list with nested code",
+			content: "- one\n- two\n\n  ```\n  code\n  ```\n",
+			want:    [7]int{3, 0, 2, 0, 0, 0, 2},
+		},
+		{
+			name:    "html block",
+			content: "<div>\n<p>Hi</p>\n</div>\n\nText.",
+			want:    [7]int{0, 0, 0, 3, 0, 0, 1},
+		},
+		{
+			name:    "admonition",
+			content: "!!! note \"Title\"\n    Body.\n\n    ```\n    code\n    ```\n\nText.",
+			want:    [7]int{0, 0, 0, 0, 5, 0, 2},
+		},
+		{
+			name:    "frontmatter",
+			content: "---\ntitle: Test\n---\n# Test\n",
+			want:    [7]int{0, 0, 0, 0, 0, 3, 1},
+		},
+		{
+			name:    "admonition syntax in code",
+			content: "~~~\n!!! note\n    Body.\n~~~\n",
+			want:    [7]int{4, 0, 0, 0, 0, 0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got := [7]int{result.CodeLines, result.TableLines, result.ListLines, result.HTMLLines,
+				result.AdmonitionLines, result.FrontmatterLines, result.EmptyLines}
+			if got != tt.want {
+				t.Errorf("code, table, list, html, admonition, frontmatter, empty = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	result, err := Parse([]byte("~~~\n!!! note\n~~~\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Admonitions) != 0 {
+		t.Errorf("Admonitions = %v, want none inside fenced code", result.Admonitions)
+	}
+}
+
+func TestParse_SegmentPositions(t *testing.T) {
+	content := "---\ntitle: Test\n---\n# Über heading\n\nPlain *emphasis* and café text.\n\n- Item `code` after\n"
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []struct {
+		text         string
+		line, column int
+	}{
+		{"Über heading", 4, 3},
+		{"Plain ", 6, 1},
+		{"emphasis", 6, 8},
+		{" and café text.", 6, 17},
+		{"Item ", 8, 3},
+		{" after", 8, 14},
+	}
+	if len(result.Segments) != len(want) {
+		t.Fatalf("got %d segments, want %d: %+v", len(result.Segments), len(want), result.Segments)
+	}
+	for i, seg := range result.Segments {
+		w := want[i]
+		if seg.Text != w.text || seg.Line != w.line || seg.Column != w.column {
+			t.Errorf("segment %d = %q at %d:%d, want %q at %d:%d", i, seg.Text, seg.Line, seg.Column, w.text, w.line, w.column)
+		}
+		if got := content[seg.Offset : seg.Offset+len(seg.Text)]; got != seg.Text {
+			t.Errorf("segment %d Offset points at %q, want %q", i, got, seg.Text)
+		}
+	}
+
+	// "text" follows the two-byte "é", so its column is less than its byte offset
+	seg := result.Segments[3]
+	if line, column := seg.Position(strings.Index(seg.Text, "text")); line != 6 || column != 27 {
+		t.Errorf("Position() = %d:%d, want 6:27", line, column)
+	}
+}
+
+func TestParse_MaterialContainers(t *testing.T) {
+	content := "# Install\n\n=== \"Python\"\n\n    Install with pip.\n\n    ```bash\n    pip install tool\n    ```\n\n=== \"Go\"\n\n    Install with go.\n\n/// tab | Docker\n\nPull the image.\n///\n\n??? note\n    Hidden note.\n\nDone.\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantProse := "Install Install with pip. Install with go. Pull the image. Done."
+	if result.Prose != wantProse {
+		t.Errorf("Prose = %q, want %q", result.Prose, wantProse)
+	}
+	if len(result.CodeBlocks) != 1 || !strings.Contains(result.CodeBlocks[0], "pip install tool") {
+		t.Errorf("CodeBlocks = %q, want the fenced block inside the tab", result.CodeBlocks)
+	}
+	if len(result.Admonitions) != 1 || !result.Admonitions[0].Collapsible {
+		t.Errorf("Admonitions = %+v, want one collapsible admonition", result.Admonitions)
+	}
+
+	// Tab content is dedented before parsing; positions still point at
+	// the original, indented text
+	want := map[string][2]int{
+		"Install with pip.": {5, 5},
+		"Install with go.":  {13, 5},
+		"Pull the image.":   {17, 1},
+	}
+	for _, seg := range result.Segments {
+		pos, ok := want[seg.Text]
+		if !ok {
+			continue
+		}
+		if seg.Line != pos[0] || seg.Column != pos[1] {
+			t.Errorf("%q at %d:%d, want %d:%d", seg.Text, seg.Line, seg.Column, pos[0], pos[1])
+		}
+		if !strings.HasPrefix(content[seg.Offset:], seg.Text) {
+			t.Errorf("%q Offset %d points at %q", seg.Text, seg.Offset, content[seg.Offset:seg.Offset+len(seg.Text)])
+		}
+		delete(want, seg.Text)
+	}
+	if len(want) > 0 {
+		t.Errorf("missing segments: %v", want)
+	}
+}
+
+func TestParse_GitHubAlerts(t *testing.T) {
+	content := "# Setup\n\n> [!IMPORTANT]\n> Back up your data first.\nThis line continues the alert.\n\n> A plain quote stays prose.\n\nRun the installer.\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Admonition{{Line: 3, Type: "important"}}
+	if !reflect.DeepEqual(result.Admonitions, want) {
+		t.Errorf("Admonitions = %+v, want %+v", result.Admonitions, want)
+	}
+	wantProse := "Setup A plain quote stays prose. Run the installer."
+	if result.Prose != wantProse {
+		t.Errorf("Prose = %q, want %q", result.Prose, wantProse)
+	}
+	if result.AdmonitionLines != 3 {
+		t.Errorf("AdmonitionLines = %d, want 3", result.AdmonitionLines)
+	}
+	if result.Headings[0].Next != BlockAdmonition {
+		t.Errorf("Headings[0].Next = %q, want %q", result.Headings[0].Next, BlockAdmonition)
+	}
+}
+
+func TestParseMDX(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantProse string
+		wantText  []string // Paragraph text, checked when set
+		wantCode  []string // Code blocks, checked when set
+		wantAdm   []Admonition
+	}{
+		{
+			name:      "esm statements",
+			content:   "import Tabs from '@theme/Tabs';\nimport {\n  TabItem,\n} from '@theme/TabItem';\n\nText.\n\nexport const meta = {a: 1};\n",
+			wantProse: "Text.",
+		},
+		{
+			name:      "import in a paragraph is prose",
+			content:   "You can\nimport data from a file.\n",
+			wantProse: "You can import data from a file.",
+		},
+		{
+			name:      "jsx keeps text children",
+			content:   "<Tabs>\n  <TabItem value=\"a\" label=\"A\">\n\n    Indented child text.\n\n  </TabItem>\n</Tabs>\n\nUse <Highlight color=\"#25c2a0\">green</Highlight> now.\n",
+			wantProse: "Indented child text. Use green now.",
+		},
+		{
+			name:      "expressions and comments",
+			content:   "Hello {props.name}. {/* it's hidden */}\n\n<Button onClick={() => go('>')} />\n",
+			wantProse: "Hello .",
+		},
+		{
+			name:      "jsx in code is kept",
+			content:   "Write `<Tabs>` here.\n\n```jsx\n<Tabs />\n```\n",
+			wantProse: "Write here.",
+			wantText:  []string{"Write <Tabs> here."},
+			wantCode:  []string{"<Tabs />\n"},
+		},
+		{
+			name:      "admonitions",
+			content:   ":::note\nHidden.\n:::\n\n:::tip[Pro tip]\nHidden.\n:::\n\n::::info Outer\n:::warning\nNested.\n:::\n::::\n\nShown.\n",
+			wantProse: "Shown.",
+			wantAdm: []Admonition{
+				{Line: 1, Type: "note"},
+				{Line: 5, Type: "tip", Title: "Pro tip"},
+				{Line: 9, Type: "info", Title: "Outer"},
+				{Line: 10, Type: "warning"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseMDX([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseMDX() error = %v", err)
+			}
+			if result.Prose != tt.wantProse {
+				t.Errorf("Prose = %q, want %q", result.Prose, tt.wantProse)
+			}
+			if tt.wantText != nil {
+				var text []string
+				for _, p := range result.Paragraphs {
+					text = append(text, p.Text)
+				}
+				if !reflect.DeepEqual(text, tt.wantText) {
+					t.Errorf("Paragraphs = %q, want %q", text, tt.wantText)
+				}
+			}
+			if tt.wantCode != nil && !reflect.DeepEqual(result.CodeBlocks, tt.wantCode) {
+				t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, tt.wantCode)
+			}
+			if len(result.Admonitions) != len(tt.wantAdm) || (len(tt.wantAdm) > 0 && !reflect.DeepEqual(result.Admonitions, tt.wantAdm)) {
+				t.Errorf("Admonitions = %+v, want %+v", result.Admonitions, tt.wantAdm)
+			}
+		})
+	}
+}
+
+func TestParseMDX_LineComposition(t *testing.T) {
+	content := "import Tabs from '@theme/Tabs';\n\n# Title\n\n<Tabs>\n  Child text.\n</Tabs>\n\n:::note\nBody.\n:::\n"
+
+	result, err := ParseMDX([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseMDX() error = %v", err)
+	}
+	if result.CodeLines != 1 || result.HTMLLines != 2 || result.AdmonitionLines != 3 {
+		t.Errorf("CodeLines = %d, HTMLLines = %d, AdmonitionLines = %d, want 1, 2, 3",
+			result.CodeLines, result.HTMLLines, result.AdmonitionLines)
+	}
+}
+
+func TestParseWithOptions_Templates(t *testing.T) {
+	hugo := TemplateSyntax{Profiles: []string{TemplateHugo}}
+	jinja := TemplateSyntax{Profiles: []string{TemplateJinja}}
+	liquid := TemplateSyntax{Profiles: []string{TemplateLiquid}}
+
+	tests := []struct {
+		name      string
+		content   string
+		templates TemplateSyntax
+		wantProse string
+		wantCode  []string // Code blocks, checked when set
+	}{
+		{
+			name:      "no profiles",
+			content:   "Welcome to {{ site.name }}.\n",
+			wantProse: "Welcome to {{ site.name }}.",
+		},
+		{
+			name:      "hugo shortcodes and variables",
+			content:   "See {{< ref \"install.md\" >}} or {{% param \"name\" %}} on {{ .Site.Title }}.\n\n{{< figure src=\"a.png\" />}}\n",
+			templates: hugo,
+			wantProse: "See or on .",
+		},
+		{
+			name:      "hugo paired shortcodes",
+			content:   "{{< notice warning >}}\nHidden text.\n{{< /notice >}}\n\nShown {{% inline %}}hidden{{% /inline %}} text.\n",
+			templates: hugo,
+			wantProse: "Shown text.",
+		},
+		{
+			name:      "hugo paired shortcodes kept",
+			content:   "{{< notice warning >}}\nKept text.\n{{< /notice >}}\n\nShown {{% inline %}}kept{{% /inline %}} text.\n",
+			templates: TemplateSyntax{Profiles: []string{TemplateHugo}, KeepPaired: true},
+			wantProse: "Kept text. Shown kept text.",
+		},
+		{
+			name:      "jinja tags, expressions, and comments",
+			content:   "{% include \"header.md\" %}\n\nVersion {{- config.version -}} is out. {# todo:\nreword #}\n\n{% if beta %}\nBeta only.\n{% else %}\nStable only.\n{% endif %}\n",
+			templates: jinja,
+			wantProse: "Version is out.",
+		},
+		{
+			name:      "liquid comments are dropped even when paired text is kept",
+			content:   "{% comment %}\nDraft.\n{% endcomment %}\n\n{% capture x %}Kept.{% endcapture %}\n",
+			templates: TemplateSyntax{Profiles: []string{TemplateLiquid}, KeepPaired: true},
+			wantProse: "Kept.",
+		},
+		{
+			name:      "raw blocks are kept",
+			content:   "{% raw %}\nWrite {{ name }} to insert it.\n{% endraw %}\n",
+			templates: liquid,
+			wantProse: "Write {{ name }} to insert it.",
+		},
+		{
+			name:      "code is kept",
+			content:   "Write `{{ name }}` here.\n\n```jinja\n{{ name }}\n```\n",
+			templates: jinja,
+			wantProse: "Write here.",
+			wantCode:  []string{"{{ name }}\n"},
+		},
+		{
+			name:      "unclosed tags are text",
+			content:   "Use {{ to open.\n\nOr {% here.\n",
+			templates: jinja,
+			wantProse: "Use {{ to open. Or {% here.",
+		},
+		{
+			name:      "custom delimiters",
+			content:   "Hello <%= user %>, see [[toc]].\n",
+			templates: TemplateSyntax{Delimiters: []Delimiters{{"<%", "%>"}, {"[[", "]]"}}},
+			wantProse: "Hello , see .",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseWithOptions([]byte(tt.content), Options{Templates: tt.templates})
+			if err != nil {
+				t.Fatalf("ParseWithOptions() error = %v", err)
+			}
+			if result.Prose != tt.wantProse {
+				t.Errorf("Prose = %q, want %q", result.Prose, tt.wantProse)
+			}
+			if tt.wantCode != nil && !reflect.DeepEqual(result.CodeBlocks, tt.wantCode) {
+				t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions_TemplateErrors(t *testing.T) {
+	tests := []TemplateSyntax{
+		{Profiles: []string{"erb"}},
+		{Delimiters: []Delimiters{{Open: "<%"}}},
+	}
+	for _, tt := range tests {
+		if _, err := ParseWithOptions([]byte("Text.\n"), Options{Templates: tt}); err == nil {
+			t.Errorf("ParseWithOptions(%+v) error = nil, want an error", tt)
+		}
+	}
+}
+
+func TestParseWithOptions_TemplateLineComposition(t *testing.T) {
+	content := "# Title\n\n{{< notice >}}\nHidden.\n{{< /notice >}}\n\nText {{ .Param }}.\n"
+
+	result, err := ParseWithOptions([]byte(content), Options{Templates: TemplateSyntax{Profiles: []string{TemplateHugo}}})
+	if err != nil {
+		t.Fatalf("ParseWithOptions() error = %v", err)
+	}
+	if result.HTMLLines != 3 || result.EmptyLines != 3 {
+		t.Errorf("HTMLLines = %d, EmptyLines = %d, want 3, 3", result.HTMLLines, result.EmptyLines)
+	}
+	want := []Segment{
+		{Text: "Title", Line: 1, Column: 3, Offset: 2, Kind: SegmentHeading},
+		{Text: "Text             .", Line: 7, Column: 1, Offset: 49, Kind: SegmentProse},
+	}
+	if !reflect.DeepEqual(result.Segments, want) {
+		t.Errorf("Segments = %+v, want %+v", result.Segments, want)
+	}
+}
+
+func TestBlankLines(t *testing.T) {
+	content := []byte("a\nbb\nccc\ndd\ne")
+	tests := []struct {
+		name   string
+		ranges []lineRange
+		want   string
+	}{
+		{"none", nil, "a\nbb\nccc\ndd\ne"},
+		{"one", []lineRange{{1, 3}}, "a\n  \n   \ndd\ne"},
+		{"nested", []lineRange{{0, 4}, {1, 2}}, " \n  \n   \n  \ne"},
+		{"overlapping", []lineRange{{0, 2}, {1, 3}}, " \n  \n   \ndd\ne"},
+		{"past the end", []lineRange{{4, 9}}, "a\nbb\nccc\ndd\n "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blankLines(content, tt.ranges)
+			if string(got) != tt.want {
+				t.Errorf("blankLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if string(content) != "a\nbb\nccc\ndd\ne" {
+		t.Errorf("blankLines() changed its input to %q", content)
+	}
+}
@@ -0,0 +1,324 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Template profiles name the tag syntax of common site generators.
+const (
+	TemplateHugo   = "hugo"   // {{< shortcode >}}, {{% shortcode %}}, and {{ .Param }}
+	TemplateJinja  = "jinja"  // {{ variable }}, {% tag %}, and {# comment #}, as in MkDocs-macros
+	TemplateLiquid = "liquid" // {{ variable }} and {% tag %}, as in Jekyll
+)
+
+// Delimiters is the opening and closing text of a template tag.
+type Delimiters struct {
+	Open  string
+	Close string
+}
+
+// TemplateSyntax selects the template tags blanked before parsing. Tags
+// render to text the tool cannot see, so they would otherwise be read as
+// prose and distort word, syllable, and sentence counts.
+type TemplateSyntax struct {
+	Profiles   []string     // TemplateHugo, TemplateJinja, or TemplateLiquid
+	Delimiters []Delimiters // Custom tags, in addition to the profiles
+	KeepPaired bool         // Keep the text between paired tags, such as {{< note >}}...{{< /note >}}
+}
+
+// Options configures ParseWithOptions.
+type Options struct {
+	Templates TemplateSyntax
+}
+
+// blockLead matches what may come before the text of a line in a block:
+// indentation, list markers, and blockquote markers.
+var blockLead = regexp.MustCompile(`^[ \t]*(?:(?:>|(?:[-*+]|[0-9]+[.)])[ \t])[ \t]*)*$`)
+
+// templateTag is a delimiter pair and whether its tags can open a block
+// closed by a matching tag: {% if %}...{% endif %} or {{< x >}}...{{< /x >}}.
+type templateTag struct {
+	Delimiters
+	block bool
+}
+
+// templateProfiles holds the tags of each profile.
+var templateProfiles = map[string][]templateTag{
+	TemplateHugo: {
+		{Delimiters{"{{<", ">}}"}, true},
+		{Delimiters{"{{%", "%}}"}, true},
+		{Delimiters{"{{", "}}"}, false},
+	},
+	TemplateJinja: {
+		{Delimiters{"{%", "%}"}, true},
+		{Delimiters{"{{", "}}"}, false},
+		{Delimiters{"{#", "#}"}, false},
+	},
+	TemplateLiquid: {
+		{Delimiters{"{%", "%}"}, true},
+		{Delimiters{"{{", "}}"}, false},
+	},
+}
+
+// templateTags returns the tags to blank, longest opening first so that
+// {{< is tried before {{.
+func templateTags(s TemplateSyntax) ([]templateTag, error) {
+	var tags []templateTag
+	seen := make(map[Delimiters]bool)
+	add := func(t templateTag) {
+		if !seen[t.Delimiters] {
+			seen[t.Delimiters] = true
+			tags = append(tags, t)
+		}
+	}
+	for _, p := range s.Profiles {
+		profile, ok := templateProfiles[p]
+		if !ok {
+			return nil, fmt.Errorf("unknown template profile %q", p)
+		}
+		for _, t := range profile {
+			add(t)
+		}
+	}
+	for _, d := range s.Delimiters {
+		if d.Open == "" || d.Close == "" {
+			return nil, fmt.Errorf("template delimiters need both an opening and a closing text")
+		}
+		add(templateTag{d, true})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return len(tags[i].Open) > len(tags[j].Open)
+	})
+	return tags, nil
+}
+
+// tagMatch is a template tag found in the text: stream positions of its
+// first and last byte, and its name, such as "include", "/note" or
+// "endif".
+type tagMatch struct {
+	start, end int
+	name       string
+	block      bool
+}
+
+// stripTemplates blanks template tags outside fenced code and inline code
+// spans. Text between paired tags is blanked too, unless KeepPaired is
+// set. Comment blocks are always blanked and raw blocks always kept.
+// Newlines are kept so line numbers stay unchanged. Lines left blank count
+// as HTML.
+//
+// Text after a tag that starts a line is moved back to where the tag
+// began, so the blanked tag does not indent it into a code block. Returns
+// the bytes moved on each line, nil when no tags are configured.
+func stripTemplates(content []byte, s TemplateSyntax) ([]byte, []kindRange, []int, error) {
+	tags, err := templateTags(s)
+	if err != nil || len(tags) == 0 {
+		return content, nil, nil, err
+	}
+
+	original := bytes.Split(content, []byte("\n"))
+	lines := bytes.Split(bytes.Clone(content), []byte("\n"))
+	fenced := fencedLines(lines)
+
+	// Scan the lines as one stream so tags can span line breaks
+	type pos struct{ line, col int }
+	var stream []pos
+	for i, line := range lines {
+		if fenced[i] {
+			continue
+		}
+		for j := range line {
+			stream = append(stream, pos{i, j})
+		}
+		stream = append(stream, pos{i, len(line)}) // Line break
+	}
+	at := func(k int) byte {
+		p := stream[k]
+		if p.col == len(lines[p.line]) {
+			return '\n'
+		}
+		return lines[p.line][p.col]
+	}
+	hasAt := func(k int, text string) bool {
+		if k+len(text) > len(stream) {
+			return false
+		}
+		for i := 0; i < len(text); i++ {
+			if at(k+i) != text[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	matches := findTags(len(stream), at, hasAt, tags)
+
+	touched := make([]bool, len(lines))
+	blankStream := func(from, to int) {
+		for k := from; k <= to; k++ {
+			p := stream[k]
+			if p.col < len(lines[p.line]) {
+				lines[p.line][p.col] = ' '
+				touched[p.line] = true
+			}
+		}
+	}
+	for _, m := range matches {
+		blankStream(m.start, m.end)
+	}
+	for _, p := range pairTags(matches) {
+		open, closing := matches[p[0]], matches[p[1]]
+		if open.name == "raw" || (s.KeepPaired && open.name != "comment") {
+			continue
+		}
+		blankStream(open.end+1, closing.start-1)
+	}
+
+	var known []kindRange
+	shifts := make([]int, len(lines))
+	for i, t := range touched {
+		switch {
+		case !t:
+		case len(bytes.TrimSpace(lines[i])) == 0:
+			known = append(known, kindRange{lineRange{i, i + 1}, lineHTML})
+		default:
+			shifts[i] = outdent(original[i], lines[i])
+		}
+	}
+	return bytes.Join(lines, []byte("\n")), known, shifts, nil
+}
+
+// outdent moves the text after a blanked run at the start of a line, after
+// any indentation and list or blockquote markers, to where the run began.
+// The end is padded with spaces so the length is unchanged, and a trailing
+// carriage return stays last. Returns the number of bytes moved.
+func outdent(original, line []byte) int {
+	start := 0
+	for start < len(line) && line[start] == original[start] {
+		start++
+	}
+	if start == len(line) || !blockLead.Match(line[:start]) {
+		return 0
+	}
+	text := start
+	for text < len(line) && (line[text] == ' ' || line[text] == '\t') {
+		text++
+	}
+
+	end := len(line)
+	if line[end-1] == '\r' {
+		end--
+	}
+	n := text - start
+	copy(line[start:], line[text:end])
+	for i := end - n; i < end; i++ {
+		line[i] = ' '
+	}
+	return n
+}
+
+// findTags returns the template tags in a stream of n bytes, skipping
+// inline code spans. A tag ends at the first closing text after it and
+// may not span a blank line. Tags inside a raw block are not matched.
+func findTags(n int, at func(int) byte, hasAt func(int, string) bool, tags []templateTag) []tagMatch {
+	var matches []tagMatch
+	raw := false
+	for k := 0; k < n; k++ {
+		if at(k) == '`' && !raw {
+			k = skipCodeSpan(k, n, at)
+			continue
+		}
+		for _, t := range tags {
+			if !hasAt(k, t.Open) {
+				continue
+			}
+			end := closingTag(k+len(t.Open), n, at, hasAt, t.Close)
+			if end < 0 {
+				continue
+			}
+			var inner strings.Builder
+			for j := k + len(t.Open); j <= end-len(t.Close); j++ {
+				inner.WriteByte(at(j))
+			}
+			m := tagMatch{start: k, end: end, name: tagName(inner.String()), block: t.block}
+			if raw && m.name != "endraw" {
+				continue
+			}
+			raw = m.block && m.name == "raw"
+			matches = append(matches, m)
+			k = end
+			break
+		}
+	}
+	return matches
+}
+
+// closingTag returns the stream position of the last byte of close at or
+// after k, or -1 if a blank line or the end comes first.
+func closingTag(k, n int, at func(int) byte, hasAt func(int, string) bool, close string) int {
+	blankLine := false // No text since the last line break
+	for j := k; j < n; j++ {
+		if hasAt(j, close) {
+			return j + len(close) - 1
+		}
+		switch c := at(j); {
+		case c == '\n':
+			if blankLine {
+				return -1
+			}
+			blankLine = true
+		case c != ' ' && c != '\t' && c != '\r':
+			blankLine = false
+		}
+	}
+	return -1
+}
+
+// tagName returns the first word of a tag's content, without whitespace
+// control dashes: "- if x -" is "if". Self-closing Hugo shortcodes such
+// as "x /" have no name, so they never open a block.
+func tagName(inner string) string {
+	inner = strings.Trim(strings.TrimSpace(inner), "-~")
+	fields := strings.Fields(inner)
+	if len(fields) == 0 || strings.HasSuffix(inner, "/") {
+		return ""
+	}
+	return fields[0]
+}
+
+// pairTags matches block tags with the tags that close them: "x" with
+// "/x" (Hugo) or "endx" (Jinja and Liquid). Tags with no closing tag stay
+// single. Returns index pairs into matches.
+func pairTags(matches []tagMatch) [][2]int {
+	var pairs [][2]int
+	var stack []int
+	for i, m := range matches {
+		if !m.block || m.name == "" {
+			continue
+		}
+		target := ""
+		switch {
+		case strings.HasPrefix(m.name, "/"):
+			target = m.name[1:]
+		case strings.HasPrefix(m.name, "end"):
+			target = m.name[3:]
+		}
+		closed := false
+		for j := len(stack) - 1; j >= 0 && target != ""; j-- {
+			if matches[stack[j]].name == target {
+				pairs = append(pairs, [2]int{stack[j], i})
+				stack = stack[:j]
+				closed = true
+				break
+			}
+		}
+		if !closed {
+			stack = append(stack, i)
+		}
+	}
+	return pairs
+}
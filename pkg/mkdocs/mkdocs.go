@@ -0,0 +1,170 @@
+// Package mkdocs reads the parts of an MkDocs configuration that decide
+// which pages a site publishes: docs_dir, nav, and not_in_nav.
+package mkdocs
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Site is an MkDocs site configuration.
+type Site struct {
+	ConfigFile string    // Path to mkdocs.yml
+	DocsDir    string    // Pages directory, resolved against the config file
+	HasNav     bool      // False when nav is absent and MkDocs builds it from the tree
+	Pages      []NavPage // Local pages in nav order
+	NotInNav   []string  // Patterns for pages left out of the nav on purpose
+}
+
+// NavPage is a nav entry that points at a page in docs_dir.
+type NavPage struct {
+	Path    string   // Page path relative to docs_dir, with forward slashes
+	Title   string   // Entry title, empty when the page title is used
+	Section []string // Titles of the enclosing nav sections, outermost first
+	Line    int      // Line of the entry in mkdocs.yml (1-based)
+}
+
+// SectionName joins the section titles for display, or returns "" for
+// pages at the top of the nav.
+func (p NavPage) SectionName() string {
+	return strings.Join(p.Section, " / ")
+}
+
+// Load reads an MkDocs configuration file. The YAML is walked as nodes,
+// so Python tags used by plugins (!!python/name:...) and !ENV do not need
+// to be resolved.
+func Load(configFile string) (*Site, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid MkDocs config: %w", err)
+	}
+
+	site := &Site{ConfigFile: configFile, DocsDir: "docs"}
+	if len(doc.Content) == 0 {
+		site.DocsDir = filepath.Join(filepath.Dir(configFile), site.DocsDir)
+		return site, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid MkDocs config: expected key-value pairs")
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		switch key {
+		case "docs_dir":
+			site.DocsDir = value.Value
+		case "nav":
+			site.HasNav = true
+			if err := site.addNavItems(value, nil); err != nil {
+				return nil, err
+			}
+		case "not_in_nav":
+			for _, line := range strings.Split(value.Value, "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+					site.NotInNav = append(site.NotInNav, line)
+				}
+			}
+		}
+	}
+
+	if !filepath.IsAbs(site.DocsDir) {
+		site.DocsDir = filepath.Join(filepath.Dir(configFile), site.DocsDir)
+	}
+	return site, nil
+}
+
+// addNavItems walks a nav list. Items are a bare page path, a title mapped
+// to a page path or URL, or a title mapped to a nested section list.
+func (s *Site) addNavItems(list *yaml.Node, section []string) error {
+	if list.Kind != yaml.SequenceNode {
+		return fmt.Errorf("invalid MkDocs nav at line %d: expected a list", list.Line)
+	}
+	for _, item := range list.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			s.addPage(item, "", section)
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(item.Content); i += 2 {
+				title, value := item.Content[i].Value, item.Content[i+1]
+				if value.Kind == yaml.SequenceNode {
+					nested := append(append([]string{}, section...), title)
+					if err := s.addNavItems(value, nested); err != nil {
+						return err
+					}
+					continue
+				}
+				s.addPage(value, title, section)
+			}
+		default:
+			return fmt.Errorf("invalid MkDocs nav at line %d: unexpected entry", item.Line)
+		}
+	}
+	return nil
+}
+
+// addPage records a nav entry unless it links outside the site.
+func (s *Site) addPage(node *yaml.Node, title string, section []string) {
+	target := strings.TrimSpace(node.Value)
+	if target == "" || strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") {
+		return
+	}
+	s.Pages = append(s.Pages, NavPage{
+		Path:    path.Clean(strings.TrimPrefix(target, "/")),
+		Title:   title,
+		Section: section,
+		Line:    node.Line,
+	})
+}
+
+// IsPage reports whether MkDocs builds a page from a file in docs_dir.
+// Only Markdown files are pages; other files, such as HTML or notebooks,
+// are copied as static files and never belong in the nav.
+func IsPage(file string) bool {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".md", ".markdown", ".mdown", ".mkdn", ".mkd":
+		return true
+	}
+	return false
+}
+
+// ExcludedFromNav reports whether a page matches a not_in_nav pattern.
+// Patterns starting with "/" are anchored at docs_dir; others match the
+// end of the path, as in .gitignore.
+func (s *Site) ExcludedFromNav(page string) bool {
+	for _, pattern := range s.NotInNav {
+		if anchored := strings.TrimPrefix(pattern, "/"); anchored != pattern {
+			if matchPath(anchored, page) {
+				return true
+			}
+			continue
+		}
+		parts := strings.Split(page, "/")
+		for i := range parts {
+			if matchPath(pattern, strings.Join(parts[i:], "/")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchPath matches a glob against a path, or against a directory prefix
+// when the pattern ends with "/".
+func matchPath(pattern, page string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		return strings.HasPrefix(page, dir+"/")
+	}
+	ok, _ := path.Match(pattern, page)
+	return ok
+}
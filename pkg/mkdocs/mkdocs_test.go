@@ -0,0 +1,147 @@
+package mkdocs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	configFile := filepath.Join(t.TempDir(), "mkdocs.yml")
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return configFile
+}
+
+func TestLoad(t *testing.T) {
+	configFile := writeConfig(t, `site_name: Test
+docs_dir: content
+markdown_extensions:
+  - pymdownx.emoji:
+      emoji_index: !!python/name:material.extensions.emoji.twemoji
+nav:
+  - Home: index.md
+  - Guide:
+      - guide/index.md
+      - Install: guide/install.md
+      - Advanced:
+          - Tuning: guide/advanced/tuning.md
+  - GitHub: https://github.com/example/repo
+not_in_nav: |
+  /drafts/
+  *.draft.md
+`)
+
+	site, err := Load(configFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := filepath.Join(filepath.Dir(configFile), "content"); site.DocsDir != want {
+		t.Errorf("DocsDir = %q, want %q", site.DocsDir, want)
+	}
+	if !site.HasNav {
+		t.Error("HasNav = false, want true")
+	}
+
+	want := []NavPage{
+		{Path: "index.md", Title: "Home", Line: 7},
+		{Path: "guide/index.md", Section: []string{"Guide"}, Line: 9},
+		{Path: "guide/install.md", Title: "Install", Section: []string{"Guide"}, Line: 10},
+		{Path: "guide/advanced/tuning.md", Title: "Tuning", Section: []string{"Guide", "Advanced"}, Line: 12},
+	}
+	if !reflect.DeepEqual(site.Pages, want) {
+		t.Errorf("Pages = %+v\nwant %+v", site.Pages, want)
+	}
+	if got := site.Pages[3].SectionName(); got != "Guide / Advanced" {
+		t.Errorf("SectionName() = %q, want %q", got, "Guide / Advanced")
+	}
+	if !reflect.DeepEqual(site.NotInNav, []string{"/drafts/", "*.draft.md"}) {
+		t.Errorf("NotInNav = %v", site.NotInNav)
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	configFile := writeConfig(t, "site_name: Test\n")
+	site, err := Load(configFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := filepath.Join(filepath.Dir(configFile), "docs"); site.DocsDir != want {
+		t.Errorf("DocsDir = %q, want %q", site.DocsDir, want)
+	}
+	if site.HasNav || len(site.Pages) != 0 {
+		t.Errorf("HasNav = %v, Pages = %v, want no nav", site.HasNav, site.Pages)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not yaml", "nav: [unclosed\n"},
+		{"nav not a list", "nav: index.md\n"},
+		{"nested list", "nav:\n  - - index.md\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(writeConfig(t, tt.content)); err == nil {
+				t.Error("Load() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("Load() of a missing file: error = nil, want error")
+	}
+}
+
+func TestExcludedFromNav(t *testing.T) {
+	site := &Site{NotInNav: []string{"/drafts/", "*.draft.md", "/404.md", "snippets/"}}
+
+	tests := []struct {
+		page string
+		want bool
+	}{
+		{"drafts/idea.md", true},
+		{"guide/drafts/idea.md", false},
+		{"guide/setup.draft.md", true},
+		{"404.md", true},
+		{"guide/404.md", false},
+		{"snippets/note.md", true},
+		{"guide/snippets/note.md", true},
+		{"guide/setup.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.page, func(t *testing.T) {
+			if got := site.ExcludedFromNav(tt.page); got != tt.want {
+				t.Errorf("ExcludedFromNav(%q) = %v, want %v", tt.page, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPage(t *testing.T) {
+	tests := []struct {
+		file string
+		want bool
+	}{
+		{"index.md", true},
+		{"guide/Setup.MD", true},
+		{"notes.markdown", true},
+		{"extra.html", false},
+		{"tutorials/intro.ipynb", false},
+		{"guide/setup.rst", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPage(tt.file); got != tt.want {
+			t.Errorf("IsPage(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
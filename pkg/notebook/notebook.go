@@ -0,0 +1,214 @@
+// Package notebook reads Jupyter notebooks (.ipynb). Markdown cells are
+// joined into one Markdown document and scored as a whole. Code cells
+// become fenced code blocks in that document, so they count as code and
+// never as prose.
+package notebook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// Cell types.
+const (
+	CellMarkdown = "markdown"
+	CellCode     = "code"
+)
+
+// Cell is where one notebook cell sits in the combined document.
+type Cell struct {
+	Index int    // Position in the notebook (1-based, counting every cell)
+	Type  string // CellMarkdown or CellCode
+	Line  int    // First source line in the combined document (1-based)
+	Lines int    // Number of source lines
+}
+
+// Cells lists the cells of a notebook in document order. Empty cells and
+// raw cells are left out.
+type Cells []Cell
+
+// Locate returns the cell holding a line of the combined document and the
+// line within that cell. Lines added between cells, such as code fences,
+// belong to the start of the next cell. Both are 0 when there are no cells.
+func (c Cells) Locate(line int) (cell, cellLine int) {
+	for _, cl := range c {
+		if line < cl.Line {
+			return cl.Index, 1
+		}
+		if line < cl.Line+cl.Lines {
+			return cl.Index, line - cl.Line + 1
+		}
+	}
+	if len(c) == 0 {
+		return 0, 0
+	}
+	last := c[len(c)-1]
+	return last.Index, last.Lines
+}
+
+// Count returns the number of cells of the given type.
+func (c Cells) Count(cellType string) int {
+	n := 0
+	for _, cl := range c {
+		if cl.Type == cellType {
+			n++
+		}
+	}
+	return n
+}
+
+// file is the part of the nbformat 4 schema the parser reads.
+type file struct {
+	Cells []struct {
+		CellType string `json:"cell_type"`
+		Source   source `json:"source"`
+	} `json:"cells"`
+	Metadata struct {
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+	} `json:"metadata"`
+}
+
+// source is cell source text, stored either as one string or as a list
+// of lines that keep their line breaks.
+type source string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *source) UnmarshalJSON(data []byte) error {
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err == nil {
+		*s = source(strings.Join(lines, ""))
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	*s = source(text)
+	return nil
+}
+
+// Parse extracts prose content, code blocks, and headings from a
+// notebook. Line numbers and segment offsets in the result refer to the
+// combined document; use Cells.Locate to map lines back to cells. Line
+// counts cover cell source only, not the fences and blank lines added
+// between cells.
+func Parse(content []byte) (*markdown.ParseResult, Cells, error) {
+	var nb file
+	if err := json.Unmarshal(content, &nb); err != nil {
+		return nil, nil, fmt.Errorf("invalid notebook: %w", err)
+	}
+	if nb.Cells == nil {
+		return nil, nil, errors.New("invalid notebook: no cells (nbformat 4 or later is required)")
+	}
+	language := nb.Metadata.LanguageInfo.Name
+	if language == "" {
+		language = nb.Metadata.Kernelspec.Language
+	}
+
+	var doc strings.Builder
+	var cells Cells
+	closed := 0 // Separator lines that close a fence left open in a cell
+	open := ""
+	line := 1
+	for i, c := range nb.Cells {
+		text := strings.TrimRight(strings.ReplaceAll(string(c.Source), "\r\n", "\n"), "\n")
+		if strings.TrimSpace(text) == "" || (c.CellType != CellMarkdown && c.CellType != CellCode) {
+			continue
+		}
+		if len(cells) > 0 {
+			// Keep blocks in separate cells apart, as Jupyter renders each
+			// cell on its own
+			doc.WriteString("\n" + open + "\n")
+			line += 2
+			if open != "" {
+				closed++
+			}
+		}
+		cell := Cell{Index: i + 1, Type: c.CellType, Lines: strings.Count(text, "\n") + 1}
+		if c.CellType == CellCode {
+			fence := strings.Repeat("`", max(3, longestRun(text, '`')+1))
+			doc.WriteString(fence + language + "\n" + text + "\n" + fence)
+			cell.Line = line + 1
+			line += cell.Lines + 1
+		} else {
+			doc.WriteString(text)
+			cell.Line = line
+			line += cell.Lines - 1
+			open = openFence(text)
+		}
+		cells = append(cells, cell)
+	}
+	if len(cells) > 0 {
+		doc.WriteString("\n")
+	}
+
+	result, err := markdown.Parse([]byte(doc.String()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Drop the lines added between cells from the counts
+	if len(cells) > 0 {
+		code := cells.Count(CellCode)
+		added := len(cells) - 1 + 2*code
+		result.TotalLines -= added + 1 // The final newline starts an empty line
+		result.EmptyLines -= len(cells) - closed
+		result.CodeLines -= 2*code + closed
+	}
+	return result, cells, nil
+}
+
+// openFence returns the fence that closes a fenced code block left open
+// at the end of Markdown text, or "" when every block is closed.
+func openFence(text string) string {
+	fence := ""
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) > 3 || len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+			continue
+		}
+		run := trimmed[:longestPrefix(trimmed, trimmed[0])]
+		switch {
+		case len(run) < 3:
+		case fence == "":
+			if run[0] == '~' || !strings.Contains(trimmed[len(run):], "`") {
+				fence = run
+			}
+		case run[0] == fence[0] && len(run) >= len(fence) && strings.TrimSpace(trimmed[len(run):]) == "":
+			fence = ""
+		}
+	}
+	return fence
+}
+
+// longestPrefix returns the number of leading c bytes in text.
+func longestPrefix(text string, c byte) int {
+	n := 0
+	for n < len(text) && text[n] == c {
+		n++
+	}
+	return n
+}
+
+// longestRun returns the length of the longest run of c in text.
+func longestRun(text string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
@@ -0,0 +1,148 @@
+package notebook
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testNotebook has a markdown cell, an empty cell, a code cell with a
+// list source, a raw cell, and a markdown cell with a string source.
+const testNotebook = `{
+ "cells": [
+  {"cell_type": "markdown", "metadata": {}, "source": ["# Tutorial\n", "\n", "Load the data first.\n"]},
+  {"cell_type": "markdown", "metadata": {}, "source": []},
+  {"cell_type": "code", "execution_count": 1, "metadata": {}, "outputs": [], "source": ["import pandas as pd\n", "df = pd.read_csv(\"data.csv\")"]},
+  {"cell_type": "raw", "metadata": {}, "source": "raw text"},
+  {"cell_type": "markdown", "metadata": {}, "source": "## Results\n\nThe the table shows\nthe totals."}
+ ],
+ "metadata": {"language_info": {"name": "python"}},
+ "nbformat": 4,
+ "nbformat_minor": 5
+}`
+
+func TestParse(t *testing.T) {
+	result, cells, err := Parse([]byte(testNotebook))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	wantCells := Cells{
+		{Index: 1, Type: CellMarkdown, Line: 1, Lines: 3},
+		{Index: 3, Type: CellCode, Line: 6, Lines: 2},
+		{Index: 5, Type: CellMarkdown, Line: 10, Lines: 4},
+	}
+	if !reflect.DeepEqual(cells, wantCells) {
+		t.Errorf("cells = %+v, want %+v", cells, wantCells)
+	}
+
+	wantProse := "Tutorial Load the data first. Results The the table shows the totals."
+	if result.Prose != wantProse {
+		t.Errorf("Prose = %q, want %q", result.Prose, wantProse)
+	}
+	wantCode := []string{"import pandas as pd\ndf = pd.read_csv(\"data.csv\")\n"}
+	if !reflect.DeepEqual(result.CodeBlocks, wantCode) {
+		t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, wantCode)
+	}
+
+	// Only cell source counts: 3 + 2 + 4 lines, with one empty line in
+	// each markdown cell
+	got := [...]int{result.TotalLines, result.EmptyLines, result.CodeLines}
+	want := [...]int{9, 2, 2}
+	if got != want {
+		t.Errorf("Total, Empty, Code lines = %v, want %v", got, want)
+	}
+	if len(result.Headings) != 2 || result.Headings[1].Line != 10 {
+		t.Errorf("Headings = %+v, want two with the second on line 10", result.Headings)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not JSON", "# Title\n"},
+		{"no cells", `{"worksheets": [], "nbformat": 3}`},
+		{"bad source", `{"cells": [{"cell_type": "markdown", "source": 1}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Parse([]byte(tt.content)); err == nil {
+				t.Error("Parse() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestParse_UnclosedFence(t *testing.T) {
+	content := `{"cells": [
+		{"cell_type": "markdown", "source": "Intro.\n\n` + "```" + `\nnot closed"},
+		{"cell_type": "markdown", "source": "Outside the fence."}
+	]}`
+
+	result, _, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.Prose != "Intro. Outside the fence." {
+		t.Errorf("Prose = %q, want %q", result.Prose, "Intro. Outside the fence.")
+	}
+	got := [...]int{result.TotalLines, result.EmptyLines, result.CodeLines}
+	want := [...]int{5, 1, 2}
+	if got != want {
+		t.Errorf("Total, Empty, Code lines = %v, want %v", got, want)
+	}
+}
+
+func TestCells_Locate(t *testing.T) {
+	cells := Cells{
+		{Index: 1, Type: CellMarkdown, Line: 1, Lines: 3},
+		{Index: 3, Type: CellCode, Line: 6, Lines: 2},
+		{Index: 5, Type: CellMarkdown, Line: 10, Lines: 4},
+	}
+	tests := []struct {
+		line     int
+		wantCell int
+		wantLine int
+	}{
+		{1, 1, 1},
+		{3, 1, 3},
+		{4, 3, 1}, // Blank line between cells
+		{5, 3, 1}, // Opening fence of the code cell
+		{7, 3, 2},
+		{11, 5, 2},
+		{20, 5, 4}, // Past the end
+	}
+
+	for _, tt := range tests {
+		cell, line := cells.Locate(tt.line)
+		if cell != tt.wantCell || line != tt.wantLine {
+			t.Errorf("Locate(%d) = %d, %d, want %d, %d", tt.line, cell, line, tt.wantCell, tt.wantLine)
+		}
+	}
+	if cell, line := Cells(nil).Locate(1); cell != 0 || line != 0 {
+		t.Errorf("Locate(1) without cells = %d, %d, want 0, 0", cell, line)
+	}
+}
+
+func TestOpenFence(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Text.", ""},
+		{"```go\ncode\n```", ""},
+		{"```go\ncode", "```"},
+		{"~~~~\n```\ncode", "~~~~"},
+		{"````\n```\n", "````"},
+		{"    ```\nindented code", ""},
+		{"```not`a fence", ""},
+	}
+
+	for _, tt := range tests {
+		if got := openFence(tt.text); got != tt.want {
+			t.Errorf("openFence(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -11,9 +11,9 @@ import (
 
 // Diagnostic writes results in linter/LSP-style diagnostic format.
 // Format: file:line:col: severity: message (rule-id)
+// Notebook lines count from the start of a cell: file:cell_N:line:col
+// Lines included from a snippet file are reported in that file.
 func Diagnostic(w io.Writer, results []*analyzer.Result) {
-	m := mw{w}
-
 	// Sort results by file path for consistent output
 	sorted := make([]*analyzer.Result, len(results))
 	copy(sorted, results)
@@ -22,21 +22,36 @@ func Diagnostic(w io.Writer, results []*analyzer.Result) {
 	})
 
 	for _, r := range sorted {
-		path := cleanPath(r.File)
-		for _, d := range r.Diagnostics {
-			col := d.Column
-			if col == 0 {
-				col = 1 // Default to column 1 if not specified
-			}
-			m.printf("%s:%d:%d: %s: %s (%s)\n",
-				path,
-				d.Line,
-				col,
-				d.Severity,
-				d.Message,
-				d.Rule,
-			)
+		FileDiagnostics(w, r.File, r.Diagnostics)
+	}
+}
+
+// FileDiagnostics writes diagnostics for one file in the format used by
+// Diagnostic. It also reports problems in files that are not analyzed as
+// pages, such as nav entries in mkdocs.yml.
+func FileDiagnostics(w io.Writer, file string, diagnostics []analyzer.Diagnostic) {
+	m := mw{w}
+	path := cleanPath(file)
+	for _, d := range diagnostics {
+		col := d.Column
+		if col == 0 {
+			col = 1 // Default to column 1 if not specified
+		}
+		position := fmt.Sprintf("%d:%d", d.Line, col)
+		if d.Cell > 0 {
+			position = fmt.Sprintf("cell_%d:%s", d.Cell, position)
+		}
+		at := path
+		if d.File != "" {
+			at = cleanPath(d.File)
 		}
+		m.printf("%s:%s: %s: %s (%s)\n",
+			at,
+			position,
+			d.Severity,
+			d.Message,
+			d.Rule,
+		)
 	}
 }
 
@@ -221,3 +221,43 @@ func TestDiagnostic_PathCleaning(t *testing.T) {
 		t.Errorf("Expected clean path 'docs/example.md', got %q", output)
 	}
 }
+
+func TestDiagnostic_NotebookCell(t *testing.T) {
+	results := []*analyzer.Result{
+		{
+			File: "tutorials/intro.ipynb",
+			Diagnostics: []analyzer.Diagnostic{
+				{Line: 2, Column: 5, Cell: 3, Severity: analyzer.SeverityWarning, Rule: "content/repeated-word", Message: "test"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	Diagnostic(&buf, results)
+
+	want := "tutorials/intro.ipynb:cell_3:2:5: warning: test (content/repeated-word)\n"
+	if buf.String() != want {
+		t.Errorf("Diagnostic() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestDiagnostic_SnippetFile(t *testing.T) {
+	results := []*analyzer.Result{
+		{
+			File: "docs/guide.md",
+			Diagnostics: []analyzer.Diagnostic{
+				{Line: 4, Column: 2, File: "./docs/includes/intro.md", Severity: analyzer.SeverityWarning, Rule: "content/repeated-word", Message: "test"},
+				{Line: 7, Severity: analyzer.SeverityWarning, Rule: "snippets/missing", Message: "test"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	Diagnostic(&buf, results)
+
+	want := "docs/includes/intro.md:4:2: warning: test (content/repeated-word)\n" +
+		"docs/guide.md:7:1: warning: test (snippets/missing)\n"
+	if buf.String() != want {
+		t.Errorf("Diagnostic() = %q, want %q", buf.String(), want)
+	}
+}
@@ -35,9 +35,12 @@ func cleanPath(path string) string {
 }
 
 // Markdown writes full results as a GitHub-flavored markdown report.
-func Markdown(w io.Writer, results []*analyzer.Result) {
+// Results with an MkDocs nav section get a table per section, in nav order;
+// other results are sorted with failures first. Files count as failed when
+// they fail a check at the failOn level.
+func Markdown(w io.Writer, results []*analyzer.Result, failOn analyzer.Severity) {
 	m := mw{w}
-	passed, failed, totalWords, totalLines := aggregateCounts(results)
+	passed, failed, totalWords, totalLines := aggregateCounts(results, failOn)
 
 	// Summary table
 	m.println("| Metric | Value |")
@@ -47,31 +50,53 @@ func Markdown(w io.Writer, results []*analyzer.Result) {
 	m.printf("| Failed | %d |\n", failed)
 	m.printf("| Words | %d |\n", totalWords)
 	m.printf("| Lines | %d |\n", totalLines)
-	m.printf("| Reading time | %d min |\n", (totalWords+199)/200)
+	m.printf("| Reading time | %d min |\n", totalReadingTime(results))
 	m.println()
 
+	// Nav sections, in nav order, each with its own results table
+	if sections := navSections(results); sections != nil {
+		m.println("| Section | Pages | Words | Read |")
+		m.println("|---------|------:|------:|-----:|")
+		for _, s := range sections {
+			m.printf("| %s | %d | %d | %s |\n", s.name, len(s.results), s.words, readingTime(s.minutes))
+		}
+		for _, s := range sections {
+			m.println()
+			m.printf("### %s\n\n", s.name)
+			writeResultsTable(m, s.results)
+		}
+		return
+	}
+
 	// Sort by status (failed first), then by file path
 	sorted := make([]*analyzer.Result, len(results))
 	copy(sorted, results)
 	sort.Slice(sorted, func(i, j int) bool {
 		if sorted[i].Status != sorted[j].Status {
-			return sorted[i].Status == "fail"
+			return statusRank(sorted[i].Status) > statusRank(sorted[j].Status)
 		}
 		return sorted[i].File < sorted[j].File
 	})
+	writeResultsTable(m, sorted)
+}
 
-	// Results table
+// writeResultsTable writes one row per result, in the order given.
+func writeResultsTable(m mw, results []*analyzer.Result) {
 	m.println("| Status | File | Lines | Read | FK Grade | ARI | Flesch | Issues |")
 	m.println("|:------:|------|------:|-----:|---------:|----:|-------:|--------|")
 
-	for _, r := range sorted {
+	for _, r := range results {
 		status := "✅"
 		issues := ""
-		if r.Status == "fail" {
+		switch r.Status {
+		case analyzer.StatusFail:
 			status = "❌"
 			issues = identifyIssues(r)
+		case analyzer.StatusWarn:
+			status = "⚠️"
+			issues = identifyIssues(r)
 		}
-		readTime := readingTime(r.Structural.Words)
+		readTime := readingTime(r.Structural.ReadingTimeMinutes)
 		m.printf("| %s | %s | %d | %s | %.1f | %.1f | %.1f | %s |\n",
 			status,
 			cleanPath(r.File),
@@ -85,17 +110,35 @@ func Markdown(w io.Writer, results []*analyzer.Result) {
 	}
 }
 
-// readingTime formats word count as reading time estimate.
-// Uses 200 WPM for technical content with ceiling division.
-func readingTime(words int) string {
-	if words <= 0 {
+// statusRank orders statuses so failures sort before warnings and passes.
+func statusRank(status string) int {
+	switch status {
+	case analyzer.StatusFail:
+		return 2
+	case analyzer.StatusWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// readingTime formats a reading time estimate in minutes.
+func readingTime(minutes int) string {
+	if minutes <= 0 {
 		return "<1m"
 	}
-	// Ceiling division: (words + 199) / 200
-	minutes := (words + 199) / 200
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// totalReadingTime sums per-file estimates and rounds up to whole minutes.
+func totalReadingTime(results []*analyzer.Result) int {
+	seconds := 0
+	for _, r := range results {
+		seconds += r.Structural.ReadingTimeSeconds
+	}
+	return analyzer.ReadingTimeMinutes(seconds)
+}
+
 // identifyIssues returns all failure reasons from diagnostics.
 func identifyIssues(r *analyzer.Result) string {
 	if len(r.Diagnostics) == 0 {
@@ -129,9 +172,9 @@ func identifyIssues(r *analyzer.Result) string {
 }
 
 // Summary writes only an aggregate summary in markdown format.
-func Summary(w io.Writer, results []*analyzer.Result) {
+func Summary(w io.Writer, results []*analyzer.Result, failOn analyzer.Severity) {
 	m := mw{w}
-	passed, failed, totalWords, totalLines := aggregateCounts(results)
+	passed, failed, totalWords, totalLines := aggregateCounts(results, failOn)
 
 	// Overall status
 	if failed == 0 {
@@ -149,7 +192,7 @@ func Summary(w io.Writer, results []*analyzer.Result) {
 	m.printf("| Failed | %d |\n", failed)
 	m.printf("| Words | %d |\n", totalWords)
 	m.printf("| Lines | %d |\n", totalLines)
-	m.printf("| Reading time | %d min |\n", (totalWords+199)/200)
+	m.printf("| Reading time | %d min |\n", totalReadingTime(results))
 	m.println()
 
 	// Failed files list if any
@@ -160,7 +203,7 @@ func Summary(w io.Writer, results []*analyzer.Result) {
 		m.println("|------|:--------:|:---:|-------|")
 
 		for _, r := range results {
-			if r.Status == "fail" {
+			if r.FailsOn(failOn) {
 				issue := identifyIssue(r)
 				m.printf("| %s | %.1f | %.1f | %s |\n",
 					cleanPath(r.File),
@@ -186,9 +229,9 @@ func Summary(w io.Writer, results []*analyzer.Result) {
 
 // Report writes a standalone markdown report suitable for job summaries.
 // This is the recommended format for CI integration.
-func Report(w io.Writer, results []*analyzer.Result) {
+func Report(w io.Writer, results []*analyzer.Result, failOn analyzer.Severity) {
 	m := mw{w}
-	passed, failed, totalWords, totalLines := aggregateCounts(results)
+	passed, failed, totalWords, totalLines := aggregateCounts(results, failOn)
 
 	m.println("## Documentation Readability")
 	m.println()
@@ -209,7 +252,7 @@ func Report(w io.Writer, results []*analyzer.Result) {
 	m.printf("| Failed | %d |\n", failed)
 	m.printf("| Words | %d |\n", totalWords)
 	m.printf("| Lines | %d |\n", totalLines)
-	m.printf("| Reading time | ~%d min |\n", (totalWords+199)/200)
+	m.printf("| Reading time | ~%d min |\n", totalReadingTime(results))
 	m.println()
 
 	// Only show failed files in report (keep it concise)
@@ -220,7 +263,7 @@ func Report(w io.Writer, results []*analyzer.Result) {
 		m.println("|------|---:|----:|-------|")
 
 		for _, r := range results {
-			if r.Status == "fail" {
+			if r.FailsOn(failOn) {
 				issue := identifyIssue(r)
 				m.printf("| %s | %.1f | %.1f | %s |\n",
 					cleanPath(r.File),
@@ -246,12 +289,14 @@ func Report(w io.Writer, results []*analyzer.Result) {
 	m.println("</details>")
 }
 
-func aggregateCounts(results []*analyzer.Result) (passed, failed, totalWords, totalLines int) {
+// aggregateCounts totals results. A file fails when it fails a check at
+// the failOn level, so the counts agree with the exit code of --check.
+func aggregateCounts(results []*analyzer.Result, failOn analyzer.Severity) (passed, failed, totalWords, totalLines int) {
 	for _, r := range results {
-		if r.Status == "pass" {
-			passed++
-		} else {
+		if r.FailsOn(failOn) {
 			failed++
+		} else {
+			passed++
 		}
 		totalWords += r.Structural.Words
 		totalLines += r.Structural.Lines
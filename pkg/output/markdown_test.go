@@ -8,29 +8,50 @@ import (
 
 func TestReadingTime(t *testing.T) {
 	tests := []struct {
-		name  string
-		words int
-		want  string
+		name    string
+		minutes int
+		want    string
 	}{
-		{"zero words", 0, "<1m"},
-		{"negative words", -10, "<1m"},
-		{"1 word", 1, "1m"},
-		{"199 words", 199, "1m"},
-		{"200 words exactly", 200, "1m"},
-		{"201 words rounds up", 201, "2m"},
-		{"399 words", 399, "2m"},
-		{"400 words", 400, "2m"},
-		{"401 words rounds up", 401, "3m"},
-		{"500 words", 500, "3m"},
-		{"1000 words", 1000, "5m"},
-		{"1001 words rounds up", 1001, "6m"},
+		{"zero minutes", 0, "<1m"},
+		{"negative minutes", -10, "<1m"},
+		{"1 minute", 1, "1m"},
+		{"2 minutes", 2, "2m"},
+		{"60 minutes", 60, "60m"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := readingTime(tt.words)
+			got := readingTime(tt.minutes)
 			if got != tt.want {
-				t.Errorf("readingTime(%d) = %q, want %q", tt.words, got, tt.want)
+				t.Errorf("readingTime(%d) = %q, want %q", tt.minutes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTotalReadingTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds []int
+		want    int
+	}{
+		{"no results", nil, 0},
+		{"single file", []int{90}, 2},
+		{"sums before rounding", []int{30, 30}, 1},
+		{"rounds total up", []int{60, 1}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []*analyzer.Result
+			for _, s := range tt.seconds {
+				results = append(results, &analyzer.Result{
+					Structural: analyzer.Structural{ReadingTimeSeconds: s},
+				})
+			}
+			got := totalReadingTime(results)
+			if got != tt.want {
+				t.Errorf("totalReadingTime() = %d, want %d", got, tt.want)
 			}
 		})
 	}
@@ -113,7 +113,7 @@ func TestTable_SingleResult(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Table(&buf, results, false)
+	Table(&buf, results, false, analyzer.SeverityWarning)
 
 	output := buf.String()
 
@@ -149,7 +149,7 @@ func TestTable_Verbose(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Table(&buf, results, true)
+	Table(&buf, results, true, analyzer.SeverityWarning)
 
 	output := buf.String()
 
@@ -157,6 +157,9 @@ func TestTable_Verbose(t *testing.T) {
 	if !strings.Contains(output, "Coleman-Liau") {
 		t.Errorf("Verbose should include Coleman-Liau")
 	}
+	if !strings.Contains(output, "Rare words:") {
+		t.Errorf("Verbose should include rare word ratio")
+	}
 	if !strings.Contains(output, "Gunning Fog") {
 		t.Errorf("Verbose should include Gunning Fog")
 	}
@@ -186,7 +189,7 @@ func TestTable_MultipleSummary(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Table(&buf, results, false)
+	Table(&buf, results, false, analyzer.SeverityWarning)
 
 	output := buf.String()
 
@@ -273,7 +276,7 @@ func TestMarkdown_Output(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Markdown(&buf, results)
+	Markdown(&buf, results, analyzer.SeverityWarning)
 
 	output := buf.String()
 
@@ -295,6 +298,48 @@ func TestMarkdown_Output(t *testing.T) {
 	}
 }
 
+func TestMarkdown_WarnStatus(t *testing.T) {
+	results := []*analyzer.Result{
+		{File: "a.md", Status: "pass"},
+		{
+			File:   "b.md",
+			Status: "warn",
+			Diagnostics: []analyzer.Diagnostic{
+				{Severity: analyzer.SeverityWarning, Rule: "content/admonitions"},
+			},
+		},
+		{
+			File:   "c.md",
+			Status: "fail",
+			Diagnostics: []analyzer.Diagnostic{
+				{Severity: analyzer.SeverityError, Rule: "structure/max-lines"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	Markdown(&buf, results, analyzer.SeverityWarning)
+	output := buf.String()
+
+	if !strings.Contains(output, "| ⚠️ | b.md |") {
+		t.Errorf("Expected ⚠️ row for warned file, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Admonitions") {
+		t.Errorf("Expected warning issue label, got:\n%s", output)
+	}
+	// Failed files sort before warned files, which sort before passing files
+	failIdx := strings.Index(output, "c.md")
+	warnIdx := strings.Index(output, "b.md")
+	passIdx := strings.Index(output, "a.md")
+	if failIdx > warnIdx || warnIdx > passIdx {
+		t.Errorf("Expected fail, warn, pass ordering, got:\n%s", output)
+	}
+	// Warnings fail a check at the warning level, as they do for --check
+	if !strings.Contains(output, "| Failed | 2 |") {
+		t.Errorf("Expected 2 failed files, got:\n%s", output)
+	}
+}
+
 func TestSummary_AllPass(t *testing.T) {
 	results := []*analyzer.Result{
 		{
@@ -306,7 +351,7 @@ func TestSummary_AllPass(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Summary(&buf, results)
+	Summary(&buf, results, analyzer.SeverityWarning)
 
 	output := buf.String()
 	if !strings.Contains(output, "All files pass") {
@@ -329,7 +374,7 @@ func TestSummary_WithFailures(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Summary(&buf, results)
+	Summary(&buf, results, analyzer.SeverityWarning)
 
 	output := buf.String()
 	if !strings.Contains(output, "1 file(s) failed") {
@@ -351,7 +396,7 @@ func TestReport_Output(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Report(&buf, results)
+	Report(&buf, results, analyzer.SeverityWarning)
 
 	output := buf.String()
 	if !strings.Contains(output, "## Documentation Readability") {
@@ -380,7 +425,7 @@ func TestReport_WithFailures(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	Report(&buf, results)
+	Report(&buf, results, analyzer.SeverityWarning)
 
 	output := buf.String()
 	if !strings.Contains(output, "1/1 failed") {
@@ -398,7 +443,7 @@ func TestAggregateCounts(t *testing.T) {
 		{Status: "fail", Structural: analyzer.Structural{Words: 150, Lines: 30}},
 	}
 
-	passed, failed, totalWords, totalLines := aggregateCounts(results)
+	passed, failed, totalWords, totalLines := aggregateCounts(results, analyzer.SeverityWarning)
 
 	if passed != 2 {
 		t.Errorf("Passed = %d, want 2", passed)
@@ -414,6 +459,49 @@ func TestAggregateCounts(t *testing.T) {
 	}
 }
 
+func TestAggregateCounts_FailOn(t *testing.T) {
+	results := []*analyzer.Result{
+		{Status: analyzer.StatusPass},
+		{Status: analyzer.StatusPass, Diagnostics: []analyzer.Diagnostic{{Severity: analyzer.SeverityInfo}}},
+		{Status: analyzer.StatusWarn},
+		{Status: analyzer.StatusFail},
+	}
+
+	tests := []struct {
+		failOn     analyzer.Severity
+		wantFailed int
+	}{
+		{analyzer.SeverityError, 1},
+		{analyzer.SeverityWarning, 2},
+		{analyzer.SeverityInfo, 3},
+	}
+
+	for _, tt := range tests {
+		passed, failed, _, _ := aggregateCounts(results, tt.failOn)
+		if failed != tt.wantFailed || passed != len(results)-tt.wantFailed {
+			t.Errorf("aggregateCounts(%s) = %d passed, %d failed, want %d failed", tt.failOn, passed, failed, tt.wantFailed)
+		}
+	}
+}
+
+func TestSummary_WarningsFail(t *testing.T) {
+	results := []*analyzer.Result{
+		{File: "warn.md", Status: analyzer.StatusWarn},
+	}
+
+	var buf bytes.Buffer
+	Summary(&buf, results, analyzer.SeverityWarning)
+	if output := buf.String(); strings.Contains(output, "All files pass") || !strings.Contains(output, "warn.md") {
+		t.Errorf("Expected warn.md to be listed as failed, got:\n%s", output)
+	}
+
+	buf.Reset()
+	Summary(&buf, results, analyzer.SeverityError)
+	if output := buf.String(); !strings.Contains(output, "All files pass") {
+		t.Errorf("Expected all files to pass at the error level, got:\n%s", output)
+	}
+}
+
 func TestIdentifyIssue(t *testing.T) {
 	tests := []struct {
 		name   string
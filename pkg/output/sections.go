@@ -0,0 +1,48 @@
+package output
+
+import "github.com/adaptive-enforcement-lab/readability/pkg/analyzer"
+
+// otherFiles names the group of files outside the MkDocs docs_dir.
+const otherFiles = "Other files"
+
+// navSection is a run of results in the same MkDocs nav section.
+type navSection struct {
+	name    string
+	results []*analyzer.Result
+	words   int
+	minutes int
+}
+
+// navSections groups results by nav section, keeping their order. CheckNav
+// sorts results into nav order first. Returns nil when no result has a
+// section, so output without an MkDocs nav is unchanged.
+func navSections(results []*analyzer.Result) []navSection {
+	grouped := false
+	for _, r := range results {
+		if r.Section != "" {
+			grouped = true
+			break
+		}
+	}
+	if !grouped {
+		return nil
+	}
+
+	var sections []navSection
+	for _, r := range results {
+		name := r.Section
+		if name == "" {
+			name = otherFiles
+		}
+		if len(sections) == 0 || sections[len(sections)-1].name != name {
+			sections = append(sections, navSection{name: name})
+		}
+		s := &sections[len(sections)-1]
+		s.results = append(s.results, r)
+		s.words += r.Structural.Words
+	}
+	for i := range sections {
+		sections[i].minutes = totalReadingTime(sections[i].results)
+	}
+	return sections
+}
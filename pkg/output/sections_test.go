@@ -0,0 +1,111 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/analyzer"
+)
+
+func navResults() []*analyzer.Result {
+	page := func(file, section string, words, seconds int) *analyzer.Result {
+		return &analyzer.Result{
+			File:       file,
+			Section:    section,
+			Status:     analyzer.StatusPass,
+			Structural: analyzer.Structural{Words: words, ReadingTimeSeconds: seconds},
+		}
+	}
+	return []*analyzer.Result{
+		page("docs/index.md", "Home", 300, 90),
+		page("docs/guide/index.md", "Guide", 400, 120),
+		page("docs/guide/install.md", "Guide", 500, 150),
+		page("docs/orphan.md", analyzer.NotInNav, 100, 30),
+		page("README.md", "", 50, 15),
+	}
+}
+
+func TestNavSections(t *testing.T) {
+	sections := navSections(navResults())
+
+	want := []struct {
+		name           string
+		pages, words   int
+		readingMinutes int
+	}{
+		{"Home", 1, 300, 2},
+		{"Guide", 2, 900, 5},
+		{analyzer.NotInNav, 1, 100, 1},
+		{otherFiles, 1, 50, 1},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("got %d sections, want %d", len(sections), len(want))
+	}
+	for i, s := range sections {
+		w := want[i]
+		if s.name != w.name || len(s.results) != w.pages || s.words != w.words || s.minutes != w.readingMinutes {
+			t.Errorf("section %d = %s (%d pages, %d words, %d min), want %s (%d pages, %d words, %d min)",
+				i, s.name, len(s.results), s.words, s.minutes, w.name, w.pages, w.words, w.readingMinutes)
+		}
+	}
+}
+
+func TestNavSections_NoSections(t *testing.T) {
+	results := []*analyzer.Result{{File: "a.md"}, {File: "b.md"}}
+	if sections := navSections(results); sections != nil {
+		t.Errorf("navSections() = %v, want nil", sections)
+	}
+}
+
+func TestTable_NavSections(t *testing.T) {
+	var buf bytes.Buffer
+	Table(&buf, navResults(), false, analyzer.SeverityWarning)
+	output := buf.String()
+
+	for _, want := range []string{
+		"=== Home ===\nPages: 1 | Words: 300 | Reading time: 2 min",
+		"=== Guide ===\nPages: 2 | Words: 900 | Reading time: 5 min",
+		"=== Not in nav ===",
+		"=== Other files ===",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Table output missing %q", want)
+		}
+	}
+	if strings.Index(output, "=== Guide ===") > strings.Index(output, "docs/guide/install.md") {
+		t.Error("section heading should come before its pages")
+	}
+}
+
+func TestMarkdown_NavSections(t *testing.T) {
+	var buf bytes.Buffer
+	Markdown(&buf, navResults(), analyzer.SeverityWarning)
+	output := buf.String()
+
+	for _, want := range []string{
+		"| Section | Pages | Words | Read |",
+		"| Home | 1 | 300 | 2m |",
+		"| Guide | 2 | 900 | 5m |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Markdown output missing %q", want)
+		}
+	}
+
+	// Pages are listed under their section in nav order, not by path
+	order := []string{
+		"### Home", "| docs/index.md |",
+		"### Guide", "| docs/guide/index.md |", "| docs/guide/install.md |",
+		"### " + analyzer.NotInNav, "| docs/orphan.md |",
+		"### " + otherFiles, "| README.md |",
+	}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(output, want)
+		if i <= last {
+			t.Errorf("%q at %d, want after %d in\n%s", want, i, last, output)
+		}
+		last = i
+	}
+}
@@ -7,16 +7,29 @@ import (
 	"github.com/adaptive-enforcement-lab/readability/pkg/analyzer"
 )
 
-// Table writes results in human-readable table format.
-func Table(w io.Writer, results []*analyzer.Result, verbose bool) {
+// Table writes results in human-readable table format. Results with an
+// MkDocs nav section are grouped under a heading per section. Files count
+// as failed in the summary when they fail a check at the failOn level.
+func Table(w io.Writer, results []*analyzer.Result, verbose bool, failOn analyzer.Severity) {
 	m := mw{w}
-	for _, r := range results {
-		writeFileResult(m, r, verbose)
-		m.println()
+	if sections := navSections(results); sections != nil {
+		for _, s := range sections {
+			m.printf("=== %s ===\n", s.name)
+			m.printf("Pages: %d | Words: %d | Reading time: %d min\n\n", len(s.results), s.words, s.minutes)
+			for _, r := range s.results {
+				writeFileResult(m, r, verbose)
+				m.println()
+			}
+		}
+	} else {
+		for _, r := range results {
+			writeFileResult(m, r, verbose)
+			m.println()
+		}
 	}
 
 	if len(results) > 1 {
-		writeSummary(m, results)
+		writeSummary(m, results, failOn)
 	}
 }
 
@@ -65,10 +78,23 @@ func writeFileResult(m mw, r *analyzer.Result, verbose bool) {
 		m.printf("    SMOG: %.1f\n", r.Readability.SMOG)
 		m.printf("    Sentences: %d\n", r.Structural.Sentences)
 		m.printf("    Characters: %d\n", r.Structural.Characters)
+		m.printf("    Rare words: %.0f%%\n", r.Vocabulary.RareWordRatio*100)
+		m.printf("    Lines: code=%d table=%d list=%d admonition=%d html=%d frontmatter=%d empty=%d\n",
+			r.Composition.CodeLines,
+			r.Composition.TableLines,
+			r.Composition.ListLines,
+			r.Composition.AdmonitionLines,
+			r.Composition.HTMLLines,
+			r.Composition.FrontmatterLines,
+			r.Composition.EmptyLines,
+		)
+		if r.Composition.MarkdownCells+r.Composition.CodeCells > 0 {
+			m.printf("    Cells: markdown=%d code=%d\n", r.Composition.MarkdownCells, r.Composition.CodeCells)
+		}
 	}
 }
 
-func writeSummary(m mw, results []*analyzer.Result) {
+func writeSummary(m mw, results []*analyzer.Result, failOn analyzer.Severity) {
 	m.println("---")
 	m.printf("Summary: %d files analyzed\n", len(results))
 
@@ -78,10 +104,10 @@ func writeSummary(m mw, results []*analyzer.Result) {
 	totalLines := 0
 
 	for _, r := range results {
-		if r.Status == "pass" {
-			passed++
-		} else {
+		if r.FailsOn(failOn) {
 			failed++
+		} else {
+			passed++
 		}
 		totalWords += r.Structural.Words
 		totalLines += r.Structural.Lines
@@ -0,0 +1,322 @@
+package rst
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// run is a stretch of visible text in a paragraph, as byte offsets
+// [start, end). Code runs come from inline literals and code roles.
+type run struct {
+	start, end int
+	code       bool
+}
+
+var (
+	// rolePrefix matches a role before interpreted text: :role:`text`
+	rolePrefix = regexp.MustCompile("^:([\\w.+-]+(?::[\\w.+-]+)?):`")
+
+	// roleSuffix matches a role after interpreted text: `text`:role:
+	roleSuffix = regexp.MustCompile(`^:([\w.+-]+(?::[\w.+-]+)?):`)
+
+	// footnoteReference matches [1]_, [#]_, [#note]_, [*]_ and [citation]_.
+	footnoteReference = regexp.MustCompile(`^\[(?:\d+|#[\w.-]*|\*|[\w.-]+)\]_`)
+
+	// embeddedTarget matches the <target> at the end of reference text.
+	embeddedTarget = regexp.MustCompile(`(?s)\s*<[^<>]+>$`)
+)
+
+// textRoles are roles that render as ordinary text. Other roles, such as
+// :code:, :func: or :py:class:, render as code.
+var textRoles = map[string]bool{
+	"ref": true, "doc": true, "term": true, "numref": true, "any": true,
+	"abbr": true, "dfn": true, "guilabel": true, "menuselection": true,
+	"emphasis": true, "strong": true, "title-reference": true, "title": true, "t": true,
+	"sub": true, "sup": true, "subscript": true, "superscript": true,
+	"pep": true, "rfc": true, "download": true,
+}
+
+// titleRoles are cross-reference roles that render the target's title when
+// no explicit text is given.
+var titleRoles = map[string]bool{
+	"ref": true, "doc": true, "numref": true, "any": true,
+}
+
+// inlineRuns returns the visible text runs of a paragraph, with inline
+// markup removed. The text may span several lines, since inline markup can
+// wrap. Markup that is never closed is text.
+func inlineRuns(text string) []run {
+	hidden := make([]bool, len(text))
+	code := make([]bool, len(text))
+	hide := func(from, to int) {
+		for i := from; i < to; i++ {
+			hidden[i] = true
+		}
+	}
+	unclosed := make(map[string]bool) // Closing strings not found after the current position
+
+	// closing returns the start of the end-string that closes markup whose
+	// text starts at from, or -1.
+	closing := func(from int, end string) int {
+		if unclosed[end] {
+			return -1
+		}
+		for j := from; j < len(text); {
+			k := strings.Index(text[j:], end)
+			if k < 0 {
+				break
+			}
+			k += j
+			n := len(end)
+			if end == "`" || end == "|" {
+				// References end in `_ or `__, and |name|_ or |name|__
+				for n < 3 && k+n < len(text) && text[k+n] == '_' {
+					n++
+				}
+			}
+			if k > from && closesMarkup(text, k, n) && !escaped(text, k) {
+				return k
+			}
+			j = k + 1
+		}
+		unclosed[end] = true
+		return -1
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c == '\\' {
+			hide(i, i+1)
+			if i+1 < len(text) && isSpace(text[i+1]) {
+				hide(i+1, i+2) // Escaped whitespace is removed
+			}
+			i++
+			continue
+		}
+		if !opensMarkup(text, i) {
+			// A simple reference name ends in _ or __: name_
+			if c == '_' && i > 0 && isWordByte(text[i-1]) {
+				n := 1
+				if i+1 < len(text) && text[i+1] == '_' {
+					n = 2
+				}
+				if closesMarkup(text, i, n) {
+					hide(i, i+n)
+					i += n - 1
+				}
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text[i:], "``"):
+			if k := closing(i+2, "``"); k >= 0 && startsText(text, i+2) {
+				hide(i, i+2)
+				markCode(code, i+2, k)
+				hide(k, k+2)
+				i = k + 1
+			}
+
+		case c == ':' && rolePrefix.MatchString(text[i:]):
+			m := rolePrefix.FindStringSubmatch(text[i:])
+			start := i + len(m[0])
+			if k := closing(start, "`"); k >= 0 && startsText(text, start) {
+				hide(i, start)
+				hide(k, k+1)
+				interpreted(text, start, k, m[1], hide, code)
+				i = k
+			}
+
+		case c == '`':
+			k := closing(i+1, "`")
+			if k < 0 || !startsText(text, i+1) {
+				continue
+			}
+			hide(i, i+1)
+			hide(k, k+1)
+			end := k + 1
+			switch {
+			case strings.HasPrefix(text[end:], "__"):
+				hide(end, end+2)
+				reference(text, i+1, k, hide)
+				end += 2
+			case strings.HasPrefix(text[end:], "_"):
+				hide(end, end+1)
+				reference(text, i+1, k, hide)
+				end++
+			default:
+				role := ""
+				if m := roleSuffix.FindString(text[end:]); m != "" {
+					hide(end, end+len(m))
+					role = strings.Trim(m, ":")
+					end += len(m)
+				}
+				interpreted(text, i+1, k, role, hide, code)
+			}
+			i = end - 1
+
+		case c == '_' && strings.HasPrefix(text[i:], "_`"):
+			// Inline internal target: _`text`
+			if k := closing(i+2, "`"); k >= 0 {
+				hide(i, i+2)
+				hide(k, k+1)
+				i = k
+			}
+
+		case strings.HasPrefix(text[i:], "**"):
+			if k := closing(i+2, "**"); k >= 0 && startsText(text, i+2) {
+				hide(i, i+2)
+				hide(k, k+2)
+				i = k + 1
+			}
+
+		case c == '*':
+			if k := closing(i+1, "*"); k >= 0 && startsText(text, i+1) {
+				hide(i, i+1)
+				hide(k, k+1)
+				i = k
+			}
+
+		case c == '|':
+			// Substitution reference: |name|, |name|_ or |name|__
+			if k := closing(i+1, "|"); k >= 0 && startsText(text, i+1) {
+				end := k + 1
+				for end < len(text) && end < k+3 && text[end] == '_' {
+					end++
+				}
+				hide(i, end)
+				i = end - 1
+			}
+
+		case c == '[':
+			if m := footnoteReference.FindString(text[i:]); m != "" && closesMarkup(text, i+len(m)-1, 1) {
+				hide(i, i+len(m))
+				i += len(m) - 1
+			}
+		}
+	}
+
+	var runs []run
+	for i := 0; i < len(text); {
+		if hidden[i] {
+			i++
+			continue
+		}
+		r := run{start: i, code: code[i]}
+		for i < len(text) && !hidden[i] && code[i] == r.code {
+			i++
+		}
+		r.end = i
+		runs = append(runs, r)
+	}
+	return runs
+}
+
+// interpreted shows interpreted text [start, end) for a role: as code, as
+// text, or without an explicit title for cross-references, hidden.
+func interpreted(text string, start, end int, role string, hide func(int, int), code []bool) {
+	name := role
+	if _, after, ok := strings.Cut(role, ":"); ok {
+		name = after // Domain roles, such as py:func
+	}
+	switch {
+	case role == "" || textRoles[name]:
+		if loc := embeddedTarget.FindStringIndex(text[start:end]); loc != nil {
+			if loc[0] == 0 && titleRoles[name] {
+				hide(start, end) // <target> alone renders the target's title
+				return
+			}
+			if loc[0] > 0 {
+				hide(start+loc[0], end)
+			}
+		} else if titleRoles[name] && name != "any" && !strings.ContainsAny(text[start:end], " ") {
+			hide(start, end) // A bare label renders the target's title
+		}
+		if name == "abbr" {
+			// :abbr:`LIFO (last-in, first-out)` shows the abbreviation
+			if k := strings.Index(text[start:end], " ("); k > 0 {
+				hide(start+k, end)
+			}
+		}
+	default:
+		markCode(code, start, end)
+	}
+}
+
+// reference hides the embedded URI or target of hyperlink reference text
+// [start, end): `text <url>`_ shows text. A reference that is only a <url>
+// shows the URL.
+func reference(text string, start, end int, hide func(int, int)) {
+	loc := embeddedTarget.FindStringIndex(text[start:end])
+	if loc == nil {
+		return
+	}
+	if loc[0] == 0 {
+		hide(start, start+1+strings.Index(text[start:end], "<"))
+		hide(end-1, end)
+		return
+	}
+	hide(start+loc[0], end)
+}
+
+// markCode marks the bytes [from, to) as code.
+func markCode(code []bool, from, to int) {
+	for i := from; i < to; i++ {
+		code[i] = true
+	}
+}
+
+// opensMarkup reports whether inline markup may start at i: at the start
+// of the text, or after whitespace or opening punctuation.
+func opensMarkup(text string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	return unicode.IsSpace(prev) || strings.ContainsRune(`'"([{<-/:‘“’«¡¿‐‑‒–—`, prev) ||
+		unicode.In(prev, unicode.Ps, unicode.Pi, unicode.Pd)
+}
+
+// startsText reports whether markup text starting at i begins with a
+// non-whitespace character.
+func startsText(text string, i int) bool {
+	return i < len(text) && !isSpace(text[i])
+}
+
+// closesMarkup reports whether an end-string of n bytes at k can close
+// inline markup: it follows non-whitespace and precedes the end of the
+// text, whitespace or closing punctuation.
+func closesMarkup(text string, k, n int) bool {
+	if k == 0 || isSpace(text[k-1]) {
+		return false
+	}
+	if k+n >= len(text) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(text[k+n:])
+	return unicode.IsSpace(next) || strings.ContainsRune(`'")]}>-/:.,;!?\’”»`, next) ||
+		unicode.In(next, unicode.Pe, unicode.Pf, unicode.Pd, unicode.Po)
+}
+
+// escaped reports whether the byte at k follows an odd number of
+// backslashes.
+func escaped(text string, k int) bool {
+	n := 0
+	for k-n-1 >= 0 && text[k-n-1] == '\\' {
+		n++
+	}
+	return n%2 == 1
+}
+
+// isSpace reports whether b is ASCII whitespace, including line breaks.
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// isWordByte reports whether b can end a simple reference name.
+func isWordByte(b byte) bool {
+	return b >= 0x80 || b == '-' || b == '.' || b == '+' ||
+		(b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
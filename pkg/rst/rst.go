@@ -0,0 +1,873 @@
+// Package rst parses reStructuredText documents into the same shape as
+// markdown.Parse, so every rule and output format works on Sphinx and
+// docutils pages.
+package rst
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/adaptive-enforcement-lab/readability/internal/linekind"
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+// FrontmatterFields is the Frontmatter format for the field list at the top
+// of a document: docutils bibliographic fields or Sphinx file-wide metadata.
+const FrontmatterFields = "fields"
+
+var (
+	explicitMarkup    = regexp.MustCompile(`^\.\.(?:\s|$)`)
+	directive         = regexp.MustCompile(`^\.\.\s+([\w.+-]+(?::[\w.+-]+)?)::(?:\s+(.*))?$`)
+	footnote          = regexp.MustCompile(`^\.\.\s+\[(?:\d+|#[\w.-]*|\*|[\w.-]+)\](?:\s+|$)`)
+	anonymousTarget   = regexp.MustCompile(`^__(?:\s|$)`)
+	bulletItem        = regexp.MustCompile(`^[-*+•‣⁃](?:\s+|$)`)
+	enumeratedItem    = regexp.MustCompile(`^(?:\(?(?:\d+|#|[a-zA-Z]|[ivxlcdmIVXLCDM]+)\)|(?:\d+|#|[a-zA-Z]|[ivxlcdmIVXLCDM]+)\.)(?:\s+|$)`)
+	fieldItem         = regexp.MustCompile(`^:((?:[^:\\\s]|\\.)(?:[^:\\]|\\.)*?):(?:\s+|$)`)
+	optionItem        = regexp.MustCompile(`^(?:--?\w[\w-]*(?:[ =](?:<[^>]+>|[\w-]+))?(?:, --?\w[\w-]*(?:[ =](?:<[^>]+>|[\w-]+))?)*|/\w)(?:\s{2,}|$)`)
+	lineBlockItem     = regexp.MustCompile(`^\|(?:\s+|$)`)
+	gridTableBorder   = regexp.MustCompile(`^\+(?:[-=]+\+)+$`)
+	simpleTableBorder = regexp.MustCompile(`^=+(?: +=+)+$`)
+)
+
+// adornmentChars are the characters that may underline or overline a
+// section title.
+const adornmentChars = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// admonitionDirectives are the directives that render as admonitions.
+var admonitionDirectives = map[string]bool{
+	"admonition": true, "attention": true, "caution": true, "danger": true,
+	"error": true, "hint": true, "important": true, "note": true, "tip": true,
+	"warning": true, "seealso": true,
+}
+
+// codeDirectives are the directives whose content is verbatim.
+var codeDirectives = map[string]bool{
+	"code": true, "code-block": true, "sourcecode": true, "literalinclude": true,
+	"parsed-literal": true, "math": true, "doctest": true, "testcode": true,
+	"testoutput": true, "testsetup": true, "testcleanup": true, "ipython": true,
+	"jupyter-execute": true, "productionlist": true,
+}
+
+// tableDirectives are the directives that hold a table.
+var tableDirectives = map[string]bool{
+	"table": true, "list-table": true, "csv-table": true,
+}
+
+// markupDirectives are the directives that hold no prose: navigation,
+// includes, raw output, and settings.
+var markupDirectives = map[string]bool{
+	"toctree": true, "include": true, "raw": true, "meta": true, "contents": true,
+	"sectnum": true, "header": true, "footer": true, "default-role": true,
+	"role": true, "title": true, "highlight": true, "index": true,
+	"autosummary": true, "currentmodule": true, "module": true,
+	"tabularcolumns": true, "class": true, "unicode": true, "date": true,
+	"replace": true, "target-notes": true,
+}
+
+// parser walks the lines of a document and fills a ParseResult.
+type parser struct {
+	lines   []string
+	offsets []int // Byte offset of each line
+	skip    []int // Leading bytes that belong to an enclosing list marker
+	kinds   []linekind.Kind
+	result  *markdown.ParseResult
+	prose   strings.Builder
+	blocks  []linekind.Block // Top-level blocks in order, for Heading.Next
+	styles  []string         // Section title styles in order of first use
+	cursor  linekind.Cursor  // Last column computed, so columns on a line are counted once
+}
+
+// Parse extracts prose content, code blocks, and headings from
+// reStructuredText.
+func Parse(content []byte) (*markdown.ParseResult, error) {
+	lines := strings.Split(string(content), "\n")
+	p := &parser{
+		lines:   make([]string, len(lines)),
+		offsets: make([]int, len(lines)),
+		skip:    make([]int, len(lines)),
+		kinds:   make([]linekind.Kind, len(lines)),
+		result: &markdown.ParseResult{
+			CodeBlocks:  make([]string, 0),
+			Headings:    make([]markdown.Heading, 0),
+			Admonitions: make([]markdown.Admonition, 0),
+			Segments:    make([]markdown.Segment, 0),
+			Paragraphs:  make([]markdown.Paragraph, 0),
+		},
+	}
+	offset := 0
+	for i, line := range lines {
+		p.offsets[i] = offset
+		p.kinds[i] = linekind.Prose // Until a block says otherwise
+		offset += len(line) + 1
+		p.lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	p.parseBlocks(0, len(p.lines), linekind.Prose, 0)
+	linekind.MarkFollowing(p.blocks, p.result.Headings)
+	linekind.Count(p.result, p.kinds)
+	p.result.Prose = strings.Join(strings.Fields(p.prose.String()), " ")
+	return p.result, nil
+}
+
+// parseBlocks parses the body elements in lines [start, end). kind is the
+// kind of paragraphs in this context: prose, or list inside a list item.
+// depth is the nesting level of indented blocks; sections only appear at
+// depth 0.
+func (p *parser) parseBlocks(start, end int, kind linekind.Kind, depth int) {
+	inList := false // The previous block was a list of listType
+	listType := ""  // bullet, enumerated, field, option, or docinfo
+
+	record := func(k markdown.BlockKind) {
+		if depth == 0 {
+			p.blocks = append(p.blocks, linekind.Block{Kind: k, Heading: -1})
+		}
+	}
+
+	for i := start; i < end; {
+		if p.blank(i) {
+			if p.skip[i] == 0 {
+				p.kinds[i] = linekind.Empty
+			}
+			i++
+			continue
+		}
+		text := p.text(i)
+		indent := p.indent(i)
+
+		// Lists, tracked so that items separated by blank lines form one list
+		if item, marker := p.listMarker(i, end); item != "" {
+			next := p.indentedEnd(i+1, end, indent)
+			if item == "field" && depth == 0 && (inList && listType == "docinfo" || !inList && p.docinfo()) {
+				p.docinfoField(i, next, marker)
+				inList, listType = true, "docinfo"
+				i = next
+				continue
+			}
+			if !inList || listType != item {
+				record(markdown.BlockList)
+			}
+			inList, listType = true, item
+			p.listItem(i, next, marker, depth)
+			i = next
+			continue
+		}
+		inList = false
+
+		title := 0
+		if depth == 0 && indent == 0 {
+			title = p.titleLines(i, end)
+		}
+
+		switch {
+		case title > 0:
+			p.section(i, title)
+			p.blocks = append(p.blocks, linekind.Block{Kind: markdown.BlockHeading, Heading: len(p.result.Headings) - 1})
+			i += title
+
+		case depth == 0 && indent == 0 && isTransition(text, p.lines, i):
+			record(markdown.BlockBreak)
+			i++
+
+		case explicitMarkup.MatchString(text):
+			next := p.indentedEnd(i+1, end, indent)
+			p.explicitBlock(i, next, kind, depth, record)
+			i = next
+
+		case anonymousTarget.MatchString(text):
+			next := p.indentedEnd(i+1, end, indent)
+			linekind.Fill(p.kinds, p.lines, linekind.Span(i, next), linekind.HTML)
+			i = next
+
+		case gridTableBorder.MatchString(strings.TrimSpace(text)):
+			next := p.gridTable(i, end)
+			record(markdown.BlockTable)
+			i = next
+
+		case simpleTableBorder.MatchString(strings.TrimSpace(text)):
+			next := p.simpleTable(i, end)
+			record(markdown.BlockTable)
+			i = next
+
+		case strings.HasPrefix(text, ">>>"):
+			next := p.paragraphEnd(i, end)
+			linekind.Fill(p.kinds, p.lines, linekind.Span(i, next), linekind.Code)
+			p.codeBlock(i, next)
+			record(markdown.BlockCode)
+			i = next
+
+		case i > start && indent > p.indent(p.previousLine(i, start)) && kind != linekind.List:
+			// An indented block after a paragraph is a block quote
+			next := p.indentedEnd(i+1, end, p.indent(p.previousLine(i, start)))
+			p.parseBlocks(i, next, kind, depth+1)
+			record(markdown.BlockQuote)
+			i = next
+
+		case p.skip[i] == 0 && i+1 < end && !p.blank(i+1) && p.indent(i+1) > indent:
+			// A single line followed by an indented block is a definition list item
+			next := p.indentedEnd(i+1, end, indent)
+			p.kinds[i] = linekind.List
+			p.inlineLines(i, i+1, markdown.SegmentList, false)
+			linekind.Fill(p.kinds, p.lines, linekind.Span(i+1, next), linekind.List)
+			p.parseBlocks(i+1, next, linekind.List, depth+1)
+			record(markdown.BlockList)
+			i = next
+
+		default:
+			i = p.paragraph(i, end, kind, depth, record)
+		}
+	}
+}
+
+// paragraph parses a paragraph starting at i and returns the line after it.
+// A paragraph ending in :: introduces a literal block.
+func (p *parser) paragraph(i, end int, kind linekind.Kind, depth int, record func(markdown.BlockKind)) int {
+	if lineBlockItem.MatchString(p.text(i)) {
+		return p.lineBlock(i, end, kind, record)
+	}
+	next := p.paragraphEnd(i, end)
+	last := p.lines[next-1]
+	literal := strings.HasSuffix(last, "::")
+
+	// "Text::" shows "Text:", "Text ::" and "::" alone show nothing
+	markerEnd := len(last)
+	if literal {
+		markerEnd = len(last) - 1
+		if trimmed := strings.TrimRight(last[:len(last)-2], " \t"); len(trimmed) < len(last)-2 || next-1 == i && strings.TrimSpace(p.text(i)) == "::" {
+			markerEnd = len(trimmed)
+		}
+	}
+
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, next), kind)
+	if strings.TrimSpace(p.text(i)) != "::" {
+		text := p.inlineParagraph(i, next, markerEnd, linekind.SegmentKind(kind), kind == linekind.Prose)
+		if kind != linekind.List {
+			p.result.Paragraphs = append(p.result.Paragraphs, markdown.Paragraph{
+				Line: i + 1,
+				Text: strings.Join(strings.Fields(text), " "),
+			})
+		}
+		if kind == linekind.List {
+			record(markdown.BlockList)
+		} else {
+			record(markdown.BlockParagraph)
+		}
+	}
+
+	if !literal {
+		return next
+	}
+	return p.literalBlock(next, end, p.indent(i), record)
+}
+
+// literalBlock parses the literal block after a paragraph ending in ::,
+// starting at or after line i. It is the following indented block, or a
+// run of quoted lines that start with punctuation. Returns the line after
+// it.
+func (p *parser) literalBlock(i, end, indent int, record func(markdown.BlockKind)) int {
+	j := i
+	for j < end && p.blank(j) {
+		j++
+	}
+	if j == end {
+		return i
+	}
+	var next int
+	switch {
+	case p.indent(j) > indent:
+		next = p.indentedEnd(j+1, end, indent)
+	case p.indent(j) == indent && strings.ContainsRune(adornmentChars, rune(p.text(j)[0])):
+		next = p.paragraphEnd(j, end)
+	default:
+		return i
+	}
+	linekind.Fill(p.kinds, p.lines, linekind.Span(j, next), linekind.Code)
+	p.codeBlock(j, next)
+	record(markdown.BlockCode)
+	return next
+}
+
+// lineBlock parses a line block, where each line starts with "| ", and
+// returns the line after it.
+func (p *parser) lineBlock(i, end int, kind linekind.Kind, record func(markdown.BlockKind)) int {
+	next := p.paragraphEnd(i, end)
+	for j := i; j < next; j++ {
+		if m := lineBlockItem.FindString(p.text(j)); m != "" {
+			p.skip[j] = p.start(j) + len(m)
+		}
+	}
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, next), kind)
+	text := p.inlineParagraph(i, next, len(p.lines[next-1]), linekind.SegmentKind(kind), kind == linekind.Prose)
+	if kind != linekind.List {
+		p.result.Paragraphs = append(p.result.Paragraphs, markdown.Paragraph{
+			Line: i + 1,
+			Text: strings.Join(strings.Fields(text), " "),
+		})
+	}
+	record(markdown.BlockParagraph)
+	return next
+}
+
+// listMarker returns the list type and marker of a list item at line i:
+// bullet, enumerated, field, or option. Returns "" for other lines.
+func (p *parser) listMarker(i, end int) (item, marker string) {
+	text := p.text(i)
+	switch {
+	case bulletItem.MatchString(text) && !isAdornment(strings.TrimSpace(text)):
+		return "bullet", bulletItem.FindString(text)
+	case enumeratedItem.MatchString(text):
+		// The next line must be blank, indented, or another item, so that
+		// a sentence starting "A. Smith" stays a paragraph
+		if i+1 < end && !p.blank(i+1) && p.indent(i+1) <= p.indent(i) && !enumeratedItem.MatchString(p.text(i+1)) {
+			return "", ""
+		}
+		return "enumerated", enumeratedItem.FindString(text)
+	case fieldItem.MatchString(text):
+		return "field", fieldItem.FindString(text)
+	case optionItem.MatchString(text):
+		return "option", optionItem.FindString(text)
+	}
+	return "", ""
+}
+
+// listItem parses a list item in lines [i, end). The marker is skipped and
+// the item body is parsed as list content.
+func (p *parser) listItem(i, end int, marker string, depth int) {
+	start := p.start(i)
+	if strings.HasPrefix(marker, ":") {
+		// The field name reads as a list term
+		p.inlineRange(i, start+1, start+len(strings.TrimRight(marker, " \t"))-1, markdown.SegmentList, false)
+	}
+	p.skip[i] = start + len(marker)
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, end), linekind.List)
+	p.parseBlocks(i, end, linekind.List, depth+1)
+}
+
+// docinfo reports whether a field list here is the document's metadata:
+// the first block, or the first after the document title.
+func (p *parser) docinfo() bool {
+	return p.result.Frontmatter == nil &&
+		(len(p.blocks) == 0 || (len(p.blocks) == 1 && p.blocks[0].Kind == markdown.BlockHeading))
+}
+
+// docinfoField records a field of the document's metadata in lines
+// [i, end).
+func (p *parser) docinfoField(i, end int, marker string) {
+	fm := p.result.Frontmatter
+	if fm == nil {
+		fm = &markdown.Frontmatter{
+			Format: FrontmatterFields,
+			Fields: make(map[string]any),
+			Lines:  make(map[string]int),
+		}
+		p.result.Frontmatter = fm
+	}
+	name := fieldItem.FindStringSubmatch(p.text(i))[1]
+	value := []string{strings.TrimSpace(p.text(i)[len(marker):])}
+	for j := i + 1; j < end; j++ {
+		value = append(value, strings.TrimSpace(p.lines[j]))
+	}
+	fm.Fields[name] = strings.Join(strings.Fields(strings.Join(value, " ")), " ")
+	fm.Lines[name] = i + 1
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, end), linekind.Frontmatter)
+}
+
+// explicitBlock parses an explicit markup block in lines [i, end): a
+// directive, footnote, comment, or hyperlink target.
+func (p *parser) explicitBlock(i, end int, kind linekind.Kind, depth int, record func(markdown.BlockKind)) {
+	text := p.text(i)
+	if m := directive.FindStringSubmatch(text); m != nil {
+		p.directive(i, end, strings.ToLower(m[1]), strings.TrimSpace(m[2]), kind, depth, record)
+		return
+	}
+	if m := footnote.FindString(text); m != "" {
+		// Footnote and citation text reads as prose
+		p.skip[i] = p.start(i) + len(m)
+		linekind.Fill(p.kinds, p.lines, linekind.Span(i, end), kind)
+		p.parseBlocks(i, end, kind, depth+1)
+		return
+	}
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, end), linekind.HTML) // Comments, targets, and substitutions
+}
+
+// directive parses a directive in lines [i, end). Its options are indented
+// :name: lines right after the header; its content follows.
+func (p *parser) directive(i, end int, name, argument string, kind linekind.Kind, depth int, record func(markdown.BlockKind)) {
+	body := i + 1
+	for body < end && !p.blank(body) {
+		body++ // Argument continuation and option lines
+	}
+	for body < end && p.blank(body) {
+		body++
+	}
+	whole := linekind.Span(i, end)
+
+	_, local, _ := strings.Cut(name, ":")
+	if local == "" {
+		local = name
+	}
+	switch {
+	case admonitionDirectives[local]:
+		title := ""
+		if local == "admonition" {
+			title = argument
+		}
+		p.result.Admonitions = append(p.result.Admonitions, markdown.Admonition{
+			Line:  i + 1,
+			Type:  local,
+			Title: title,
+		})
+		linekind.Fill(p.kinds, p.lines, whole, linekind.Admonition)
+		record(markdown.BlockAdmonition)
+
+	case codeDirectives[local]:
+		linekind.Fill(p.kinds, p.lines, whole, linekind.Code)
+		if body < end {
+			p.codeBlock(body, end)
+		}
+		record(markdown.BlockCode)
+
+	case tableDirectives[local]:
+		linekind.Fill(p.kinds, p.lines, whole, linekind.Table)
+		p.directiveTable(local, body, end)
+		record(markdown.BlockTable)
+
+	case local == "image" || local == "figure":
+		p.result.Images++
+		linekind.Fill(p.kinds, p.lines, linekind.Span(i, body), linekind.Prose)
+		if body < end {
+			p.parseBlocks(body, end, kind, depth+1) // Figure caption and legend
+		}
+		record(markdown.BlockParagraph)
+
+	case markupDirectives[local]:
+		linekind.Fill(p.kinds, p.lines, whole, linekind.HTML)
+
+	default:
+		// Containers, topics, and domain directives hold ordinary content
+		linekind.Fill(p.kinds, p.lines, linekind.Span(i, body), linekind.HTML)
+		if body < end {
+			p.parseBlocks(body, end, kind, depth+1)
+		}
+		record(markdown.BlockParagraph)
+	}
+}
+
+// directiveTable records the cells of a table directive's content in lines
+// [start, end).
+func (p *parser) directiveTable(name string, start, end int) {
+	switch name {
+	case "list-table":
+		p.result.Tables++
+		for i := start; i < end; i++ {
+			from := p.start(i)
+			for {
+				m := bulletItem.FindString(p.lines[i][from:])
+				if m == "" {
+					break
+				}
+				from += len(m)
+			}
+			p.cell(i, from, len(p.lines[i]))
+		}
+	case "csv-table":
+		p.result.Tables++
+		for i := start; i < end; i++ {
+			p.cells(i, p.start(i), ',')
+		}
+	default:
+		for i := start; i < end; {
+			switch trimmed := strings.TrimSpace(p.lines[i]); {
+			case gridTableBorder.MatchString(trimmed):
+				i = p.gridTable(i, end)
+			case simpleTableBorder.MatchString(trimmed):
+				i = p.simpleTable(i, end)
+			default:
+				i++
+			}
+		}
+	}
+}
+
+// gridTable records a grid table starting at its top border on line i and
+// returns the line after it.
+func (p *parser) gridTable(i, end int) int {
+	next := i
+	for next < end && !p.blank(next) && strings.ContainsAny(p.text(next)[:1], "+|") {
+		next++
+	}
+	p.result.Tables++
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, next), linekind.Table)
+	for j := i; j < next; j++ {
+		if strings.HasPrefix(p.text(j), "|") {
+			p.cells(j, p.start(j)+1, '|')
+		}
+	}
+	return next
+}
+
+// simpleTable records a simple table starting at its top border on line i
+// and returns the line after it. The table ends at a border followed by a
+// blank line.
+func (p *parser) simpleTable(i, end int) int {
+	top := strings.TrimSpace(p.text(i))
+	columns := columnStarts(top)
+	next := -1
+	for j := i + 1; j < end; j++ {
+		if simpleTableBorder.MatchString(strings.TrimSpace(p.lines[j])) && (j+1 == end || p.blank(j+1)) {
+			next = j + 1
+			break
+		}
+	}
+	if next < 0 {
+		next = p.paragraphEnd(i, end) // Unclosed; stop at the first blank line
+	}
+	p.result.Tables++
+	linekind.Fill(p.kinds, p.lines, linekind.Span(i, next), linekind.Table)
+	for j := i + 1; j < next; j++ {
+		trimmed := strings.TrimSpace(p.lines[j])
+		if p.blank(j) || len(strings.Trim(trimmed, "=- ")) == 0 {
+			continue // Borders and column span underlines
+		}
+		p.simpleRow(j, p.start(i), columns)
+	}
+	return next
+}
+
+// columnStarts returns the rune offsets where the columns of a simple
+// table border start.
+func columnStarts(border string) []int {
+	var starts []int
+	for i, r := range []rune(border) {
+		if r == '=' && (i == 0 || border[i-1] == ' ') {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// simpleRow records the cells of a simple table row. Columns start at the
+// given rune offsets from the table's left edge at byte indent.
+func (p *parser) simpleRow(i, indent int, columns []int) {
+	line := p.lines[i]
+	bounds := make([]int, 0, len(columns)+1)
+	runes := 0
+	for b := range line {
+		if b < indent {
+			continue
+		}
+		for len(bounds) < len(columns) && runes >= columns[len(bounds)] {
+			bounds = append(bounds, b)
+		}
+		runes++
+	}
+	bounds = append(bounds, len(line))
+	for k := 0; k+1 < len(bounds); k++ {
+		p.cell(i, bounds[k], bounds[k+1])
+	}
+}
+
+// cell records the text of a table cell in the bytes [from, to) of line i,
+// without the padding around it.
+func (p *parser) cell(i, from, to int) {
+	text := p.lines[i][from:to]
+	from += len(text) - len(strings.TrimLeft(text, " \t"))
+	to -= len(text) - len(strings.TrimRight(text, " \t"))
+	if from < to {
+		p.inlineRange(i, from, to, markdown.SegmentTable, false)
+	}
+}
+
+// cells records the cells of line i from byte from onward, split at sep.
+// CSV values may be quoted to hold the separator.
+func (p *parser) cells(i, from int, sep byte) {
+	line := p.lines[i]
+	for from < len(line) {
+		start := from + len(line[from:]) - len(strings.TrimLeft(line[from:], " \t"))
+		if sep == ',' && start < len(line) && line[start] == '"' {
+			closing := start + 1
+			for closing < len(line) && (line[closing] != '"' || strings.HasPrefix(line[closing:], `""`)) {
+				if line[closing] == '"' {
+					closing++ // Doubled quote
+				}
+				closing++
+			}
+			p.cell(i, start+1, min(closing, len(line)))
+			from = closing + 1
+			if k := strings.IndexByte(line[min(from, len(line)):], sep); k >= 0 {
+				from += k + 1
+			} else {
+				from = len(line)
+			}
+			continue
+		}
+		to := strings.IndexByte(line[from:], sep)
+		if to < 0 {
+			to = len(line)
+		} else {
+			to += from
+		}
+		p.cell(i, from, to)
+		from = to + 1
+	}
+}
+
+// codeBlock records the lines [start, end) as a code block, with the
+// common indentation removed.
+func (p *parser) codeBlock(start, end int) {
+	for end > start && p.blank(end-1) {
+		end--
+	}
+	if end == start {
+		return
+	}
+	indent := -1
+	for i := start; i < end; i++ {
+		if !p.blank(i) && (indent < 0 || p.start(i) < indent) {
+			indent = p.start(i)
+		}
+	}
+	var code strings.Builder
+	for i := start; i < end; i++ {
+		if len(p.lines[i]) > indent {
+			code.WriteString(p.lines[i][indent:])
+		}
+		code.WriteString("\n")
+	}
+	p.result.CodeBlocks = append(p.result.CodeBlocks, code.String())
+}
+
+// titleLines returns the number of lines in the section title at line i:
+// 2 for an underlined title, 3 with an overline. Returns 0 if line i does
+// not start a title.
+func (p *parser) titleLines(i, end int) int {
+	line := strings.TrimRight(p.lines[i], " \t")
+	if isAdornment(line) {
+		// Overline, title, and underline of the same length
+		if i+2 < end && !p.blank(i+1) && strings.TrimRight(p.lines[i+2], " \t") == line {
+			return 3
+		}
+		return 0
+	}
+	if i+1 >= end {
+		return 0
+	}
+	under := strings.TrimRight(p.lines[i+1], " \t")
+	if !isAdornment(under) {
+		return 0
+	}
+	// An underline shorter than the title must be at least four characters
+	if len(under) < 4 && len(under) < utf8.RuneCountInString(strings.TrimSpace(line)) {
+		return 0
+	}
+	return 2
+}
+
+// section records the section title of n lines at line i. Titles with an
+// overline are a different style from those with only an underline.
+func (p *parser) section(i, n int) {
+	if n == 3 {
+		linekind.Fill(p.kinds, p.lines, []int{i, i + 2}, linekind.Prose)
+		p.heading(i+1, "o"+p.lines[i][:1])
+		return
+	}
+	p.kinds[i+1] = linekind.Prose
+	p.heading(i, p.lines[i+1][:1])
+}
+
+// heading records the section title text on line i. The level comes from
+// the order in which title styles first appear.
+func (p *parser) heading(i int, style string) {
+	level := 0
+	for k, s := range p.styles {
+		if s == style {
+			level = k + 1
+		}
+	}
+	if level == 0 {
+		p.styles = append(p.styles, style)
+		level = len(p.styles)
+	}
+	p.kinds[i] = linekind.Prose
+	text := p.inlineParagraph(i, i+1, len(p.lines[i]), markdown.SegmentHeading, true)
+	p.result.Headings = append(p.result.Headings, markdown.Heading{
+		Line:  i + 1,
+		Level: min(level, 6),
+		Text:  strings.Join(strings.Fields(text), " "),
+	})
+}
+
+// inlineParagraph records the visible text of lines [i, next) as segments,
+// and as prose when prose is set. The last line ends at byte lastEnd.
+// Returns the visible text, including inline literals.
+func (p *parser) inlineParagraph(i, next, lastEnd int, kind markdown.SegmentKind, prose bool) string {
+	var joined strings.Builder
+	starts := make([]int, 0, next-i) // Start of each line in joined
+	froms := make([]int, 0, next-i)  // Byte in the line where its text starts
+	for j := i; j < next; j++ {
+		if j > i {
+			joined.WriteByte('\n')
+		}
+		from, to := p.start(j), len(p.lines[j])
+		if j == next-1 {
+			to = max(from, lastEnd)
+		}
+		starts = append(starts, joined.Len())
+		froms = append(froms, from)
+		joined.WriteString(p.lines[j][from:to])
+	}
+	text := joined.String()
+
+	var visible strings.Builder
+	for _, r := range inlineRuns(text) {
+		visible.WriteString(text[r.start:r.end])
+		if r.code {
+			continue
+		}
+		// Split the run into one segment per line
+		for k := range starts {
+			lineEnd := len(text)
+			if k+1 < len(starts) {
+				lineEnd = starts[k+1] - 1
+			}
+			from, to := max(r.start, starts[k]), min(r.end, lineEnd)
+			if from >= to || strings.TrimSpace(text[from:to]) == "" {
+				continue
+			}
+			p.segment(i+k, froms[k]+from-starts[k], text[from:to], kind)
+		}
+		if prose {
+			p.prose.WriteString(text[r.start:r.end])
+		}
+	}
+	if prose {
+		p.prose.WriteString(" ")
+	}
+	return strings.ReplaceAll(visible.String(), "\n", " ")
+}
+
+// inlineLines is inlineParagraph for whole lines.
+func (p *parser) inlineLines(i, next int, kind markdown.SegmentKind, prose bool) string {
+	return p.inlineParagraph(i, next, len(p.lines[next-1]), kind, prose)
+}
+
+// inlineRange records the visible text of the bytes [from, to) of line i
+// as segments.
+func (p *parser) inlineRange(i, from, to int, kind markdown.SegmentKind, prose bool) {
+	line := p.lines[i][from:to]
+	for _, r := range inlineRuns(line) {
+		if r.code || strings.TrimSpace(line[r.start:r.end]) == "" {
+			continue
+		}
+		p.segment(i, from+r.start, line[r.start:r.end], kind)
+		if prose {
+			p.prose.WriteString(line[r.start:r.end])
+		}
+	}
+	if prose {
+		p.prose.WriteString(" ")
+	}
+}
+
+// segment records text found at byte pos of line i.
+func (p *parser) segment(i, pos int, text string, kind markdown.SegmentKind) {
+	p.result.Segments = append(p.result.Segments, markdown.Segment{
+		Text:   text,
+		Line:   i + 1,
+		Column: p.cursor.Column(p.lines, i, pos),
+		Offset: p.offsets[i] + pos,
+		Kind:   kind,
+	})
+}
+
+// text returns line i after its list marker and indentation.
+func (p *parser) text(i int) string {
+	return p.lines[i][p.start(i):]
+}
+
+// start returns the byte where the text of line i starts, after any list
+// marker and indentation.
+func (p *parser) start(i int) int {
+	line := p.lines[i]
+	j := p.skip[i]
+	for j < len(line) && (line[j] == ' ' || line[j] == '\t') {
+		j++
+	}
+	return j
+}
+
+// indent returns the column where the text of line i starts. List markers
+// count as indentation, and tabs advance to the next multiple of eight.
+func (p *parser) indent(i int) int {
+	column := 0
+	for _, r := range p.lines[i][:p.start(i)] {
+		if r == '\t' {
+			column += 8 - column%8
+		} else {
+			column++
+		}
+	}
+	return column
+}
+
+// blank reports whether line i has no text after its list marker.
+func (p *parser) blank(i int) bool {
+	return p.start(i) == len(p.lines[i])
+}
+
+// indentedEnd returns the end of the indented block starting at line i:
+// the first line in [i, end) with text at or left of column indent.
+// Trailing blank lines are not part of the block.
+func (p *parser) indentedEnd(i, end, indent int) int {
+	from := i
+	for i < end && (p.blank(i) || p.indent(i) > indent) {
+		i++
+	}
+	for i > from && p.blank(i-1) {
+		i--
+	}
+	return i
+}
+
+// paragraphEnd returns the line after the paragraph starting at i: the next
+// blank line or line with a different indent. After a list marker, the
+// second line sets the indent, since field and footnote bodies need not
+// line up with the marker text.
+func (p *parser) paragraphEnd(i, end int) int {
+	indent := p.indent(i)
+	if p.skip[i] > 0 && i+1 < end && !p.blank(i+1) {
+		indent = p.indent(i + 1)
+	}
+	for i++; i < end && !p.blank(i) && p.indent(i) == indent && p.skip[i] == 0; i++ {
+	}
+	return i
+}
+
+// previousLine returns the last line with text before line i, or start.
+func (p *parser) previousLine(i, start int) int {
+	for j := i - 1; j > start; j-- {
+		if !p.blank(j) {
+			return j
+		}
+	}
+	return start
+}
+
+// isAdornment reports whether a line is a section adornment: one
+// punctuation character repeated at least twice.
+func isAdornment(line string) bool {
+	if len(line) < 2 || !strings.ContainsRune(adornmentChars, rune(line[0])) {
+		return false
+	}
+	return strings.Count(line, line[:1]) == len(line)
+}
+
+// isTransition reports whether line i is a transition: an adornment of at
+// least four characters between blank lines.
+func isTransition(text string, lines []string, i int) bool {
+	if len(text) < 4 || !isAdornment(text) {
+		return false
+	}
+	return (i == 0 || strings.TrimSpace(lines[i-1]) == "") &&
+		(i+1 == len(lines) || strings.TrimSpace(lines[i+1]) == "")
+}
@@ -0,0 +1,360 @@
+package rst
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adaptive-enforcement-lab/readability/pkg/markdown"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantProse string
+		wantCode  []string
+		wantAdm   []markdown.Admonition
+	}{
+		{
+			name:      "paragraphs",
+			content:   "First line\nof a paragraph.\n\nSecond paragraph.\n",
+			wantProse: "First line of a paragraph. Second paragraph.",
+		},
+		{
+			name:      "inline markup",
+			content:   "Use **strong**, *emphasis* and ``code`` with a `link <https://example.com>`_ to\n:ref:`the setup <setup>` and :func:`run` [1]_ |version|.\n",
+			wantProse: "Use strong, emphasis and with a link to the setup and .",
+		},
+		{
+			name:      "lone marks are text",
+			content:   "Compute 2 * 3 in snake_case with C++.\n",
+			wantProse: "Compute 2 * 3 in snake_case with C++.",
+		},
+		{
+			name:      "literal blocks",
+			content:   "Run this::\n\n    pip install sdk\n\nOr this:\n\n::\n\n    pip install -e .\n\nText.\n",
+			wantProse: "Run this: Or this: Text.",
+			wantCode:  []string{"pip install sdk\n", "pip install -e .\n"},
+		},
+		{
+			name:      "expanded literal marker",
+			content:   "Example ::\n\n    code\n",
+			wantProse: "Example",
+			wantCode:  []string{"code\n"},
+		},
+		{
+			name:      "code directives",
+			content:   ".. code-block:: python\n   :linenos:\n\n   import sdk\n\n   sdk.run()\n\n>>> print(1)\n1\n\nText.\n",
+			wantProse: "Text.",
+			wantCode:  []string{"import sdk\n\nsdk.run()\n", ">>> print(1)\n1\n"},
+		},
+		{
+			name:      "admonitions",
+			content:   ".. note:: A short note.\n\n.. warning::\n\n   A longer warning.\n\n.. admonition:: Read This\n\n   Body.\n\nText.\n",
+			wantProse: "Text.",
+			wantAdm: []markdown.Admonition{
+				{Line: 1, Type: "note"},
+				{Line: 3, Type: "warning"},
+				{Line: 7, Type: "admonition", Title: "Read This"},
+			},
+		},
+		{
+			name:      "containers hold prose",
+			content:   ".. py:function:: run(name)\n\n   Runs the client.\n\n.. only:: html\n\n   Shown in HTML.\n",
+			wantProse: "Runs the client. Shown in HTML.",
+		},
+		{
+			name:      "block quote",
+			content:   "Text.\n\n    Quoted text.\n",
+			wantProse: "Text. Quoted text.",
+		},
+		{
+			name:      "comments, targets and markup directives are dropped",
+			content:   ".. A comment\n   on two lines.\n\n.. _target:\n\n.. toctree::\n\n   intro\n\n__ https://example.com\n\nShown text.\n",
+			wantProse: "Shown text.",
+		},
+		{
+			name:      "lists and tables are not prose",
+			content:   "- One\n- Two\n\n1. First\n2. Second\n\n:param name: The name.\n\nterm\n   Definition.\n\n=====  =====\nA      B\n=====  =====\n\nText.\n",
+			wantProse: "Text.",
+		},
+		{
+			name:      "enumerated sentence stays a paragraph",
+			content:   "A. Smith wrote\nthis sentence.\n",
+			wantProse: "A. Smith wrote this sentence.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if result.Prose != tt.wantProse {
+				t.Errorf("Prose = %q, want %q", result.Prose, tt.wantProse)
+			}
+			if tt.wantCode != nil && !reflect.DeepEqual(result.CodeBlocks, tt.wantCode) {
+				t.Errorf("CodeBlocks = %q, want %q", result.CodeBlocks, tt.wantCode)
+			}
+			if tt.wantAdm == nil {
+				tt.wantAdm = []markdown.Admonition{}
+			}
+			if !reflect.DeepEqual(result.Admonitions, tt.wantAdm) {
+				t.Errorf("Admonitions = %+v, want %+v", result.Admonitions, tt.wantAdm)
+			}
+		})
+	}
+}
+
+func TestParse_Headings(t *testing.T) {
+	content := "=====\nGuide\n=====\n\nIntro.\n\nSetup\n=====\n\n- Step\n\nInstall *now*\n-------------\n\n.. code-block:: sh\n\n   make\n\nDone\n====\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// Levels follow the order styles first appear; an overline makes a
+	// different style from the same underline alone
+	want := []markdown.Heading{
+		{Line: 2, Level: 1, Text: "Guide", Next: markdown.BlockParagraph},
+		{Line: 7, Level: 2, Text: "Setup", Next: markdown.BlockList},
+		{Line: 12, Level: 3, Text: "Install now", Next: markdown.BlockCode},
+		{Line: 19, Level: 2, Text: "Done"},
+	}
+	if !reflect.DeepEqual(result.Headings, want) {
+		t.Errorf("Headings = %+v, want %+v", result.Headings, want)
+	}
+}
+
+func TestParse_Docinfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantFM  map[string]any
+		wantLn  int // Line of the first field
+	}{
+		{
+			name:    "file-wide metadata",
+			content: ":orphan:\n:description: A short\n   guide.\n\nBody.\n",
+			wantFM:  map[string]any{"orphan": "", "description": "A short guide."},
+			wantLn:  1,
+		},
+		{
+			name:    "after the document title",
+			content: "Guide\n=====\n\n:author: Jane\n\nBody.\n",
+			wantFM:  map[string]any{"author": "Jane"},
+			wantLn:  4,
+		},
+		{
+			name:    "field list in the body",
+			content: "Guide\n=====\n\nBody.\n\n:param name: The name.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			fm := result.Frontmatter
+			if tt.wantFM == nil {
+				if fm != nil {
+					t.Errorf("Frontmatter = %+v, want nil", fm)
+				}
+				return
+			}
+			if fm == nil {
+				t.Fatal("Frontmatter = nil")
+			}
+			if fm.Format != FrontmatterFields {
+				t.Errorf("Format = %q, want %q", fm.Format, FrontmatterFields)
+			}
+			if !reflect.DeepEqual(fm.Fields, tt.wantFM) {
+				t.Errorf("Fields = %v, want %v", fm.Fields, tt.wantFM)
+			}
+			for name, line := range fm.Lines {
+				if line < tt.wantLn {
+					t.Errorf("Lines[%s] = %d, want at least %d", name, line, tt.wantLn)
+				}
+			}
+			if result.Prose != "Guide Body." && result.Prose != "Body." {
+				t.Errorf("Prose = %q, want the fields left out", result.Prose)
+			}
+		})
+	}
+}
+
+func TestParse_LineComposition(t *testing.T) {
+	content := strings.Join([]string{
+		":orphan:",           // 1 frontmatter
+		"",                   // 2 empty
+		"Guide",              // 3 prose
+		"=====",              // 4 prose
+		"",                   // 5 empty
+		".. comment",         // 6 html
+		"",                   // 7 empty
+		"- One",              // 8 list
+		"- Two",              // 9 list
+		"",                   // 10 empty
+		".. code-block:: sh", // 11 code
+		"",                   // 12 code
+		"   make",            // 13 code
+		"",                   // 14 empty
+		".. note:: A note.",  // 15 admonition
+		"",                   // 16 empty
+		"+---+---+",          // 17 table
+		"| A | B |",          // 18 table
+		"+---+---+",          // 19 table
+	}, "\n")
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := [...]int{result.TotalLines, result.EmptyLines, result.FrontmatterLines, result.HTMLLines,
+		result.ListLines, result.CodeLines, result.AdmonitionLines, result.TableLines}
+	want := [...]int{19, 6, 1, 1, 2, 3, 1, 3}
+	if got != want {
+		t.Errorf("Total, Empty, Frontmatter, HTML, List, Code, Admonition, Table lines = %v, want %v", got, want)
+	}
+	if result.Tables != 1 {
+		t.Errorf("Tables = %d, want 1", result.Tables)
+	}
+}
+
+func TestParse_Tables(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "grid table",
+			content: "+------+-------+\n| Name | Value |\n+======+=======+\n| a    | b     |\n+------+-------+\n",
+			want:    []string{"Name", "Value", "a", "b"},
+		},
+		{
+			name:    "simple table",
+			content: "=====  ======\nCol A  Col B\n=====  ======\nx      y z\n=====  ======\n",
+			want:    []string{"Col A", "Col B", "x", "y z"},
+		},
+		{
+			name:    "list table",
+			content: ".. list-table:: Options\n   :header-rows: 1\n\n   * - Flag\n     - Meaning\n",
+			want:    []string{"Flag", "Meaning"},
+		},
+		{
+			name:    "csv table",
+			content: ".. csv-table::\n\n   a, \"b, c\"\n",
+			want:    []string{"a", "b, c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var cells []string
+			for _, s := range result.Segments {
+				if s.Kind == markdown.SegmentTable {
+					cells = append(cells, s.Text)
+				}
+			}
+			if !reflect.DeepEqual(cells, tt.want) {
+				t.Errorf("cells = %q, want %q", cells, tt.want)
+			}
+			if result.Tables != 1 {
+				t.Errorf("Tables = %d, want 1", result.Tables)
+			}
+		})
+	}
+}
+
+func TestParse_Segments(t *testing.T) {
+	content := "Café\n====\n\nSee *the*\n`docs <https://example.com>`_.\n\n:param x: Item ``x`` here\n"
+
+	result, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := []markdown.Segment{
+		{Text: "Café", Line: 1, Column: 1, Offset: 0, Kind: markdown.SegmentHeading},
+		{Text: "See ", Line: 4, Column: 1, Offset: 12, Kind: markdown.SegmentProse},
+		{Text: "the", Line: 4, Column: 6, Offset: 17, Kind: markdown.SegmentProse},
+		{Text: "docs", Line: 5, Column: 2, Offset: 23, Kind: markdown.SegmentProse},
+		{Text: ".", Line: 5, Column: 30, Offset: 51, Kind: markdown.SegmentProse},
+		{Text: "param x", Line: 7, Column: 2, Offset: 55, Kind: markdown.SegmentList},
+		{Text: "Item ", Line: 7, Column: 11, Offset: 64, Kind: markdown.SegmentList},
+		{Text: " here", Line: 7, Column: 21, Offset: 74, Kind: markdown.SegmentList},
+	}
+	if !reflect.DeepEqual(result.Segments, want) {
+		t.Errorf("Segments =\n%+v\nwant\n%+v", result.Segments, want)
+	}
+}
+
+func TestInlineRuns(t *testing.T) {
+	tests := []struct {
+		text string
+		want string // Visible text, with code in brackets
+	}{
+		{"plain text", "plain text"},
+		{"*emphasis* and **strong**", "emphasis and strong"},
+		{"a ``literal`` span", "a [literal] span"},
+		{"``*not emphasis*``", "[*not emphasis*]"},
+		{"call :func:`run` or :py:meth:`Client.close`", "call [run] or [Client.close]"},
+		{"see :ref:`intro` and :doc:`the guide <guide>`", "see  and the guide"},
+		{":abbr:`LIFO (last-in, first-out)`", "LIFO"},
+		{"`Example <https://example.com>`_ and `<https://x.org>`__", "Example and https://x.org"},
+		{"Python_ and `default role`", "Python and default role"},
+		{"a footnote [1]_ and |sub| here", "a footnote  and  here"},
+		{"an _`inline target`", "an inline target"},
+		{"wrapped *emphasis\nacross* lines", "wrapped emphasis\nacross lines"},
+		{`escaped \*star\*`, "escaped *star*"},
+		{"2 * 3 and snake_case_name", "2 * 3 and snake_case_name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var got strings.Builder
+			for _, r := range inlineRuns(tt.text) {
+				if r.code {
+					got.WriteString("[" + tt.text[r.start:r.end] + "]")
+				} else {
+					got.WriteString(tt.text[r.start:r.end])
+				}
+			}
+			if got.String() != tt.want {
+				t.Errorf("inlineRuns(%q) = %q, want %q", tt.text, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte("Title\n=====\n\n:orphan:\n\nText with *emphasis* and `a link`_.\n"))
+	f.Add([]byte(".. code-block:: go\n\n   code\n\nText::\n\n   literal\n"))
+	f.Add([]byte(".. note:: note\n\n.. warning::\n\n   warn\n"))
+	f.Add([]byte("+---+---+\n| a | b |\n+---+---+\n\n===  ===\nx    y\n===  ===\n"))
+	f.Add([]byte("- one\n\n  - nested\n\n:field: body\n    more\n"))
+	f.Add([]byte("====\n"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		result, err := Parse(content)
+		if err != nil {
+			return
+		}
+		for _, s := range result.Segments {
+			if s.Offset < 0 || s.Offset+len(s.Text) > len(content) {
+				t.Fatalf("segment %+v out of range for %d bytes", s, len(content))
+			}
+			if string(content[s.Offset:s.Offset+len(s.Text)]) != s.Text {
+				t.Fatalf("segment %+v does not match content", s)
+			}
+		}
+	})
+}
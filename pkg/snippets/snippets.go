@@ -0,0 +1,285 @@
+// Package snippets expands the include directives of pymdownx.snippets, so
+// a page is scored on the text readers see rather than on the directive.
+// Both forms are read: a single line such as --8<-- "intro.md", and a block
+// that lists one file per line between two --8<-- lines. A snippet may
+// select lines ("file.md:3:10", "file.md:1:3,8:9") or a named section
+// ("file.md:intro") marked with --8<-- [start:intro] and
+// --8<-- [end:intro]. Snippets are expanded recursively; a snippet that
+// includes itself, directly or through others, is skipped.
+package snippets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned for a snippet file in none of the base paths.
+	ErrNotFound = errors.New("file not found in the snippet base paths")
+
+	// ErrNoSection is returned for a section the snippet file does not mark.
+	ErrNoSection = errors.New("section not found")
+
+	// ErrCycle is returned for a snippet that is already being included.
+	ErrCycle = errors.New("snippet includes itself")
+)
+
+var (
+	// inlineDirective matches a single-line include: --8<-- "file.md"
+	inlineDirective = regexp.MustCompile(`^([ \t]*)-+8<-+[ \t]+("(?:\\"|[^"])+"|'(?:\\'|[^'])+')[ \t]*$`)
+
+	// blockMarker matches the line that opens or closes a block of includes.
+	blockMarker = regexp.MustCompile(`^([ \t]*)-+8<-+[ \t]*$`)
+
+	// escaped matches a directive escaped with a semicolon, which is shown
+	// as written without the semicolon.
+	escaped = regexp.MustCompile(`^[ \t]*;+-+8<-+`)
+
+	// sectionMarker matches a line marking the start or end of a section.
+	// Markers may sit in a comment of a source file: # --8<-- [start:main]
+	// A marker escaped with a semicolon is shown without the semicolon.
+	sectionMarker = regexp.MustCompile(`(;*)-+8<-+[ \t]+\[(start|end):([-_0-9A-Za-z]+)\]`)
+
+	// target splits a snippet into its path and line ranges or section.
+	target = regexp.MustCompile(`^(.*?)(?::([0-9]*(?::[0-9]*)?(?:,[0-9]*(?::[0-9]*)?)*)|:([A-Za-z][-_0-9A-Za-z]*))?$`)
+)
+
+// Options configures Expand.
+type Options struct {
+	BasePaths []string // Directories snippet paths are relative to, tried in order (default ".")
+}
+
+// Source is where a line of an expanded page comes from.
+type Source struct {
+	File   string // Page or snippet file holding the line
+	Line   int    // Line number in that file (1-based)
+	Indent int    // Bytes of indentation added in front of the line by the directive
+}
+
+// Sources maps the lines of an expanded page to their source, in order.
+type Sources []Source
+
+// Locate returns the source of a line of the expanded page. Lines past the
+// end belong to the last line.
+func (s Sources) Locate(line int) Source {
+	if len(s) == 0 {
+		return Source{}
+	}
+	return s[min(max(line, 1), len(s))-1]
+}
+
+// Problem is a directive that could not be expanded. Its line is left out
+// of the page, as pymdownx.snippets leaves it out.
+type Problem struct {
+	Source        // Where the directive is
+	Target string // Snippet as written, such as "intro.md:2:5"
+	Err    error  // ErrNotFound, ErrNoSection, ErrCycle, or a read error
+}
+
+// expander builds the expanded page.
+type expander struct {
+	basePaths []string
+	lines     []string
+	sources   Sources
+	problems  []Problem
+	including []string // Absolute paths of the files being expanded, for cycle checks
+}
+
+// Expand replaces the snippet directives of a page with the snippet text.
+// Sources is nil when the page has no directives, and content is returned
+// unchanged. Snippets given by URL are not downloaded; their directives
+// are left out.
+func Expand(path string, content []byte, opts Options) ([]byte, Sources, []Problem) {
+	if !strings.Contains(string(content), "8<-") {
+		return content, nil, nil
+	}
+
+	e := &expander{basePaths: opts.BasePaths}
+	if len(e.basePaths) == 0 {
+		e.basePaths = []string{"."}
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		e.including = append(e.including, abs)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	numbers := make([]int, len(lines))
+	for i := range lines {
+		numbers[i] = i + 1
+	}
+	e.expand(path, lines, numbers, "")
+	return []byte(strings.Join(e.lines, "\n")), e.sources, e.problems
+}
+
+// expand adds lines of a file to the page, with indent in front of each,
+// and expands the directives among them. numbers holds the line number of
+// each line in the file.
+func (e *expander) expand(file string, lines []string, numbers []int, indent string) {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		at := Source{File: file, Line: numbers[i], Indent: len(indent)}
+
+		if m := inlineDirective.FindStringSubmatch(line); m != nil {
+			e.include(unquote(m[2]), indent+m[1], at)
+			continue
+		}
+		if m := blockMarker.FindStringSubmatch(line); m != nil {
+			// Every line up to the closing marker names a snippet, except
+			// blank lines and lines commented out with a semicolon
+			for i++; i < len(lines) && !blockMarker.MatchString(lines[i]); i++ {
+				name := strings.TrimSpace(lines[i])
+				if name != "" && !strings.HasPrefix(name, ";") {
+					e.include(name, indent+m[1], Source{File: file, Line: numbers[i], Indent: len(indent)})
+				}
+			}
+			continue
+		}
+		if m := sectionMarker.FindStringSubmatchIndex(line); m != nil {
+			if m[2] == m[3] {
+				continue
+			}
+			line = line[:m[2]] + " " + line[m[2]+1:]
+		} else if escaped.MatchString(line) {
+			// Keep the line length, so columns stay where they are
+			line = strings.Replace(line, ";", " ", 1)
+		}
+
+		if line != "" {
+			line = indent + line
+		}
+		e.lines = append(e.lines, line)
+		e.sources = append(e.sources, at)
+	}
+}
+
+// include expands one snippet in place of the directive at.
+func (e *expander) include(name, indent string, at Source) {
+	if strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+		return
+	}
+	m := target.FindStringSubmatch(name)
+	problem := func(err error) {
+		e.problems = append(e.problems, Problem{Source: at, Target: name, Err: err})
+	}
+
+	path, err := e.resolve(m[1])
+	if err != nil {
+		problem(err)
+		return
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		problem(err)
+		return
+	}
+	for _, f := range e.including {
+		if f == abs {
+			problem(ErrCycle)
+			return
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		problem(err)
+		return
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1] // Final line break
+	}
+	var numbers []int
+	switch {
+	case m[3] != "":
+		lines, numbers = section(lines, m[3])
+		if lines == nil {
+			problem(ErrNoSection)
+			return
+		}
+	case m[2] != "":
+		lines, numbers = lineRanges(lines, m[2])
+	default:
+		numbers = make([]int, len(lines))
+		for i := range lines {
+			numbers[i] = i + 1
+		}
+	}
+
+	e.including = append(e.including, abs)
+	e.expand(path, lines, numbers, indent)
+	e.including = e.including[:len(e.including)-1]
+}
+
+// resolve returns the path of a snippet file in the first base path that
+// holds it.
+func (e *expander) resolve(name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", ErrNotFound // pymdownx.snippets only reads below its base paths
+	}
+	for _, base := range e.basePaths {
+		path := filepath.Join(base, name)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+// section returns the lines between the start and end markers of a named
+// section, with their line numbers. Nil means the section is not marked.
+// A section with no end marker runs to the end of the file.
+func section(lines []string, name string) ([]string, []int) {
+	var selected []string
+	var numbers []int
+	inside := false
+	for i, line := range lines {
+		if m := sectionMarker.FindStringSubmatch(line); m != nil && m[1] == "" && m[3] == name {
+			if m[2] == "end" && inside {
+				return selected, numbers
+			}
+			if m[2] == "start" && !inside {
+				inside = true
+				selected, numbers = []string{}, []int{}
+			}
+			continue
+		}
+		if inside {
+			selected = append(selected, line)
+			numbers = append(numbers, i+1)
+		}
+	}
+	return selected, numbers
+}
+
+// lineRanges returns the lines in a list of 1-based, inclusive ranges such
+// as "3:10" or "1:3,8:9", with their line numbers. A missing start means
+// the first line and a missing or zero end means the last line.
+func lineRanges(lines []string, spec string) ([]string, []int) {
+	selected := []string{}
+	numbers := []int{}
+	for _, r := range strings.Split(spec, ",") {
+		from, to, _ := strings.Cut(r, ":")
+		start, _ := strconv.Atoi(from)
+		end, _ := strconv.Atoi(to)
+		start = max(start, 1)
+		if end <= 0 || end > len(lines) {
+			end = len(lines)
+		}
+		for i := start; i <= end; i++ {
+			selected = append(selected, lines[i-1])
+			numbers = append(numbers, i)
+		}
+	}
+	return selected, numbers
+}
+
+// unquote removes the quotes around a snippet name and the backslashes
+// that escape quotes inside it.
+func unquote(quoted string) string {
+	q := quoted[:1]
+	return strings.ReplaceAll(quoted[1:len(quoted)-1], `\`+q, q)
+}
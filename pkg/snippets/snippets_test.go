@@ -0,0 +1,275 @@
+package snippets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files in a temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// snippetFiles is shared by the Expand tests.
+var snippetFiles = map[string]string{
+	"intro.md":        "Intro one.\nIntro two.\n",
+	"lines.md":        "L1\nL2\nL3\nL4\nL5\n",
+	"sections.md":     "Before.\n<!-- --8<-- [start:usage] -->\nUsage text.\n<!-- --8<-- [end:usage] -->\nAfter.\n",
+	"nested.md":       "Nested start.\n--8<-- \"intro.md\"\n",
+	"includes/tip.md": "A tip.\n",
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name  string
+		page  string
+		want  string
+		lines []string // File and line of each expanded line, "page:1" for the page
+	}{
+		{
+			name:  "inline",
+			page:  "Top.\n--8<-- \"intro.md\"\nEnd.",
+			want:  "Top.\nIntro one.\nIntro two.\nEnd.",
+			lines: []string{"page:1", "intro.md:1", "intro.md:2", "page:3"},
+		},
+		{
+			name:  "single quotes",
+			page:  "--8<-- 'includes/tip.md'",
+			want:  "A tip.",
+			lines: []string{"includes/tip.md:1"},
+		},
+		{
+			name:  "block",
+			page:  "--8<--\nintro.md\n\n; lines.md\nincludes/tip.md\n--8<--\nEnd.",
+			want:  "Intro one.\nIntro two.\nA tip.\nEnd.",
+			lines: []string{"intro.md:1", "intro.md:2", "includes/tip.md:1", "page:7"},
+		},
+		{
+			name:  "line range",
+			page:  `--8<-- "lines.md:2:3"`,
+			want:  "L2\nL3",
+			lines: []string{"lines.md:2", "lines.md:3"},
+		},
+		{
+			name:  "several line ranges",
+			page:  `--8<-- "lines.md:1:1,4:"`,
+			want:  "L1\nL4\nL5",
+			lines: []string{"lines.md:1", "lines.md:4", "lines.md:5"},
+		},
+		{
+			name:  "open start",
+			page:  `--8<-- "lines.md::2"`,
+			want:  "L1\nL2",
+			lines: []string{"lines.md:1", "lines.md:2"},
+		},
+		{
+			name:  "section",
+			page:  `--8<-- "sections.md:usage"`,
+			want:  "Usage text.",
+			lines: []string{"sections.md:3"},
+		},
+		{
+			name:  "section markers removed from whole file",
+			page:  `--8<-- "sections.md"`,
+			want:  "Before.\nUsage text.\nAfter.",
+			lines: []string{"sections.md:1", "sections.md:3", "sections.md:5"},
+		},
+		{
+			name:  "nested",
+			page:  `--8<-- "nested.md"`,
+			want:  "Nested start.\nIntro one.\nIntro two.",
+			lines: []string{"nested.md:1", "intro.md:1", "intro.md:2"},
+		},
+		{
+			name:  "indented",
+			page:  "- Item\n\n    --8<-- \"intro.md\"",
+			want:  "- Item\n\n    Intro one.\n    Intro two.",
+			lines: []string{"page:1", "page:2", "intro.md:1", "intro.md:2"},
+		},
+		{
+			name:  "escaped",
+			page:  `;--8<-- "intro.md"`,
+			want:  ` --8<-- "intro.md"`,
+			lines: []string{"page:1"},
+		},
+		{
+			name:  "escaped section marker",
+			page:  "Mark it with `;--8<-- [start:usage]`.\n--8<-- [end:usage]",
+			want:  "Mark it with ` --8<-- [start:usage]`.",
+			lines: []string{"page:1"},
+		},
+		{
+			name:  "url",
+			page:  "Top.\n--8<-- \"https://example.com/x.md\"",
+			want:  "Top.",
+			lines: []string{"page:1"},
+		},
+	}
+
+	dir := writeFiles(t, snippetFiles)
+	page := filepath.Join(dir, "page.md")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, sources, problems := Expand(page, []byte(tt.page), Options{BasePaths: []string{dir}})
+			if len(problems) > 0 {
+				t.Fatalf("Expand() problems = %+v", problems)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+
+			var lines []string
+			for _, s := range sources {
+				file := "page"
+				if s.File != page {
+					rel, _ := filepath.Rel(dir, s.File)
+					file = filepath.ToSlash(rel)
+				}
+				lines = append(lines, fmt.Sprintf("%s:%d", file, s.Line))
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("sources = %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+}
+
+func TestExpand_Indent(t *testing.T) {
+	dir := writeFiles(t, snippetFiles)
+	_, sources, _ := Expand("page.md", []byte("!!! note\n    --8<-- \"intro.md\""), Options{BasePaths: []string{dir}})
+	if len(sources) != 3 || sources[1].Indent != 4 || sources[0].Indent != 0 {
+		t.Errorf("sources = %+v, want the included lines indented by 4", sources)
+	}
+}
+
+func TestExpand_NoDirectives(t *testing.T) {
+	content := []byte("Plain page.\n")
+	got, sources, problems := Expand("page.md", content, Options{})
+	if string(got) != string(content) || sources != nil || problems != nil {
+		t.Errorf("Expand() = %q, %v, %v, want the content unchanged", got, sources, problems)
+	}
+}
+
+func TestExpand_Problems(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.md":        "A.\n--8<-- \"b.md\"\n",
+		"b.md":        "B.\n--8<-- \"a.md\"\n",
+		"self.md":     "--8<-- \"self.md\"\n",
+		"sections.md": "No markers.\n",
+		"page.md":     "",
+	})
+	page := filepath.Join(dir, "page.md")
+
+	tests := []struct {
+		name   string
+		page   string
+		want   string
+		err    error
+		file   string // Snippet holding the directive, "" for the page
+		line   int
+		target string
+	}{
+		{name: "missing file", page: "Top.\n--8<-- \"nope.md\"", want: "Top.", err: ErrNotFound, line: 2, target: "nope.md"},
+		{name: "absolute path", page: `--8<-- "/etc/hosts"`, want: "", err: ErrNotFound, line: 1, target: "/etc/hosts"},
+		{name: "missing section", page: `--8<-- "sections.md:usage"`, want: "", err: ErrNoSection, line: 1, target: "sections.md:usage"},
+		{name: "page includes itself", page: `--8<-- "page.md"`, want: "", err: ErrCycle, line: 1, target: "page.md"},
+		{name: "file includes itself", page: `--8<-- "self.md"`, want: "", err: ErrCycle, file: "self.md", line: 1, target: "self.md"},
+		{name: "cycle through others", page: `--8<-- "a.md"`, want: "A.\nB.", err: ErrCycle, file: "b.md", line: 2, target: "a.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, problems := Expand(page, []byte(tt.page), Options{BasePaths: []string{dir}})
+			if string(got) != tt.want {
+				t.Errorf("Expand() = %q, want %q", got, tt.want)
+			}
+			if len(problems) != 1 {
+				t.Fatalf("problems = %+v, want one", problems)
+			}
+			p := problems[0]
+			if !errors.Is(p.Err, tt.err) {
+				t.Errorf("Err = %v, want %v", p.Err, tt.err)
+			}
+			wantFile := page
+			if tt.file != "" {
+				wantFile = filepath.Join(dir, tt.file)
+			}
+			if p.File != wantFile || p.Line != tt.line || p.Target != tt.target {
+				t.Errorf("problem = %s:%d %q, want %s:%d %q", p.File, p.Line, p.Target, wantFile, tt.line, tt.target)
+			}
+		})
+	}
+}
+
+func TestExpand_BasePathOrder(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"first/tip.md":  "First.\n",
+		"second/tip.md": "Second.\n",
+		"second/end.md": "End.\n",
+	})
+	opts := Options{BasePaths: []string{filepath.Join(dir, "first"), filepath.Join(dir, "second")}}
+	got, _, problems := Expand("page.md", []byte("--8<--\ntip.md\nend.md\n--8<--"), opts)
+	if len(problems) > 0 || string(got) != "First.\nEnd." {
+		t.Errorf("Expand() = %q, %+v, want the first base path holding each file", got, problems)
+	}
+}
+
+func TestSources_Locate(t *testing.T) {
+	sources := Sources{{File: "a.md", Line: 1}, {File: "b.md", Line: 4, Indent: 2}}
+	tests := []struct {
+		line int
+		want Source
+	}{
+		{1, Source{File: "a.md", Line: 1}},
+		{2, Source{File: "b.md", Line: 4, Indent: 2}},
+		{9, Source{File: "b.md", Line: 4, Indent: 2}},
+		{0, Source{File: "a.md", Line: 1}},
+	}
+	for _, tt := range tests {
+		if got := sources.Locate(tt.line); got != tt.want {
+			t.Errorf("Locate(%d) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+	if got := Sources(nil).Locate(1); got != (Source{}) {
+		t.Errorf("Locate() on no sources = %+v, want zero", got)
+	}
+}
+
+func FuzzExpand(f *testing.F) {
+	f.Add([]byte("Top.\n--8<-- \"intro.md\"\nEnd."))
+	f.Add([]byte("--8<--\nintro.md\n; skipped.md\n--8<--"))
+	f.Add([]byte("--8<-- \"intro.md:1:2,5:\"\n--8<-- \"intro.md:usage\""))
+	f.Add([]byte(";--8<-- \"intro.md\"\n--8<-- [start:x]"))
+
+	dir := f.TempDir()
+	intro := "Intro.\n<!-- --8<-- [start:usage] -->\nUsage.\n<!-- --8<-- [end:usage] -->\n--8<-- \"intro.md\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "intro.md"), []byte(intro), 0o644); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		got, sources, _ := Expand(filepath.Join(dir, "page.md"), content, Options{BasePaths: []string{dir}})
+		if sources == nil {
+			return
+		}
+		if lines := strings.Count(string(got), "\n") + 1; lines != len(sources) {
+			t.Fatalf("%d lines but %d sources", lines, len(sources))
+		}
+	})
+}